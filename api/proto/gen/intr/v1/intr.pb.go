@@ -20,32 +20,819 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Collection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Uid  int64  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// 收藏夹里面有多少内容
+	ItemCnt int64 `protobuf:"varint,4,opt,name=item_cnt,json=itemCnt,proto3" json:"item_cnt,omitempty"`
+	// 毫秒数
+	Ctime int64 `protobuf:"varint,5,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	Utime int64 `protobuf:"varint,6,opt,name=utime,proto3" json:"utime,omitempty"`
+}
+
+func (x *Collection) Reset() {
+	*x = Collection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Collection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collection) ProtoMessage() {}
+
+func (x *Collection) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collection.ProtoReflect.Descriptor instead.
+func (*Collection) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Collection) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Collection) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *Collection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Collection) GetItemCnt() int64 {
+	if x != nil {
+		return x.ItemCnt
+	}
+	return 0
+}
+
+func (x *Collection) GetCtime() int64 {
+	if x != nil {
+		return x.Ctime
+	}
+	return 0
+}
+
+func (x *Collection) GetUtime() int64 {
+	if x != nil {
+		return x.Utime
+	}
+	return 0
+}
+
+type CollectionItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cid   int64  `protobuf:"varint,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	Biz   string `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId int64  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	// 收藏的时间，毫秒数
+	Ctime int64 `protobuf:"varint,4,opt,name=ctime,proto3" json:"ctime,omitempty"`
+}
+
+func (x *CollectionItem) Reset() {
+	*x = CollectionItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CollectionItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionItem) ProtoMessage() {}
+
+func (x *CollectionItem) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionItem.ProtoReflect.Descriptor instead.
+func (*CollectionItem) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CollectionItem) GetCid() int64 {
+	if x != nil {
+		return x.Cid
+	}
+	return 0
+}
+
+func (x *CollectionItem) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *CollectionItem) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+func (x *CollectionItem) GetCtime() int64 {
+	if x != nil {
+		return x.Ctime
+	}
+	return 0
+}
+
+type CreateCollectionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid  int64  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *CreateCollectionRequest) Reset() {
+	*x = CreateCollectionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCollectionRequest) ProtoMessage() {}
+
+func (x *CreateCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCollectionRequest.ProtoReflect.Descriptor instead.
+func (*CreateCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateCollectionRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *CreateCollectionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateCollectionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CreateCollectionResponse) Reset() {
+	*x = CreateCollectionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCollectionResponse) ProtoMessage() {}
+
+func (x *CreateCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCollectionResponse.ProtoReflect.Descriptor instead.
+func (*CreateCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateCollectionResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RenameCollectionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Uid  int64  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *RenameCollectionRequest) Reset() {
+	*x = RenameCollectionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenameCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameCollectionRequest) ProtoMessage() {}
+
+func (x *RenameCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameCollectionRequest.ProtoReflect.Descriptor instead.
+func (*RenameCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RenameCollectionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RenameCollectionRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *RenameCollectionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RenameCollectionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RenameCollectionResponse) Reset() {
+	*x = RenameCollectionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenameCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameCollectionResponse) ProtoMessage() {}
+
+func (x *RenameCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameCollectionResponse.ProtoReflect.Descriptor instead.
+func (*RenameCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{5}
+}
+
+type DeleteCollectionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id  int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Uid int64 `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+}
+
+func (x *DeleteCollectionRequest) Reset() {
+	*x = DeleteCollectionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCollectionRequest) ProtoMessage() {}
+
+func (x *DeleteCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCollectionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteCollectionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteCollectionRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+type DeleteCollectionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteCollectionResponse) Reset() {
+	*x = DeleteCollectionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCollectionResponse) ProtoMessage() {}
+
+func (x *DeleteCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCollectionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{7}
+}
+
+type ListCollectionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid    int64 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit  int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListCollectionsRequest) Reset() {
+	*x = ListCollectionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCollectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionsRequest) ProtoMessage() {}
+
+func (x *ListCollectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionsRequest.ProtoReflect.Descriptor instead.
+func (*ListCollectionsRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListCollectionsRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *ListCollectionsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListCollectionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListCollectionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Collections []*Collection `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+}
+
+func (x *ListCollectionsResponse) Reset() {
+	*x = ListCollectionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCollectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionsResponse) ProtoMessage() {}
+
+func (x *ListCollectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionsResponse.ProtoReflect.Descriptor instead.
+func (*ListCollectionsResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListCollectionsResponse) GetCollections() []*Collection {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+type ListCollectionItemsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cid    int64 `protobuf:"varint,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	Uid    int64 `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Offset int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit  int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListCollectionItemsRequest) Reset() {
+	*x = ListCollectionItemsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCollectionItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionItemsRequest) ProtoMessage() {}
+
+func (x *ListCollectionItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListCollectionItemsRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListCollectionItemsRequest) GetCid() int64 {
+	if x != nil {
+		return x.Cid
+	}
+	return 0
+}
+
+func (x *ListCollectionItemsRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *ListCollectionItemsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListCollectionItemsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListCollectionItemsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*CollectionItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *ListCollectionItemsResponse) Reset() {
+	*x = ListCollectionItemsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCollectionItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionItemsResponse) ProtoMessage() {}
+
+func (x *ListCollectionItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListCollectionItemsResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListCollectionItemsResponse) GetItems() []*CollectionItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
 type GetByIdsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Biz string  `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
-	Ids []int64 `protobuf:"varint,2,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	Biz string  `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	Ids []int64 `protobuf:"varint,2,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *GetByIdsRequest) Reset() {
+	*x = GetByIdsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetByIdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByIdsRequest) ProtoMessage() {}
+
+func (x *GetByIdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByIdsRequest.ProtoReflect.Descriptor instead.
+func (*GetByIdsRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetByIdsRequest) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *GetByIdsRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type GetByIdsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Intrs map[int64]*Interactive `protobuf:"bytes,1,rep,name=intrs,proto3" json:"intrs,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *GetByIdsResponse) Reset() {
+	*x = GetByIdsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetByIdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByIdsResponse) ProtoMessage() {}
+
+func (x *GetByIdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByIdsResponse.ProtoReflect.Descriptor instead.
+func (*GetByIdsResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetByIdsResponse) GetIntrs() map[int64]*Interactive {
+	if x != nil {
+		return x.Intrs
+	}
+	return nil
+}
+
+type DeleteBizRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Biz   string `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId int64  `protobuf:"varint,2,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
 }
 
-func (x *GetByIdsRequest) Reset() {
-	*x = GetByIdsRequest{}
+func (x *DeleteBizRequest) Reset() {
+	*x = DeleteBizRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_intr_v1_intr_proto_msgTypes[0]
+		mi := &file_intr_v1_intr_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetByIdsRequest) String() string {
+func (x *DeleteBizRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetByIdsRequest) ProtoMessage() {}
+func (*DeleteBizRequest) ProtoMessage() {}
 
-func (x *GetByIdsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[0]
+func (x *DeleteBizRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -56,50 +843,48 @@ func (x *GetByIdsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetByIdsRequest.ProtoReflect.Descriptor instead.
-func (*GetByIdsRequest) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use DeleteBizRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBizRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetByIdsRequest) GetBiz() string {
+func (x *DeleteBizRequest) GetBiz() string {
 	if x != nil {
 		return x.Biz
 	}
 	return ""
 }
 
-func (x *GetByIdsRequest) GetIds() []int64 {
+func (x *DeleteBizRequest) GetBizId() int64 {
 	if x != nil {
-		return x.Ids
+		return x.BizId
 	}
-	return nil
+	return 0
 }
 
-type GetByIdsResponse struct {
+type DeleteBizResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Intrs map[int64]*Interactive `protobuf:"bytes,1,rep,name=intrs,proto3" json:"intrs,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *GetByIdsResponse) Reset() {
-	*x = GetByIdsResponse{}
+func (x *DeleteBizResponse) Reset() {
+	*x = DeleteBizResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_intr_v1_intr_proto_msgTypes[1]
+		mi := &file_intr_v1_intr_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetByIdsResponse) String() string {
+func (x *DeleteBizResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetByIdsResponse) ProtoMessage() {}
+func (*DeleteBizResponse) ProtoMessage() {}
 
-func (x *GetByIdsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[1]
+func (x *DeleteBizResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -110,16 +895,9 @@ func (x *GetByIdsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetByIdsResponse.ProtoReflect.Descriptor instead.
-func (*GetByIdsResponse) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *GetByIdsResponse) GetIntrs() map[int64]*Interactive {
-	if x != nil {
-		return x.Intrs
-	}
-	return nil
+// Deprecated: Use DeleteBizResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBizResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{15}
 }
 
 type GetRequest struct {
@@ -135,7 +913,7 @@ type GetRequest struct {
 func (x *GetRequest) Reset() {
 	*x = GetRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_intr_v1_intr_proto_msgTypes[2]
+		mi := &file_intr_v1_intr_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -148,7 +926,7 @@ func (x *GetRequest) String() string {
 func (*GetRequest) ProtoMessage() {}
 
 func (x *GetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[2]
+	mi := &file_intr_v1_intr_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -161,7 +939,7 @@ func (x *GetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
 func (*GetRequest) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{2}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetRequest) GetBiz() string {
@@ -196,7 +974,7 @@ type GetResponse struct {
 func (x *GetResponse) Reset() {
 	*x = GetResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_intr_v1_intr_proto_msgTypes[3]
+		mi := &file_intr_v1_intr_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -209,7 +987,7 @@ func (x *GetResponse) String() string {
 func (*GetResponse) ProtoMessage() {}
 
 func (x *GetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[3]
+	mi := &file_intr_v1_intr_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -222,7 +1000,7 @@ func (x *GetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
 func (*GetResponse) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{3}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetResponse) GetIntr() *Interactive {
@@ -244,12 +1022,13 @@ type Interactive struct {
 	CollectCnt int64  `protobuf:"varint,5,opt,name=collect_cnt,json=collectCnt,proto3" json:"collect_cnt,omitempty"`
 	Liked      bool   `protobuf:"varint,6,opt,name=liked,proto3" json:"liked,omitempty"`
 	Collected  bool   `protobuf:"varint,7,opt,name=collected,proto3" json:"collected,omitempty"`
+	CommentCnt int64  `protobuf:"varint,8,opt,name=comment_cnt,json=commentCnt,proto3" json:"comment_cnt,omitempty"`
 }
 
 func (x *Interactive) Reset() {
 	*x = Interactive{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_intr_v1_intr_proto_msgTypes[4]
+		mi := &file_intr_v1_intr_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -262,7 +1041,7 @@ func (x *Interactive) String() string {
 func (*Interactive) ProtoMessage() {}
 
 func (x *Interactive) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[4]
+	mi := &file_intr_v1_intr_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -275,7 +1054,7 @@ func (x *Interactive) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Interactive.ProtoReflect.Descriptor instead.
 func (*Interactive) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{4}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *Interactive) GetBiz() string {
@@ -327,6 +1106,13 @@ func (x *Interactive) GetCollected() bool {
 	return false
 }
 
+func (x *Interactive) GetCommentCnt() int64 {
+	if x != nil {
+		return x.CommentCnt
+	}
+	return 0
+}
+
 type CollectRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -341,7 +1127,7 @@ type CollectRequest struct {
 func (x *CollectRequest) Reset() {
 	*x = CollectRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_intr_v1_intr_proto_msgTypes[5]
+		mi := &file_intr_v1_intr_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -354,7 +1140,7 @@ func (x *CollectRequest) String() string {
 func (*CollectRequest) ProtoMessage() {}
 
 func (x *CollectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[5]
+	mi := &file_intr_v1_intr_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -367,7 +1153,7 @@ func (x *CollectRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectRequest.ProtoReflect.Descriptor instead.
 func (*CollectRequest) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{5}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *CollectRequest) GetBiz() string {
@@ -407,7 +1193,7 @@ type CollectResponse struct {
 func (x *CollectResponse) Reset() {
 	*x = CollectResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_intr_v1_intr_proto_msgTypes[6]
+		mi := &file_intr_v1_intr_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -420,7 +1206,7 @@ func (x *CollectResponse) String() string {
 func (*CollectResponse) ProtoMessage() {}
 
 func (x *CollectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[6]
+	mi := &file_intr_v1_intr_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -433,7 +1219,117 @@ func (x *CollectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectResponse.ProtoReflect.Descriptor instead.
 func (*CollectResponse) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{6}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{20}
+}
+
+type CancelCollectRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Biz   string `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId int64  `protobuf:"varint,2,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	Uid   int64  `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	// 为 0 表示不管收藏在哪个收藏夹
+	Cid int64 `protobuf:"varint,4,opt,name=cid,proto3" json:"cid,omitempty"`
+}
+
+func (x *CancelCollectRequest) Reset() {
+	*x = CancelCollectRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelCollectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelCollectRequest) ProtoMessage() {}
+
+func (x *CancelCollectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelCollectRequest.ProtoReflect.Descriptor instead.
+func (*CancelCollectRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CancelCollectRequest) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *CancelCollectRequest) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+func (x *CancelCollectRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *CancelCollectRequest) GetCid() int64 {
+	if x != nil {
+		return x.Cid
+	}
+	return 0
+}
+
+type CancelCollectResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CancelCollectResponse) Reset() {
+	*x = CancelCollectResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelCollectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelCollectResponse) ProtoMessage() {}
+
+func (x *CancelCollectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelCollectResponse.ProtoReflect.Descriptor instead.
+func (*CancelCollectResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{22}
 }
 
 type CancelLikeRequest struct {
@@ -449,7 +1345,7 @@ type CancelLikeRequest struct {
 func (x *CancelLikeRequest) Reset() {
 	*x = CancelLikeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_intr_v1_intr_proto_msgTypes[7]
+		mi := &file_intr_v1_intr_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -462,7 +1358,7 @@ func (x *CancelLikeRequest) String() string {
 func (*CancelLikeRequest) ProtoMessage() {}
 
 func (x *CancelLikeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[7]
+	mi := &file_intr_v1_intr_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -475,7 +1371,7 @@ func (x *CancelLikeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelLikeRequest.ProtoReflect.Descriptor instead.
 func (*CancelLikeRequest) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{7}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *CancelLikeRequest) GetBiz() string {
@@ -508,20 +1404,121 @@ type CancelLikeResponse struct {
 func (x *CancelLikeResponse) Reset() {
 	*x = CancelLikeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_intr_v1_intr_proto_msgTypes[8]
+		mi := &file_intr_v1_intr_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelLikeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelLikeResponse) ProtoMessage() {}
+
+func (x *CancelLikeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelLikeResponse.ProtoReflect.Descriptor instead.
+func (*CancelLikeResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{24}
+}
+
+type LikeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Biz   string `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId int64  `protobuf:"varint,2,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	Uid   int64  `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`
+}
+
+func (x *LikeRequest) Reset() {
+	*x = LikeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LikeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikeRequest) ProtoMessage() {}
+
+func (x *LikeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikeRequest.ProtoReflect.Descriptor instead.
+func (*LikeRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *LikeRequest) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *LikeRequest) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+func (x *LikeRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+type LikeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *LikeResponse) Reset() {
+	*x = LikeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_intr_v1_intr_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CancelLikeResponse) String() string {
+func (x *LikeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelLikeResponse) ProtoMessage() {}
+func (*LikeResponse) ProtoMessage() {}
 
-func (x *CancelLikeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[8]
+func (x *LikeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -532,38 +1529,38 @@ func (x *CancelLikeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelLikeResponse.ProtoReflect.Descriptor instead.
-func (*CancelLikeResponse) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use LikeResponse.ProtoReflect.Descriptor instead.
+func (*LikeResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{26}
 }
 
-type LikeRequest struct {
+type IncrCommentCntRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Biz   string `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
 	BizId int64  `protobuf:"varint,2,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
-	Uid   int64  `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Delta int64  `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
 }
 
-func (x *LikeRequest) Reset() {
-	*x = LikeRequest{}
+func (x *IncrCommentCntRequest) Reset() {
+	*x = IncrCommentCntRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_intr_v1_intr_proto_msgTypes[9]
+		mi := &file_intr_v1_intr_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LikeRequest) String() string {
+func (x *IncrCommentCntRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LikeRequest) ProtoMessage() {}
+func (*IncrCommentCntRequest) ProtoMessage() {}
 
-func (x *LikeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[9]
+func (x *IncrCommentCntRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -574,55 +1571,55 @@ func (x *LikeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LikeRequest.ProtoReflect.Descriptor instead.
-func (*LikeRequest) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use IncrCommentCntRequest.ProtoReflect.Descriptor instead.
+func (*IncrCommentCntRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *LikeRequest) GetBiz() string {
+func (x *IncrCommentCntRequest) GetBiz() string {
 	if x != nil {
 		return x.Biz
 	}
 	return ""
 }
 
-func (x *LikeRequest) GetBizId() int64 {
+func (x *IncrCommentCntRequest) GetBizId() int64 {
 	if x != nil {
 		return x.BizId
 	}
 	return 0
 }
 
-func (x *LikeRequest) GetUid() int64 {
+func (x *IncrCommentCntRequest) GetDelta() int64 {
 	if x != nil {
-		return x.Uid
+		return x.Delta
 	}
 	return 0
 }
 
-type LikeResponse struct {
+type IncrCommentCntResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *LikeResponse) Reset() {
-	*x = LikeResponse{}
+func (x *IncrCommentCntResponse) Reset() {
+	*x = IncrCommentCntResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_intr_v1_intr_proto_msgTypes[10]
+		mi := &file_intr_v1_intr_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LikeResponse) String() string {
+func (x *IncrCommentCntResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LikeResponse) ProtoMessage() {}
+func (*IncrCommentCntResponse) ProtoMessage() {}
 
-func (x *LikeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[10]
+func (x *IncrCommentCntResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -633,9 +1630,9 @@ func (x *LikeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LikeResponse.ProtoReflect.Descriptor instead.
-func (*LikeResponse) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use IncrCommentCntResponse.ProtoReflect.Descriptor instead.
+func (*IncrCommentCntResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{28}
 }
 
 type IncrReadCntRequest struct {
@@ -650,7 +1647,7 @@ type IncrReadCntRequest struct {
 func (x *IncrReadCntRequest) Reset() {
 	*x = IncrReadCntRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_intr_v1_intr_proto_msgTypes[11]
+		mi := &file_intr_v1_intr_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -663,7 +1660,7 @@ func (x *IncrReadCntRequest) String() string {
 func (*IncrReadCntRequest) ProtoMessage() {}
 
 func (x *IncrReadCntRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[11]
+	mi := &file_intr_v1_intr_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -676,7 +1673,7 @@ func (x *IncrReadCntRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IncrReadCntRequest.ProtoReflect.Descriptor instead.
 func (*IncrReadCntRequest) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{11}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *IncrReadCntRequest) GetBiz() string {
@@ -702,7 +1699,7 @@ type IncrReadCntResponse struct {
 func (x *IncrReadCntResponse) Reset() {
 	*x = IncrReadCntResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_intr_v1_intr_proto_msgTypes[12]
+		mi := &file_intr_v1_intr_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -715,7 +1712,7 @@ func (x *IncrReadCntResponse) String() string {
 func (*IncrReadCntResponse) ProtoMessage() {}
 
 func (x *IncrReadCntResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[12]
+	mi := &file_intr_v1_intr_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -728,99 +1725,221 @@ func (x *IncrReadCntResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IncrReadCntResponse.ProtoReflect.Descriptor instead.
 func (*IncrReadCntResponse) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{12}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{30}
 }
 
 var File_intr_v1_intr_proto protoreflect.FileDescriptor
 
 var file_intr_v1_intr_proto_rawDesc = []byte{
 	0x0a, 0x12, 0x69, 0x6e, 0x74, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x22, 0x35, 0x0a,
-	0x0f, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62,
-	0x69, 0x7a, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52,
-	0x03, 0x69, 0x64, 0x73, 0x22, 0x9e, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x64,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x05, 0x69, 0x6e, 0x74,
-	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x2e, 0x49, 0x6e, 0x74, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05,
-	0x69, 0x6e, 0x74, 0x72, 0x73, 0x1a, 0x4e, 0x0a, 0x0a, 0x49, 0x6e, 0x74, 0x72, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49,
-	0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x47, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x22, 0x89, 0x01,
+	0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x63, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x69, 0x74, 0x65, 0x6d, 0x43, 0x6e, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x75, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x61, 0x0a, 0x0e, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x63,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x62, 0x69, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12,
+	0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x3f, 0x0a, 0x17,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x2a, 0x0a,
+	0x18, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x4f, 0x0a, 0x17, 0x52, 0x65, 0x6e,
+	0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x1a, 0x0a, 0x18, 0x52, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3b, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03,
+	0x75, 0x69, 0x64, 0x22, 0x1a, 0x0a, 0x18, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x58, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x50, 0x0a, 0x17, 0x4c, 0x69, 0x73,
+	0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x6e, 0x74, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x6e, 0x0a, 0x1a, 0x4c,
+	0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x74, 0x65,
+	0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x4c, 0x0a, 0x1b, 0x4c,
+	0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x74, 0x65,
+	0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6e, 0x74, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x74,
+	0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x35, 0x0a, 0x0f, 0x47, 0x65, 0x74,
+	0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x10,
+	0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x03, 0x69, 0x64, 0x73,
+	0x22, 0x9e, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x05, 0x69, 0x6e, 0x74, 0x72, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x49, 0x6e, 0x74, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x69, 0x6e, 0x74, 0x72,
+	0x73, 0x1a, 0x4e, 0x0a, 0x0a, 0x49, 0x6e, 0x74, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x3b, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x69, 0x7a, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x22, 0x13,
+	0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x69, 0x7a, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x47, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x22, 0x37, 0x0a, 0x0b,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x69,
+	0x6e, 0x74, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6e, 0x74, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52,
+	0x04, 0x69, 0x6e, 0x74, 0x72, 0x22, 0xe2, 0x01, 0x0a, 0x0b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x19,
+	0x0a, 0x08, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x63, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x69, 0x6b,
+	0x65, 0x5f, 0x63, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x69, 0x6b,
+	0x65, 0x43, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x5f,
+	0x63, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x43, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6b, 0x65, 0x64, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6c, 0x69, 0x6b, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x6e, 0x74, 0x22, 0x5d, 0x0a, 0x0e, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15,
+	0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x63, 0x69, 0x64, 0x22, 0x11, 0x0a, 0x0f, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x63, 0x0a, 0x14,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x63, 0x69,
+	0x64, 0x22, 0x17, 0x0a, 0x15, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4e, 0x0a, 0x11, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69,
+	0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x22, 0x14, 0x0a, 0x12, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x48, 0x0a, 0x0b, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69,
+	0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x22, 0x0e, 0x0a, 0x0c, 0x4c, 0x69,
+	0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x56, 0x0a, 0x15, 0x49, 0x6e,
+	0x63, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03,
-	0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x22, 0x37,
-	0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a,
-	0x04, 0x69, 0x6e, 0x74, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6e,
-	0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76,
-	0x65, 0x52, 0x04, 0x69, 0x6e, 0x74, 0x72, 0x22, 0xc1, 0x01, 0x0a, 0x0b, 0x49, 0x6e, 0x74, 0x65,
-	0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a,
-	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64,
-	0x12, 0x19, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x63, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6c,
-	0x69, 0x6b, 0x65, 0x5f, 0x63, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c,
-	0x69, 0x6b, 0x65, 0x43, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x5f, 0x63, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x43, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6b, 0x65, 0x64,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6c, 0x69, 0x6b, 0x65, 0x64, 0x12, 0x1c, 0x0a,
-	0x09, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x09, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x22, 0x5d, 0x0a, 0x0e, 0x43,
-	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
-	0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12,
-	0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x63, 0x69, 0x64, 0x22, 0x11, 0x0a, 0x0f, 0x43, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4e, 0x0a,
-	0x11, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75,
-	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x22, 0x14, 0x0a,
-	0x12, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x48, 0x0a, 0x0b, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x64, 0x65, 0x6c,
+	0x74, 0x61, 0x22, 0x18, 0x0a, 0x16, 0x49, 0x6e, 0x63, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x43, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3d, 0x0a, 0x12,
+	0x49, 0x6e, 0x63, 0x72, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75,
-	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x22, 0x0e, 0x0a,
-	0x0c, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3d, 0x0a,
-	0x12, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x22, 0x15, 0x0a, 0x13,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x49,
+	0x6e, 0x63, 0x72, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x32, 0xb5, 0x08, 0x0a, 0x12, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x49, 0x6e, 0x63,
+	0x72, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6e, 0x74, 0x12, 0x1b, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e,
 	0x49, 0x6e, 0x63, 0x72, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x32, 0x8b, 0x03, 0x0a, 0x12, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74,
-	0x69, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x49, 0x6e,
-	0x63, 0x72, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6e, 0x74, 0x12, 0x1b, 0x2e, 0x69, 0x6e, 0x74, 0x72,
-	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6e, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x04, 0x4c, 0x69, 0x6b, 0x65, 0x12, 0x14, 0x2e, 0x69,
-	0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6b,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x0a, 0x43, 0x61, 0x6e,
-	0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x12, 0x1a, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61,
-	0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x3c, 0x0a, 0x07, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x17, 0x2e, 0x69, 0x6e,
-	0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43,
-	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30,
-	0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x13, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e,
-	0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x69, 0x6e, 0x74,
-	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x3f, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x64, 0x73, 0x12, 0x18, 0x2e, 0x69,
-	0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x42, 0x10, 0x5a, 0x0e, 0x69, 0x6e, 0x74, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x69, 0x6e, 0x74,
-	0x72, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x04, 0x4c, 0x69, 0x6b, 0x65, 0x12, 0x14, 0x2e, 0x69, 0x6e,
+	0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x15, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6b, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x0a, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x12, 0x1a, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3c, 0x0a, 0x07, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x17, 0x2e, 0x69, 0x6e, 0x74,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a,
+	0x0d, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x1d,
+	0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a,
+	0x03, 0x47, 0x65, 0x74, 0x12, 0x13, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x69, 0x6e, 0x74, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3f, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x64, 0x73, 0x12, 0x18, 0x2e, 0x69, 0x6e,
+	0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x42, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x69, 0x7a, 0x12, 0x19, 0x2e,
+	0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x69,
+	0x7a, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x69, 0x7a, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0e, 0x49, 0x6e, 0x63, 0x72, 0x43, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x43, 0x6e, 0x74, 0x12, 0x1e, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x49, 0x6e, 0x63, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x6e, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x49, 0x6e, 0x63, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x6e, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x2e, 0x69, 0x6e,
+	0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
+	0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x57, 0x0a, 0x10, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x10, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x2e,
+	0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x21, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x54, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12,
+	0x23, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x74, 0x65,
+	0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x10, 0x5a, 0x0e, 0x69, 0x6e,
+	0x74, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x69, 0x6e, 0x74, 0x72, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -835,44 +1954,80 @@ func file_intr_v1_intr_proto_rawDescGZIP() []byte {
 	return file_intr_v1_intr_proto_rawDescData
 }
 
-var file_intr_v1_intr_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_intr_v1_intr_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_intr_v1_intr_proto_goTypes = []any{
-	(*GetByIdsRequest)(nil),     // 0: intr.v1.GetByIdsRequest
-	(*GetByIdsResponse)(nil),    // 1: intr.v1.GetByIdsResponse
-	(*GetRequest)(nil),          // 2: intr.v1.GetRequest
-	(*GetResponse)(nil),         // 3: intr.v1.GetResponse
-	(*Interactive)(nil),         // 4: intr.v1.Interactive
-	(*CollectRequest)(nil),      // 5: intr.v1.CollectRequest
-	(*CollectResponse)(nil),     // 6: intr.v1.CollectResponse
-	(*CancelLikeRequest)(nil),   // 7: intr.v1.CancelLikeRequest
-	(*CancelLikeResponse)(nil),  // 8: intr.v1.CancelLikeResponse
-	(*LikeRequest)(nil),         // 9: intr.v1.LikeRequest
-	(*LikeResponse)(nil),        // 10: intr.v1.LikeResponse
-	(*IncrReadCntRequest)(nil),  // 11: intr.v1.IncrReadCntRequest
-	(*IncrReadCntResponse)(nil), // 12: intr.v1.IncrReadCntResponse
-	nil,                         // 13: intr.v1.GetByIdsResponse.IntrsEntry
+	(*Collection)(nil),                  // 0: intr.v1.Collection
+	(*CollectionItem)(nil),              // 1: intr.v1.CollectionItem
+	(*CreateCollectionRequest)(nil),     // 2: intr.v1.CreateCollectionRequest
+	(*CreateCollectionResponse)(nil),    // 3: intr.v1.CreateCollectionResponse
+	(*RenameCollectionRequest)(nil),     // 4: intr.v1.RenameCollectionRequest
+	(*RenameCollectionResponse)(nil),    // 5: intr.v1.RenameCollectionResponse
+	(*DeleteCollectionRequest)(nil),     // 6: intr.v1.DeleteCollectionRequest
+	(*DeleteCollectionResponse)(nil),    // 7: intr.v1.DeleteCollectionResponse
+	(*ListCollectionsRequest)(nil),      // 8: intr.v1.ListCollectionsRequest
+	(*ListCollectionsResponse)(nil),     // 9: intr.v1.ListCollectionsResponse
+	(*ListCollectionItemsRequest)(nil),  // 10: intr.v1.ListCollectionItemsRequest
+	(*ListCollectionItemsResponse)(nil), // 11: intr.v1.ListCollectionItemsResponse
+	(*GetByIdsRequest)(nil),             // 12: intr.v1.GetByIdsRequest
+	(*GetByIdsResponse)(nil),            // 13: intr.v1.GetByIdsResponse
+	(*DeleteBizRequest)(nil),            // 14: intr.v1.DeleteBizRequest
+	(*DeleteBizResponse)(nil),           // 15: intr.v1.DeleteBizResponse
+	(*GetRequest)(nil),                  // 16: intr.v1.GetRequest
+	(*GetResponse)(nil),                 // 17: intr.v1.GetResponse
+	(*Interactive)(nil),                 // 18: intr.v1.Interactive
+	(*CollectRequest)(nil),              // 19: intr.v1.CollectRequest
+	(*CollectResponse)(nil),             // 20: intr.v1.CollectResponse
+	(*CancelCollectRequest)(nil),        // 21: intr.v1.CancelCollectRequest
+	(*CancelCollectResponse)(nil),       // 22: intr.v1.CancelCollectResponse
+	(*CancelLikeRequest)(nil),           // 23: intr.v1.CancelLikeRequest
+	(*CancelLikeResponse)(nil),          // 24: intr.v1.CancelLikeResponse
+	(*LikeRequest)(nil),                 // 25: intr.v1.LikeRequest
+	(*LikeResponse)(nil),                // 26: intr.v1.LikeResponse
+	(*IncrCommentCntRequest)(nil),       // 27: intr.v1.IncrCommentCntRequest
+	(*IncrCommentCntResponse)(nil),      // 28: intr.v1.IncrCommentCntResponse
+	(*IncrReadCntRequest)(nil),          // 29: intr.v1.IncrReadCntRequest
+	(*IncrReadCntResponse)(nil),         // 30: intr.v1.IncrReadCntResponse
+	nil,                                 // 31: intr.v1.GetByIdsResponse.IntrsEntry
 }
 var file_intr_v1_intr_proto_depIdxs = []int32{
-	13, // 0: intr.v1.GetByIdsResponse.intrs:type_name -> intr.v1.GetByIdsResponse.IntrsEntry
-	4,  // 1: intr.v1.GetResponse.intr:type_name -> intr.v1.Interactive
-	4,  // 2: intr.v1.GetByIdsResponse.IntrsEntry.value:type_name -> intr.v1.Interactive
-	11, // 3: intr.v1.InteractiveService.IncrReadCnt:input_type -> intr.v1.IncrReadCntRequest
-	9,  // 4: intr.v1.InteractiveService.Like:input_type -> intr.v1.LikeRequest
-	7,  // 5: intr.v1.InteractiveService.CancelLike:input_type -> intr.v1.CancelLikeRequest
-	5,  // 6: intr.v1.InteractiveService.Collect:input_type -> intr.v1.CollectRequest
-	2,  // 7: intr.v1.InteractiveService.Get:input_type -> intr.v1.GetRequest
-	0,  // 8: intr.v1.InteractiveService.GetByIds:input_type -> intr.v1.GetByIdsRequest
-	12, // 9: intr.v1.InteractiveService.IncrReadCnt:output_type -> intr.v1.IncrReadCntResponse
-	10, // 10: intr.v1.InteractiveService.Like:output_type -> intr.v1.LikeResponse
-	8,  // 11: intr.v1.InteractiveService.CancelLike:output_type -> intr.v1.CancelLikeResponse
-	6,  // 12: intr.v1.InteractiveService.Collect:output_type -> intr.v1.CollectResponse
-	3,  // 13: intr.v1.InteractiveService.Get:output_type -> intr.v1.GetResponse
-	1,  // 14: intr.v1.InteractiveService.GetByIds:output_type -> intr.v1.GetByIdsResponse
-	9,  // [9:15] is the sub-list for method output_type
-	3,  // [3:9] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	0,  // 0: intr.v1.ListCollectionsResponse.collections:type_name -> intr.v1.Collection
+	1,  // 1: intr.v1.ListCollectionItemsResponse.items:type_name -> intr.v1.CollectionItem
+	31, // 2: intr.v1.GetByIdsResponse.intrs:type_name -> intr.v1.GetByIdsResponse.IntrsEntry
+	18, // 3: intr.v1.GetResponse.intr:type_name -> intr.v1.Interactive
+	18, // 4: intr.v1.GetByIdsResponse.IntrsEntry.value:type_name -> intr.v1.Interactive
+	29, // 5: intr.v1.InteractiveService.IncrReadCnt:input_type -> intr.v1.IncrReadCntRequest
+	25, // 6: intr.v1.InteractiveService.Like:input_type -> intr.v1.LikeRequest
+	23, // 7: intr.v1.InteractiveService.CancelLike:input_type -> intr.v1.CancelLikeRequest
+	19, // 8: intr.v1.InteractiveService.Collect:input_type -> intr.v1.CollectRequest
+	21, // 9: intr.v1.InteractiveService.CancelCollect:input_type -> intr.v1.CancelCollectRequest
+	16, // 10: intr.v1.InteractiveService.Get:input_type -> intr.v1.GetRequest
+	12, // 11: intr.v1.InteractiveService.GetByIds:input_type -> intr.v1.GetByIdsRequest
+	14, // 12: intr.v1.InteractiveService.DeleteBiz:input_type -> intr.v1.DeleteBizRequest
+	27, // 13: intr.v1.InteractiveService.IncrCommentCnt:input_type -> intr.v1.IncrCommentCntRequest
+	2,  // 14: intr.v1.InteractiveService.CreateCollection:input_type -> intr.v1.CreateCollectionRequest
+	4,  // 15: intr.v1.InteractiveService.RenameCollection:input_type -> intr.v1.RenameCollectionRequest
+	6,  // 16: intr.v1.InteractiveService.DeleteCollection:input_type -> intr.v1.DeleteCollectionRequest
+	8,  // 17: intr.v1.InteractiveService.ListCollections:input_type -> intr.v1.ListCollectionsRequest
+	10, // 18: intr.v1.InteractiveService.ListCollectionItems:input_type -> intr.v1.ListCollectionItemsRequest
+	30, // 19: intr.v1.InteractiveService.IncrReadCnt:output_type -> intr.v1.IncrReadCntResponse
+	26, // 20: intr.v1.InteractiveService.Like:output_type -> intr.v1.LikeResponse
+	24, // 21: intr.v1.InteractiveService.CancelLike:output_type -> intr.v1.CancelLikeResponse
+	20, // 22: intr.v1.InteractiveService.Collect:output_type -> intr.v1.CollectResponse
+	22, // 23: intr.v1.InteractiveService.CancelCollect:output_type -> intr.v1.CancelCollectResponse
+	17, // 24: intr.v1.InteractiveService.Get:output_type -> intr.v1.GetResponse
+	13, // 25: intr.v1.InteractiveService.GetByIds:output_type -> intr.v1.GetByIdsResponse
+	15, // 26: intr.v1.InteractiveService.DeleteBiz:output_type -> intr.v1.DeleteBizResponse
+	28, // 27: intr.v1.InteractiveService.IncrCommentCnt:output_type -> intr.v1.IncrCommentCntResponse
+	3,  // 28: intr.v1.InteractiveService.CreateCollection:output_type -> intr.v1.CreateCollectionResponse
+	5,  // 29: intr.v1.InteractiveService.RenameCollection:output_type -> intr.v1.RenameCollectionResponse
+	7,  // 30: intr.v1.InteractiveService.DeleteCollection:output_type -> intr.v1.DeleteCollectionResponse
+	9,  // 31: intr.v1.InteractiveService.ListCollections:output_type -> intr.v1.ListCollectionsResponse
+	11, // 32: intr.v1.InteractiveService.ListCollectionItems:output_type -> intr.v1.ListCollectionItemsResponse
+	19, // [19:33] is the sub-list for method output_type
+	5,  // [5:19] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_intr_v1_intr_proto_init() }
@@ -882,7 +2037,7 @@ func file_intr_v1_intr_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_intr_v1_intr_proto_msgTypes[0].Exporter = func(v any, i int) any {
-			switch v := v.(*GetByIdsRequest); i {
+			switch v := v.(*Collection); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -894,7 +2049,7 @@ func file_intr_v1_intr_proto_init() {
 			}
 		}
 		file_intr_v1_intr_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*GetByIdsResponse); i {
+			switch v := v.(*CollectionItem); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -906,7 +2061,7 @@ func file_intr_v1_intr_proto_init() {
 			}
 		}
 		file_intr_v1_intr_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*GetRequest); i {
+			switch v := v.(*CreateCollectionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -918,7 +2073,7 @@ func file_intr_v1_intr_proto_init() {
 			}
 		}
 		file_intr_v1_intr_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*GetResponse); i {
+			switch v := v.(*CreateCollectionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -930,7 +2085,7 @@ func file_intr_v1_intr_proto_init() {
 			}
 		}
 		file_intr_v1_intr_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*Interactive); i {
+			switch v := v.(*RenameCollectionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -942,7 +2097,7 @@ func file_intr_v1_intr_proto_init() {
 			}
 		}
 		file_intr_v1_intr_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*CollectRequest); i {
+			switch v := v.(*RenameCollectionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -954,7 +2109,7 @@ func file_intr_v1_intr_proto_init() {
 			}
 		}
 		file_intr_v1_intr_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*CollectResponse); i {
+			switch v := v.(*DeleteCollectionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -966,7 +2121,7 @@ func file_intr_v1_intr_proto_init() {
 			}
 		}
 		file_intr_v1_intr_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*CancelLikeRequest); i {
+			switch v := v.(*DeleteCollectionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -978,7 +2133,7 @@ func file_intr_v1_intr_proto_init() {
 			}
 		}
 		file_intr_v1_intr_proto_msgTypes[8].Exporter = func(v any, i int) any {
-			switch v := v.(*CancelLikeResponse); i {
+			switch v := v.(*ListCollectionsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -990,7 +2145,7 @@ func file_intr_v1_intr_proto_init() {
 			}
 		}
 		file_intr_v1_intr_proto_msgTypes[9].Exporter = func(v any, i int) any {
-			switch v := v.(*LikeRequest); i {
+			switch v := v.(*ListCollectionsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1002,7 +2157,7 @@ func file_intr_v1_intr_proto_init() {
 			}
 		}
 		file_intr_v1_intr_proto_msgTypes[10].Exporter = func(v any, i int) any {
-			switch v := v.(*LikeResponse); i {
+			switch v := v.(*ListCollectionItemsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1014,7 +2169,7 @@ func file_intr_v1_intr_proto_init() {
 			}
 		}
 		file_intr_v1_intr_proto_msgTypes[11].Exporter = func(v any, i int) any {
-			switch v := v.(*IncrReadCntRequest); i {
+			switch v := v.(*ListCollectionItemsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1026,6 +2181,222 @@ func file_intr_v1_intr_proto_init() {
 			}
 		}
 		file_intr_v1_intr_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*GetByIdsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*GetByIdsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteBizRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteBizResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*GetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*GetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*Interactive); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*CollectRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*CollectResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*CancelCollectRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*CancelCollectResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*CancelLikeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*CancelLikeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*LikeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*LikeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*IncrCommentCntRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*IncrCommentCntResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*IncrReadCntRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_intr_v1_intr_proto_msgTypes[30].Exporter = func(v any, i int) any {
 			switch v := v.(*IncrReadCntResponse); i {
 			case 0:
 				return &v.state
@@ -1044,7 +2415,7 @@ func file_intr_v1_intr_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_intr_v1_intr_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,12 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	InteractiveService_IncrReadCnt_FullMethodName = "/intr.v1.InteractiveService/IncrReadCnt"
-	InteractiveService_Like_FullMethodName        = "/intr.v1.InteractiveService/Like"
-	InteractiveService_CancelLike_FullMethodName  = "/intr.v1.InteractiveService/CancelLike"
-	InteractiveService_Collect_FullMethodName     = "/intr.v1.InteractiveService/Collect"
-	InteractiveService_Get_FullMethodName         = "/intr.v1.InteractiveService/Get"
-	InteractiveService_GetByIds_FullMethodName    = "/intr.v1.InteractiveService/GetByIds"
+	InteractiveService_IncrReadCnt_FullMethodName         = "/intr.v1.InteractiveService/IncrReadCnt"
+	InteractiveService_Like_FullMethodName                = "/intr.v1.InteractiveService/Like"
+	InteractiveService_CancelLike_FullMethodName          = "/intr.v1.InteractiveService/CancelLike"
+	InteractiveService_Collect_FullMethodName             = "/intr.v1.InteractiveService/Collect"
+	InteractiveService_CancelCollect_FullMethodName       = "/intr.v1.InteractiveService/CancelCollect"
+	InteractiveService_Get_FullMethodName                 = "/intr.v1.InteractiveService/Get"
+	InteractiveService_GetByIds_FullMethodName            = "/intr.v1.InteractiveService/GetByIds"
+	InteractiveService_DeleteBiz_FullMethodName           = "/intr.v1.InteractiveService/DeleteBiz"
+	InteractiveService_IncrCommentCnt_FullMethodName      = "/intr.v1.InteractiveService/IncrCommentCnt"
+	InteractiveService_CreateCollection_FullMethodName    = "/intr.v1.InteractiveService/CreateCollection"
+	InteractiveService_RenameCollection_FullMethodName    = "/intr.v1.InteractiveService/RenameCollection"
+	InteractiveService_DeleteCollection_FullMethodName    = "/intr.v1.InteractiveService/DeleteCollection"
+	InteractiveService_ListCollections_FullMethodName     = "/intr.v1.InteractiveService/ListCollections"
+	InteractiveService_ListCollectionItems_FullMethodName = "/intr.v1.InteractiveService/ListCollectionItems"
 )
 
 // InteractiveServiceClient is the client API for InteractiveService service.
@@ -38,8 +46,24 @@ type InteractiveServiceClient interface {
 	CancelLike(ctx context.Context, in *CancelLikeRequest, opts ...grpc.CallOption) (*CancelLikeResponse, error)
 	// Collect 收藏
 	Collect(ctx context.Context, in *CollectRequest, opts ...grpc.CallOption) (*CollectResponse, error)
+	// CancelCollect 取消收藏
+	CancelCollect(ctx context.Context, in *CancelCollectRequest, opts ...grpc.CallOption) (*CancelCollectResponse, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	GetByIds(ctx context.Context, in *GetByIdsRequest, opts ...grpc.CallOption) (*GetByIdsResponse, error)
+	// DeleteBiz 资源被彻底删除，删掉它的计数、点赞和收藏记录
+	DeleteBiz(ctx context.Context, in *DeleteBizRequest, opts ...grpc.CallOption) (*DeleteBizResponse, error)
+	// IncrCommentCnt 调整评论数，delta 为负数表示评论被删除
+	IncrCommentCnt(ctx context.Context, in *IncrCommentCntRequest, opts ...grpc.CallOption) (*IncrCommentCntResponse, error)
+	// CreateCollection 创建收藏夹
+	CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CreateCollectionResponse, error)
+	// RenameCollection 重命名收藏夹
+	RenameCollection(ctx context.Context, in *RenameCollectionRequest, opts ...grpc.CallOption) (*RenameCollectionResponse, error)
+	// DeleteCollection 删除收藏夹，收藏夹里面的内容也一并取消收藏
+	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
+	// ListCollections 分页获取用户的收藏夹
+	ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error)
+	// ListCollectionItems 分页获取收藏夹里面的内容
+	ListCollectionItems(ctx context.Context, in *ListCollectionItemsRequest, opts ...grpc.CallOption) (*ListCollectionItemsResponse, error)
 }
 
 type interactiveServiceClient struct {
@@ -90,6 +114,16 @@ func (c *interactiveServiceClient) Collect(ctx context.Context, in *CollectReque
 	return out, nil
 }
 
+func (c *interactiveServiceClient) CancelCollect(ctx context.Context, in *CancelCollectRequest, opts ...grpc.CallOption) (*CancelCollectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelCollectResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_CancelCollect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *interactiveServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetResponse)
@@ -110,6 +144,76 @@ func (c *interactiveServiceClient) GetByIds(ctx context.Context, in *GetByIdsReq
 	return out, nil
 }
 
+func (c *interactiveServiceClient) DeleteBiz(ctx context.Context, in *DeleteBizRequest, opts ...grpc.CallOption) (*DeleteBizResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBizResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_DeleteBiz_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) IncrCommentCnt(ctx context.Context, in *IncrCommentCntRequest, opts ...grpc.CallOption) (*IncrCommentCntResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncrCommentCntResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_IncrCommentCnt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CreateCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCollectionResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_CreateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) RenameCollection(ctx context.Context, in *RenameCollectionRequest, opts ...grpc.CallOption) (*RenameCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenameCollectionResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_RenameCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCollectionResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_DeleteCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListCollectionItems(ctx context.Context, in *ListCollectionItemsRequest, opts ...grpc.CallOption) (*ListCollectionItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionItemsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListCollectionItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InteractiveServiceServer is the server API for InteractiveService service.
 // All implementations must embed UnimplementedInteractiveServiceServer
 // for forward compatibility
@@ -121,8 +225,24 @@ type InteractiveServiceServer interface {
 	CancelLike(context.Context, *CancelLikeRequest) (*CancelLikeResponse, error)
 	// Collect 收藏
 	Collect(context.Context, *CollectRequest) (*CollectResponse, error)
+	// CancelCollect 取消收藏
+	CancelCollect(context.Context, *CancelCollectRequest) (*CancelCollectResponse, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	GetByIds(context.Context, *GetByIdsRequest) (*GetByIdsResponse, error)
+	// DeleteBiz 资源被彻底删除，删掉它的计数、点赞和收藏记录
+	DeleteBiz(context.Context, *DeleteBizRequest) (*DeleteBizResponse, error)
+	// IncrCommentCnt 调整评论数，delta 为负数表示评论被删除
+	IncrCommentCnt(context.Context, *IncrCommentCntRequest) (*IncrCommentCntResponse, error)
+	// CreateCollection 创建收藏夹
+	CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error)
+	// RenameCollection 重命名收藏夹
+	RenameCollection(context.Context, *RenameCollectionRequest) (*RenameCollectionResponse, error)
+	// DeleteCollection 删除收藏夹，收藏夹里面的内容也一并取消收藏
+	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error)
+	// ListCollections 分页获取用户的收藏夹
+	ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error)
+	// ListCollectionItems 分页获取收藏夹里面的内容
+	ListCollectionItems(context.Context, *ListCollectionItemsRequest) (*ListCollectionItemsResponse, error)
 	mustEmbedUnimplementedInteractiveServiceServer()
 }
 
@@ -142,12 +262,36 @@ func (UnimplementedInteractiveServiceServer) CancelLike(context.Context, *Cancel
 func (UnimplementedInteractiveServiceServer) Collect(context.Context, *CollectRequest) (*CollectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Collect not implemented")
 }
+func (UnimplementedInteractiveServiceServer) CancelCollect(context.Context, *CancelCollectRequest) (*CancelCollectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelCollect not implemented")
+}
 func (UnimplementedInteractiveServiceServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
 func (UnimplementedInteractiveServiceServer) GetByIds(context.Context, *GetByIdsRequest) (*GetByIdsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetByIds not implemented")
 }
+func (UnimplementedInteractiveServiceServer) DeleteBiz(context.Context, *DeleteBizRequest) (*DeleteBizResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBiz not implemented")
+}
+func (UnimplementedInteractiveServiceServer) IncrCommentCnt(context.Context, *IncrCommentCntRequest) (*IncrCommentCntResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IncrCommentCnt not implemented")
+}
+func (UnimplementedInteractiveServiceServer) CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedInteractiveServiceServer) RenameCollection(context.Context, *RenameCollectionRequest) (*RenameCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameCollection not implemented")
+}
+func (UnimplementedInteractiveServiceServer) DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollection not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollections not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListCollectionItems(context.Context, *ListCollectionItemsRequest) (*ListCollectionItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollectionItems not implemented")
+}
 func (UnimplementedInteractiveServiceServer) mustEmbedUnimplementedInteractiveServiceServer() {}
 
 // UnsafeInteractiveServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -233,6 +377,24 @@ func _InteractiveService_Collect_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InteractiveService_CancelCollect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelCollectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).CancelCollect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_CancelCollect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).CancelCollect(ctx, req.(*CancelCollectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InteractiveService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRequest)
 	if err := dec(in); err != nil {
@@ -269,6 +431,132 @@ func _InteractiveService_GetByIds_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InteractiveService_DeleteBiz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBizRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).DeleteBiz(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_DeleteBiz_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).DeleteBiz(ctx, req.(*DeleteBizRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_IncrCommentCnt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrCommentCntRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).IncrCommentCnt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_IncrCommentCnt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).IncrCommentCnt(ctx, req.(*IncrCommentCntRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).CreateCollection(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_RenameCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).RenameCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_RenameCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).RenameCollection(ctx, req.(*RenameCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).DeleteCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_DeleteCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).DeleteCollection(ctx, req.(*DeleteCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListCollections(ctx, req.(*ListCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListCollectionItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListCollectionItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListCollectionItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListCollectionItems(ctx, req.(*ListCollectionItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InteractiveService_ServiceDesc is the grpc.ServiceDesc for InteractiveService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -292,6 +580,10 @@ var InteractiveService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Collect",
 			Handler:    _InteractiveService_Collect_Handler,
 		},
+		{
+			MethodName: "CancelCollect",
+			Handler:    _InteractiveService_CancelCollect_Handler,
+		},
 		{
 			MethodName: "Get",
 			Handler:    _InteractiveService_Get_Handler,
@@ -300,6 +592,34 @@ var InteractiveService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetByIds",
 			Handler:    _InteractiveService_GetByIds_Handler,
 		},
+		{
+			MethodName: "DeleteBiz",
+			Handler:    _InteractiveService_DeleteBiz_Handler,
+		},
+		{
+			MethodName: "IncrCommentCnt",
+			Handler:    _InteractiveService_IncrCommentCnt_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _InteractiveService_CreateCollection_Handler,
+		},
+		{
+			MethodName: "RenameCollection",
+			Handler:    _InteractiveService_RenameCollection_Handler,
+		},
+		{
+			MethodName: "DeleteCollection",
+			Handler:    _InteractiveService_DeleteCollection_Handler,
+		},
+		{
+			MethodName: "ListCollections",
+			Handler:    _InteractiveService_ListCollections_Handler,
+		},
+		{
+			MethodName: "ListCollectionItems",
+			Handler:    _InteractiveService_ListCollectionItems_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "intr/v1/intr.proto",
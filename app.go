@@ -3,6 +3,7 @@ package main
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/mrhelloboy/wehook/internal/events"
+	"github.com/mrhelloboy/wehook/internal/job"
 	"github.com/robfig/cron/v3"
 )
 
@@ -10,4 +11,5 @@ type App struct {
 	web       *gin.Engine
 	consumers []events.Consumer
 	cron      *cron.Cron
+	scheduler *job.Scheduler
 }
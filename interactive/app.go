@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/mrhelloboy/wehook/interactive/job"
 	"github.com/mrhelloboy/wehook/pkg/ginx"
 	"github.com/mrhelloboy/wehook/pkg/grpcx"
 	"github.com/mrhelloboy/wehook/pkg/saramax"
@@ -10,4 +11,6 @@ type App struct {
 	server    *grpcx.Server
 	consumers []saramax.Consumer
 	webAdmin  *ginx.Server
+	// readCntFlush 定时把阅读数写回数据库
+	readCntFlush *job.ReadCntFlushJob
 }
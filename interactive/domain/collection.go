@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Collection 收藏夹，一个用户可以有多个
+type Collection struct {
+	Id   int64
+	Uid  int64
+	Name string
+	// ItemCnt 收藏夹里面有多少内容
+	ItemCnt int64
+	Ctime   time.Time
+	Utime   time.Time
+}
+
+// CollectionItem 收藏夹里面收藏的一个资源
+type CollectionItem struct {
+	Cid   int64
+	Biz   string
+	BizId int64
+	// Ctime 收藏的时间
+	Ctime time.Time
+}
@@ -6,6 +6,7 @@ type Interactive struct {
 	ReadCnt    int64 `json:"read_cnt"`
 	LikeCnt    int64 `json:"like_cnt"`
 	CollectCnt int64 `json:"collect_cnt"`
+	CommentCnt int64 `json:"comment_cnt"`
 	Liked      bool  `json:"liked"`
 	Collected  bool  `json:"collected"`
 }
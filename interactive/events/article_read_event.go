@@ -4,3 +4,10 @@ type ReadEvent struct {
 	Uid int64
 	Aid int64
 }
+
+// ReadEventV1 批量阅读事件，和 webook 里面的 article.ReadEventV1 保持一致，
+// Uids 和 Aids 按下标一一对应
+type ReadEventV1 struct {
+	Uids []int64
+	Aids []int64
+}
@@ -2,6 +2,7 @@ package events
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/mrhelloboy/wehook/interactive/repository"
@@ -45,6 +46,12 @@ func (i *InteractiveReadEventBatchConsumer) Consume(msg []*sarama.ConsumerMessag
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 	err := i.repo.BatchIncrReadCnt(ctx, bizs, ids)
+	var pe *repository.BatchIncrReadCntError
+	if errors.As(err, &pe) {
+		// 数据库已经成功了，不能重试，只记录缓存没更新上的资源
+		i.l.Warn("批量增加阅读计数，部分缓存更新失败", logger.Field{Key: "ids", Value: pe.BizIds}, logger.Error(pe.Err))
+		return nil
+	}
 	if err != nil {
 		i.l.Error("批量增加阅读计数失败", logger.Field{Key: "ids", Value: ids}, logger.Error(err))
 	}
@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/mrhelloboy/wehook/interactive/repository"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+	"github.com/mrhelloboy/wehook/pkg/saramax"
+)
+
+// InteractiveReadEventV1Consumer 消费批量阅读事件，
+// 每条消息本身就是一批，再攒够一批消息一起更新阅读数
+type InteractiveReadEventV1Consumer struct {
+	client sarama.Client
+	repo   repository.InteractiveRepository
+	l      logger.Logger
+}
+
+func NewInteractiveReadEventV1Consumer(client sarama.Client, repo repository.InteractiveRepository, l logger.Logger) *InteractiveReadEventV1Consumer {
+	return &InteractiveReadEventV1Consumer{client: client, repo: repo, l: l}
+}
+
+func (i *InteractiveReadEventV1Consumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("interactive_v1", i.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := cg.Consume(context.Background(), []string{"read_article_v1"}, saramax.NewBatchHandler[ReadEventV1](i.l, i.Consume))
+		if err != nil {
+			i.l.Error("消费循环异常", logger.Error(err))
+		}
+	}()
+	return err
+}
+
+func (i *InteractiveReadEventV1Consumer) Consume(msgs []*sarama.ConsumerMessage, ts []ReadEventV1) error {
+	ids := make([]int64, 0, len(ts))
+	bizs := make([]string, 0, len(ts))
+	for idx, evt := range ts {
+		if len(evt.Uids) != len(evt.Aids) {
+			// 格式不对的消息跳过，不影响同一批的其它消息
+			i.l.Error("批量阅读事件格式错误",
+				logger.String("topic", msgs[idx].Topic),
+				logger.Int32("partition", msgs[idx].Partition),
+				logger.Int64("offset", msgs[idx].Offset))
+			continue
+		}
+		for _, aid := range evt.Aids {
+			ids = append(ids, aid)
+			bizs = append(bizs, "article")
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := i.repo.BatchIncrReadCnt(ctx, bizs, ids)
+	var pe *repository.BatchIncrReadCntError
+	if errors.As(err, &pe) {
+		// 数据库已经成功了，不能重试，只记录缓存没更新上的资源
+		i.l.Warn("批量增加阅读计数，部分缓存更新失败", logger.Field{Key: "ids", Value: pe.BizIds}, logger.Error(pe.Err))
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/IBM/sarama"
+)
+
+const (
+	topicLikeEvent    = "like_event"
+	topicCollectEvent = "collect_event"
+)
+
+// Producer 点赞、收藏事件，供热榜等下游实时消费
+type Producer interface {
+	ProduceLikeEvent(ctx context.Context, evt LikeEvent) error
+	ProduceCollectEvent(ctx context.Context, evt CollectEvent) error
+}
+
+type kafkaProducer struct {
+	producer sarama.SyncProducer
+}
+
+func NewKafkaProducer(producer sarama.SyncProducer) Producer {
+	return &kafkaProducer{producer: producer}
+}
+
+func (k *kafkaProducer) ProduceLikeEvent(ctx context.Context, evt LikeEvent) error {
+	return k.produce(topicLikeEvent, evt)
+}
+
+func (k *kafkaProducer) ProduceCollectEvent(ctx context.Context, evt CollectEvent) error {
+	return k.produce(topicCollectEvent, evt)
+}
+
+func (k *kafkaProducer) produce(topic string, evt any) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topic,
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
+
+// LikeEvent 点赞或者取消点赞
+type LikeEvent struct {
+	Biz   string
+	BizId int64
+	Uid   int64
+	// Liked false 表示取消点赞
+	Liked bool
+}
+
+// CollectEvent 收藏或者取消收藏
+type CollectEvent struct {
+	Biz   string
+	BizId int64
+	Uid   int64
+	Cid   int64
+	// Collected false 表示取消收藏
+	Collected bool
+}
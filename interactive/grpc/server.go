@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
 	"google.golang.org/grpc"
 
@@ -48,8 +49,16 @@ func (i *InteractiveServiceServer) CancelLike(ctx context.Context, request *intr
 }
 
 func (i *InteractiveServiceServer) Collect(ctx context.Context, request *intrv1.CollectRequest) (*intrv1.CollectResponse, error) {
-	err := i.svc.Collect(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(), request.GetCid())
-	return &intrv1.CollectResponse{}, err
+	err := i.svc.Collect(ctx, request.GetBiz(), request.GetBizId(), request.GetCid(), request.GetUid())
+	return &intrv1.CollectResponse{}, toStatusErr(err)
+}
+
+func (i *InteractiveServiceServer) CancelCollect(ctx context.Context, request *intrv1.CancelCollectRequest) (*intrv1.CancelCollectResponse, error) {
+	if request.Uid <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "uid 错误")
+	}
+	err := i.svc.CancelCollect(ctx, request.GetBiz(), request.GetBizId(), request.GetCid(), request.GetUid())
+	return &intrv1.CancelCollectResponse{}, err
 }
 
 func (i *InteractiveServiceServer) Get(ctx context.Context, request *intrv1.GetRequest) (*intrv1.GetResponse, error) {
@@ -76,6 +85,16 @@ func (i *InteractiveServiceServer) GetByIds(ctx context.Context, request *intrv1
 	}, nil
 }
 
+func (i *InteractiveServiceServer) DeleteBiz(ctx context.Context, request *intrv1.DeleteBizRequest) (*intrv1.DeleteBizResponse, error) {
+	err := i.svc.DeleteBiz(ctx, request.GetBiz(), request.GetBizId())
+	return &intrv1.DeleteBizResponse{}, err
+}
+
+func (i *InteractiveServiceServer) IncrCommentCnt(ctx context.Context, request *intrv1.IncrCommentCntRequest) (*intrv1.IncrCommentCntResponse, error) {
+	err := i.svc.IncrCommentCnt(ctx, request.GetBiz(), request.GetBizId(), request.GetDelta())
+	return &intrv1.IncrCommentCntResponse{}, err
+}
+
 func (i *InteractiveServiceServer) toDTO(intr domain.Interactive) *intrv1.Interactive {
 	return &intrv1.Interactive{
 		Biz:        intr.Biz,
@@ -83,7 +102,76 @@ func (i *InteractiveServiceServer) toDTO(intr domain.Interactive) *intrv1.Intera
 		ReadCnt:    intr.ReadCnt,
 		LikeCnt:    intr.LikeCnt,
 		CollectCnt: intr.CollectCnt,
+		CommentCnt: intr.CommentCnt,
 		Liked:      intr.Liked,
 		Collected:  intr.Collected,
 	}
 }
+
+func (i *InteractiveServiceServer) CreateCollection(ctx context.Context, request *intrv1.CreateCollectionRequest) (*intrv1.CreateCollectionResponse, error) {
+	if request.GetUid() <= 0 || request.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "uid 或者收藏夹名字错误")
+	}
+	id, err := i.svc.CreateCollection(ctx, request.GetUid(), request.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.CreateCollectionResponse{Id: id}, nil
+}
+
+func (i *InteractiveServiceServer) RenameCollection(ctx context.Context, request *intrv1.RenameCollectionRequest) (*intrv1.RenameCollectionResponse, error) {
+	if request.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "收藏夹名字不能为空")
+	}
+	err := i.svc.RenameCollection(ctx, request.GetId(), request.GetUid(), request.GetName())
+	return &intrv1.RenameCollectionResponse{}, toStatusErr(err)
+}
+
+func (i *InteractiveServiceServer) DeleteCollection(ctx context.Context, request *intrv1.DeleteCollectionRequest) (*intrv1.DeleteCollectionResponse, error) {
+	err := i.svc.DeleteCollection(ctx, request.GetId(), request.GetUid())
+	return &intrv1.DeleteCollectionResponse{}, toStatusErr(err)
+}
+
+func (i *InteractiveServiceServer) ListCollections(ctx context.Context, request *intrv1.ListCollectionsRequest) (*intrv1.ListCollectionsResponse, error) {
+	cols, err := i.svc.ListCollections(ctx, request.GetUid(), int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*intrv1.Collection, 0, len(cols))
+	for _, col := range cols {
+		res = append(res, &intrv1.Collection{
+			Id:      col.Id,
+			Uid:     col.Uid,
+			Name:    col.Name,
+			ItemCnt: col.ItemCnt,
+			Ctime:   col.Ctime.UnixMilli(),
+			Utime:   col.Utime.UnixMilli(),
+		})
+	}
+	return &intrv1.ListCollectionsResponse{Collections: res}, nil
+}
+
+func (i *InteractiveServiceServer) ListCollectionItems(ctx context.Context, request *intrv1.ListCollectionItemsRequest) (*intrv1.ListCollectionItemsResponse, error) {
+	items, err := i.svc.ListCollectionItems(ctx, request.GetCid(), request.GetUid(), int(request.GetOffset()), int(request.GetLimit()))
+	if err != nil {
+		return nil, toStatusErr(err)
+	}
+	res := make([]*intrv1.CollectionItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, &intrv1.CollectionItem{
+			Cid:   item.Cid,
+			Biz:   item.Biz,
+			BizId: item.BizId,
+			Ctime: item.Ctime.UnixMilli(),
+		})
+	}
+	return &intrv1.ListCollectionItemsResponse{Items: res}, nil
+}
+
+// toStatusErr 业务错误转成 gRPC 的错误码，客户端可以据此区分
+func toStatusErr(err error) error {
+	if errors.Is(err, service.ErrCollectionNotFound) {
+		return status.Error(codes.NotFound, "收藏夹不存在")
+	}
+	return err
+}
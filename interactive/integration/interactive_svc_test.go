@@ -12,6 +12,7 @@ import (
 	"github.com/mrhelloboy/wehook/interactive/repository/dao"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"golang.org/x/net/context"
 	"gorm.io/gorm"
@@ -39,6 +40,8 @@ func (s *InteractiveTestSuite) TearDownTest() {
 	assert.NoError(s.T(), err)
 	err = s.db.Exec("TRUNCATE TABLE `user_collection_bizs`").Error
 	assert.NoError(s.T(), err)
+	err = s.db.Exec("TRUNCATE TABLE `collections`").Error
+	assert.NoError(s.T(), err)
 	// 清空 Redis
 	err = s.rdb.FlushDB(ctx).Err()
 	assert.NoError(s.T(), err)
@@ -628,8 +631,55 @@ func (s *InteractiveTestSuite) TestCollect() {
 			uid:      1,
 			wantResp: &intrv1.CollectResponse{},
 		},
+		{
+			name: "重复收藏,收藏数不变",
+			before: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				err := s.db.WithContext(ctx).Create(&dao.Interactive{
+					Biz:        "test",
+					BizId:      4,
+					CollectCnt: 10,
+					Ctime:      123,
+					Utime:      234,
+				}).Error
+				assert.NoError(t, err)
+				err = s.db.WithContext(ctx).Create(&dao.UserCollectionBiz{
+					Biz:   "test",
+					BizId: 4,
+					Cid:   1,
+					Uid:   1,
+					Ctime: 123,
+					Utime: 234,
+				}).Error
+				assert.NoError(t, err)
+				err = s.rdb.HSet(ctx, "interactive:test:4", "collect_cnt", 10).Err()
+				assert.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				var intr dao.Interactive
+				err := s.db.WithContext(ctx).
+					Where("biz = ? AND biz_id = ?", "test", 4).First(&intr).Error
+				assert.NoError(t, err)
+				assert.Equal(t, int64(10), intr.CollectCnt)
+				cnt, err := s.rdb.HGet(ctx, "interactive:test:4", "collect_cnt").Int()
+				assert.NoError(t, err)
+				assert.Equal(t, 10, cnt)
+			},
+			bizId:    4,
+			biz:      "test",
+			cid:      1,
+			uid:      1,
+			wantResp: &intrv1.CollectResponse{},
+		},
 	}
 
+	// 收藏夹要是自己的
+	err := s.db.Create(&dao.Collection{Id: 1, Name: "test", Uid: 1, Ctime: 123, Utime: 234}).Error
+	require.NoError(s.T(), err)
+
 	for _, tc := range testCases {
 		s.T().Run(tc.name, func(t *testing.T) {
 			tc.before(t)
@@ -646,6 +696,113 @@ func (s *InteractiveTestSuite) TestCollect() {
 	}
 }
 
+func (s *InteractiveTestSuite) TestCancelCollect() {
+	testCases := []struct {
+		name string
+
+		before func(t *testing.T)
+		after  func(t *testing.T)
+
+		bizId int64
+		biz   string
+		cid   int64
+		uid   int64
+
+		wantErr  error
+		wantResp *intrv1.CancelCollectResponse
+	}{
+		{
+			name: "取消收藏成功,db和缓存都有",
+			before: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				err := s.db.WithContext(ctx).Create(&dao.Interactive{
+					Biz:        "test",
+					BizId:      1,
+					CollectCnt: 10,
+					Ctime:      123,
+					Utime:      234,
+				}).Error
+				assert.NoError(t, err)
+				err = s.db.WithContext(ctx).Create(&dao.UserCollectionBiz{
+					Biz:   "test",
+					BizId: 1,
+					Cid:   1,
+					Uid:   1,
+					Ctime: 123,
+					Utime: 234,
+				}).Error
+				assert.NoError(t, err)
+				err = s.rdb.HSet(ctx, "interactive:test:1", "collect_cnt", 10).Err()
+				assert.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				var intr dao.Interactive
+				err := s.db.WithContext(ctx).
+					Where("biz = ? AND biz_id = ?", "test", 1).First(&intr).Error
+				assert.NoError(t, err)
+				assert.Equal(t, int64(9), intr.CollectCnt)
+				cnt, err := s.rdb.HGet(ctx, "interactive:test:1", "collect_cnt").Int()
+				assert.NoError(t, err)
+				assert.Equal(t, 9, cnt)
+				err = s.db.WithContext(ctx).
+					Where("uid = ? AND biz = ? AND biz_id = ?", 1, "test", 1).
+					First(&dao.UserCollectionBiz{}).Error
+				assert.Equal(t, dao.ErrRecordNotFound, err)
+			},
+			bizId:    1,
+			biz:      "test",
+			uid:      1,
+			wantResp: &intrv1.CancelCollectResponse{},
+		},
+		{
+			name: "没有收藏过,收藏数不变",
+			before: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				err := s.db.WithContext(ctx).Create(&dao.Interactive{
+					Biz:        "test",
+					BizId:      2,
+					CollectCnt: 10,
+					Ctime:      123,
+					Utime:      234,
+				}).Error
+				assert.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				var intr dao.Interactive
+				err := s.db.WithContext(ctx).
+					Where("biz = ? AND biz_id = ?", "test", 2).First(&intr).Error
+				assert.NoError(t, err)
+				assert.Equal(t, int64(10), intr.CollectCnt)
+			},
+			bizId:    2,
+			biz:      "test",
+			uid:      1,
+			wantResp: &intrv1.CancelCollectResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			resp, err := s.server.CancelCollect(context.Background(), &intrv1.CancelCollectRequest{
+				Biz:   tc.biz,
+				BizId: tc.bizId,
+				Cid:   tc.cid,
+				Uid:   tc.uid,
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantResp, resp)
+			tc.after(t)
+		})
+	}
+}
+
 func (s *InteractiveTestSuite) TestGet() {
 	testCases := []struct {
 		name string
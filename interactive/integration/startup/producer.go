@@ -0,0 +1,22 @@
+package startup
+
+import (
+	"context"
+
+	"github.com/mrhelloboy/wehook/interactive/events"
+)
+
+// InitPhantomProducer 啥也不发的 producer，集成测试不依赖 kafka
+func InitPhantomProducer() events.Producer {
+	return phantomProducer{}
+}
+
+type phantomProducer struct{}
+
+func (phantomProducer) ProduceLikeEvent(ctx context.Context, evt events.LikeEvent) error {
+	return nil
+}
+
+func (phantomProducer) ProduceCollectEvent(ctx context.Context, evt events.CollectEvent) error {
+	return nil
+}
@@ -12,19 +12,21 @@ import (
 )
 
 var thirdProvider = wire.NewSet(
-	InitRedis, InitLog, InitTestDB,
+	InitRedis, InitLog, InitTestDB, InitPhantomProducer,
 )
 
 var interactiveSvcProvider = wire.NewSet(
 	service.NewInteractiveService,
 	repository.NewCachedInteractiveRepo,
+	repository.NewCachedCollectionRepo,
 	dao.NewGormInteractiveDAO,
+	dao.NewGORMCollectionDAO,
 	cache.NewRedisInteractiveCache,
 )
 
 func InitInteractiveService() service.InteractiveService {
 	wire.Build(thirdProvider, interactiveSvcProvider)
-	return service.NewInteractiveService(nil, nil)
+	return service.NewInteractiveService(nil, nil, nil, nil)
 }
 
 func InitInteractiveGRPCServer() *grpc.InteractiveServiceServer {
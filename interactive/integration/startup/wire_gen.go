@@ -24,7 +24,10 @@ func InitInteractiveService() service.InteractiveService {
 	interactiveCache := cache.NewRedisInteractiveCache(cmdable)
 	logger := InitLog()
 	interactiveRepository := repository.NewCachedInteractiveRepo(interactiveDAO, interactiveCache, logger)
-	interactiveService := service.NewInteractiveService(interactiveRepository, logger)
+	collectionDAO := dao.NewGORMCollectionDAO(gormDB)
+	collectionRepository := repository.NewCachedCollectionRepo(collectionDAO, interactiveCache, logger)
+	producer := InitPhantomProducer()
+	interactiveService := service.NewInteractiveService(interactiveRepository, collectionRepository, producer, logger)
 	return interactiveService
 }
 
@@ -35,7 +38,10 @@ func InitInteractiveGRPCServer() *grpc.InteractiveServiceServer {
 	interactiveCache := cache.NewRedisInteractiveCache(cmdable)
 	logger := InitLog()
 	interactiveRepository := repository.NewCachedInteractiveRepo(interactiveDAO, interactiveCache, logger)
-	interactiveService := service.NewInteractiveService(interactiveRepository, logger)
+	collectionDAO := dao.NewGORMCollectionDAO(gormDB)
+	collectionRepository := repository.NewCachedCollectionRepo(collectionDAO, interactiveCache, logger)
+	producer := InitPhantomProducer()
+	interactiveService := service.NewInteractiveService(interactiveRepository, collectionRepository, producer, logger)
 	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
 	return interactiveServiceServer
 }
@@ -43,7 +49,7 @@ func InitInteractiveGRPCServer() *grpc.InteractiveServiceServer {
 // wire.go:
 
 var thirdProvider = wire.NewSet(
-	InitRedis, InitLog, InitTestDB,
+	InitRedis, InitLog, InitTestDB, InitPhantomProducer,
 )
 
-var interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, repository.NewCachedInteractiveRepo, dao.NewGormInteractiveDAO, cache.NewRedisInteractiveCache)
+var interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, repository.NewCachedInteractiveRepo, repository.NewCachedCollectionRepo, dao.NewGormInteractiveDAO, dao.NewGORMCollectionDAO, cache.NewRedisInteractiveCache)
@@ -39,9 +39,11 @@ func InitSyncProducer(client sarama.Client) sarama.SyncProducer {
 // 规避 wire 的问题
 type fixerInteractive *fixer.Consumer[dao.Interactive]
 
-func NewConsumers(intr *events.InteractiveReadEventConsumer, fix *fixer.Consumer[dao.Interactive]) []saramax.Consumer {
+func NewConsumers(intr *events.InteractiveReadEventConsumer, intrV1 *events.InteractiveReadEventV1Consumer,
+	fix *fixer.Consumer[dao.Interactive]) []saramax.Consumer {
 	return []saramax.Consumer{
 		intr,
+		intrV1,
 		fix,
 	}
 }
@@ -0,0 +1,36 @@
+package ioc
+
+import (
+	"time"
+
+	rlock "github.com/gotomicro/redis-lock"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+
+	"github.com/mrhelloboy/wehook/interactive/job"
+	"github.com/mrhelloboy/wehook/interactive/repository"
+	"github.com/mrhelloboy/wehook/interactive/repository/cache"
+	"github.com/mrhelloboy/wehook/interactive/repository/dao"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+// InitInteractiveRepo 阅读数先累加在 Redis 里面，定时写回数据库，减轻热门文章对数据库的写压力
+func InitInteractiveRepo(d dao.InteractiveDAO, c cache.InteractiveCache,
+	buffer cache.ReadCntBuffer, l logger.Logger) *repository.WriteBehindInteractiveRepo {
+	return repository.NewWriteBehindInteractiveRepo(repository.NewCachedInteractiveRepo(d, c, l), d, c, buffer, l)
+}
+
+func InitReadCntFlushJob(repo *repository.WriteBehindInteractiveRepo, client redis.Cmdable, l logger.Logger) *job.ReadCntFlushJob {
+	type Config struct {
+		// FlushInterval 多久写回一次数据库
+		FlushInterval time.Duration `yaml:"flushInterval"`
+	}
+	cfg := Config{
+		FlushInterval: time.Second * 10,
+	}
+	err := viper.UnmarshalKey("readCnt", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return job.NewReadCntFlushJob(repo, cfg.FlushInterval, rlock.NewClient(client), l)
+}
@@ -0,0 +1,89 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	rlock "github.com/gotomicro/redis-lock"
+
+	"github.com/mrhelloboy/wehook/interactive/repository"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+// ReadCntFlushJob 定时把累加的阅读数写回数据库。
+// 多个实例同时跑的时候，用分布式锁保证同一时刻只有一个实例在写回
+type ReadCntFlushJob struct {
+	repo     *repository.WriteBehindInteractiveRepo
+	interval time.Duration
+	timeout  time.Duration
+	client   *rlock.Client
+	key      string
+	l        logger.Logger
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewReadCntFlushJob(repo *repository.WriteBehindInteractiveRepo, interval time.Duration,
+	client *rlock.Client, l logger.Logger) *ReadCntFlushJob {
+	return &ReadCntFlushJob{
+		repo:     repo,
+		interval: interval,
+		timeout:  time.Second * 30,
+		client:   client,
+		key:      "rlock:interactive:read_cnt_flush",
+		l:        l,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (j *ReadCntFlushJob) Start() {
+	go func() {
+		defer close(j.done)
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				j.flush()
+			case <-j.stop:
+				// 退出之前再写一次，尽量不留尾巴
+				j.flush()
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止定时写回，会等最后一次写回结束
+func (j *ReadCntFlushJob) Stop() {
+	j.stopOnce.Do(func() {
+		close(j.stop)
+	})
+	<-j.done
+}
+
+func (j *ReadCntFlushJob) flush() {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	lock, err := j.client.Lock(ctx, j.key, j.timeout, &rlock.FixIntervalRetry{
+		Interval: time.Millisecond * 100,
+		Max:      0,
+	}, time.Second)
+	if err != nil {
+		// 别的实例正在写回
+		return
+	}
+	defer func() {
+		if er := lock.Unlock(context.Background()); er != nil {
+			j.l.Error("释放阅读数写回的锁失败", logger.Error(er))
+		}
+	}()
+	if err = j.repo.Flush(ctx); err != nil {
+		// 没有 Ack 的下一次还会再写
+		j.l.Error("阅读数写回数据库失败", logger.Error(err))
+	}
+}
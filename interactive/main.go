@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os/signal"
+	"syscall"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/pflag"
@@ -18,12 +21,25 @@ func main() {
 			panic(err)
 		}
 	}
+	app.readCntFlush.Start()
 	go func() {
 		err := app.webAdmin.Start()
 		log.Println(err)
 	}()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		// 先摘掉注册中心里面的实例，再等正在处理的请求结束
+		if err := app.server.Close(); err != nil {
+			log.Println(err)
+			app.server.GracefulStop()
+		}
+	}()
 	err := app.server.Serve()
 	log.Println(err)
+	// 请求都处理完了，把还没写回的阅读数写回去
+	app.readCntFlush.Stop()
 }
 
 func initViper() {
@@ -19,6 +19,7 @@ const (
 	fieldReadCnt    = "read_cnt"
 	fieldLikeCnt    = "like_cnt"
 	fieldCollectCnt = "collect_cnt"
+	fieldCommentCnt = "comment_cnt"
 )
 
 //go:generate mockgen -source=./interactive.go -package=cachemocks -destination=mocks/interactive.mock.go InteractiveCache
@@ -26,12 +27,19 @@ const (
 type InteractiveCache interface {
 	// IncrReadCntIfPresent 如果在缓存中有对应的数据，就 +1
 	IncrReadCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	// BatchIncrReadCntIfPresent 批量增加阅读数，一次网络往返。
+	// 返回的 errs 和入参一一对应，nil 表示这一个成功了，用来判断部分失败
+	BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) []error
 	IncrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	DecrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	IncrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	// IncrCommentCntIfPresent 评论数加 delta，delta 为负数表示减少
+	IncrCommentCntIfPresent(ctx context.Context, biz string, bizId int64, delta int64) error
 	// Get 查询缓存中的数据, liked 和 collected shi不需要缓存的
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
 	Set(ctx context.Context, biz string, bizId int64, inter domain.Interactive) error
+	Del(ctx context.Context, biz string, bizId int64) error
 }
 
 type redisInteractiveCache struct {
@@ -55,12 +63,14 @@ func (r *redisInteractiveCache) Get(ctx context.Context, biz string, bizId int64
 	readCnt, _ := strconv.ParseInt(data[fieldReadCnt], 10, 64)
 	likeCnt, _ := strconv.ParseInt(data[fieldLikeCnt], 10, 64)
 	collectCnt, _ := strconv.ParseInt(data[fieldCollectCnt], 10, 64)
+	commentCnt, _ := strconv.ParseInt(data[fieldCommentCnt], 10, 64)
 
 	return domain.Interactive{
 		BizId:      bizId,
 		ReadCnt:    readCnt,
 		LikeCnt:    likeCnt,
 		CollectCnt: collectCnt,
+		CommentCnt: commentCnt,
 	}, nil
 }
 
@@ -70,6 +80,7 @@ func (r *redisInteractiveCache) Set(ctx context.Context, biz string, bizId int64
 		fieldReadCnt, inter.ReadCnt,
 		fieldLikeCnt, inter.LikeCnt,
 		fieldCollectCnt, inter.CollectCnt,
+		fieldCommentCnt, inter.CommentCnt,
 	).Err()
 	if err != nil {
 		return err
@@ -89,10 +100,38 @@ func (r *redisInteractiveCache) IncrReadCntIfPresent(ctx context.Context, biz st
 	return r.client.Eval(ctx, luaIncrCnt, []string{r.key(biz, bizId)}, fieldReadCnt, 1).Err()
 }
 
+func (r *redisInteractiveCache) BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) []error {
+	// 用 pipeline 一次发过去，每个 key 还是各自执行 lua 脚本，互不影响
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.Cmd, len(bizs))
+	for i := range bizs {
+		cmds[i] = pipe.Eval(ctx, luaIncrCnt, []string{r.key(bizs[i], bizIds[i])}, fieldReadCnt, cnts[i])
+	}
+	// 整体的 error 就是第一个失败的命令的 error，这里按命令逐个判断
+	_, _ = pipe.Exec(ctx)
+	errs := make([]error, len(cmds))
+	for i, cmd := range cmds {
+		errs[i] = cmd.Err()
+	}
+	return errs
+}
+
 func (r *redisInteractiveCache) IncrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
 	return r.client.Eval(ctx, luaIncrCnt, []string{r.key(biz, bizId)}, fieldCollectCnt, 1).Err()
 }
 
+func (r *redisInteractiveCache) DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	return r.client.Eval(ctx, luaIncrCnt, []string{r.key(biz, bizId)}, fieldCollectCnt, -1).Err()
+}
+
+func (r *redisInteractiveCache) IncrCommentCntIfPresent(ctx context.Context, biz string, bizId int64, delta int64) error {
+	return r.client.Eval(ctx, luaIncrCnt, []string{r.key(biz, bizId)}, fieldCommentCnt, delta).Err()
+}
+
+func (r *redisInteractiveCache) Del(ctx context.Context, biz string, bizId int64) error {
+	return r.client.Del(ctx, r.key(biz, bizId)).Err()
+}
+
 func (r *redisInteractiveCache) key(biz string, bizId int64) string {
 	return fmt.Sprintf("interactive:%s:%d", biz, bizId)
 }
@@ -0,0 +1,14 @@
+-- 取出待刷新的阅读数
+-- KEYS[1] 正在累加的 key，KEYS[2] 正在刷新的 key
+local pending = KEYS[1]
+local flushing = KEYS[2]
+
+-- 上一次刷新没有确认，说明中途失败了，先把它处理完
+if redis.call("EXISTS", flushing) == 0 then
+    if redis.call("EXISTS", pending) == 0 then
+        return {}
+    end
+    -- 换个名字，新的阅读数会累加到新的 pending 里面
+    redis.call("RENAME", pending, flushing)
+end
+return redis.call("HGETALL", flushing)
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: ./interactive.go
+// Source: interactive/repository/cache/interactive.go
 //
 // Generated by this command:
 //
-//	mockgen -source=./interactive.go -package=cachemocks -destination=mocks/interactive.mock.go InteractiveCache
+//	mockgen -source=interactive/repository/cache/interactive.go -package=cachemocks -destination=interactive/repository/cache/mocks/interactive.mock.go
 //
 
 // Package cachemocks is a generated GoMock package.
@@ -13,7 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
-	domain "github.com/mrhelloboy/wehook/internal/domain"
+	domain "github.com/mrhelloboy/wehook/interactive/domain"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +40,34 @@ func (m *MockInteractiveCache) EXPECT() *MockInteractiveCacheMockRecorder {
 	return m.recorder
 }
 
+// BatchIncrReadCntIfPresent mocks base method.
+func (m *MockInteractiveCache) BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, bizIds, cnts []int64) []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrReadCntIfPresent", ctx, bizs, bizIds, cnts)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// BatchIncrReadCntIfPresent indicates an expected call of BatchIncrReadCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) BatchIncrReadCntIfPresent(ctx, bizs, bizIds, cnts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).BatchIncrReadCntIfPresent), ctx, bizs, bizIds, cnts)
+}
+
+// DecrCollectCntIfPresent mocks base method.
+func (m *MockInteractiveCache) DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrCollectCntIfPresent", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrCollectCntIfPresent indicates an expected call of DecrCollectCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) DecrCollectCntIfPresent(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrCollectCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).DecrCollectCntIfPresent), ctx, biz, bizId)
+}
+
 // DecrLikeCntIfPresent mocks base method.
 func (m *MockInteractiveCache) DecrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error {
 	m.ctrl.T.Helper()
@@ -54,6 +82,20 @@ func (mr *MockInteractiveCacheMockRecorder) DecrLikeCntIfPresent(ctx, biz, bizId
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrLikeCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).DecrLikeCntIfPresent), ctx, biz, bizId)
 }
 
+// Del mocks base method.
+func (m *MockInteractiveCache) Del(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Del", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockInteractiveCacheMockRecorder) Del(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockInteractiveCache)(nil).Del), ctx, biz, bizId)
+}
+
 // Get mocks base method.
 func (m *MockInteractiveCache) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
 	m.ctrl.T.Helper()
@@ -83,6 +125,20 @@ func (mr *MockInteractiveCacheMockRecorder) IncrCollectCntIfPresent(ctx, biz, bi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCollectCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrCollectCntIfPresent), ctx, biz, bizId)
 }
 
+// IncrCommentCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncrCommentCntIfPresent(ctx context.Context, biz string, bizId, delta int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrCommentCntIfPresent", ctx, biz, bizId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrCommentCntIfPresent indicates an expected call of IncrCommentCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncrCommentCntIfPresent(ctx, biz, bizId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCommentCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrCommentCntIfPresent), ctx, biz, bizId, delta)
+}
+
 // IncrLikeCntIfPresent mocks base method.
 func (m *MockInteractiveCache) IncrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error {
 	m.ctrl.T.Helper()
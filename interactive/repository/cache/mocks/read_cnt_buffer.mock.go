@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interactive/repository/cache/read_cnt_buffer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interactive/repository/cache/read_cnt_buffer.go -package=cachemocks -destination=interactive/repository/cache/mocks/read_cnt_buffer.mock.go
+//
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReadCntBuffer is a mock of ReadCntBuffer interface.
+type MockReadCntBuffer struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadCntBufferMockRecorder
+}
+
+// MockReadCntBufferMockRecorder is the mock recorder for MockReadCntBuffer.
+type MockReadCntBufferMockRecorder struct {
+	mock *MockReadCntBuffer
+}
+
+// NewMockReadCntBuffer creates a new mock instance.
+func NewMockReadCntBuffer(ctrl *gomock.Controller) *MockReadCntBuffer {
+	mock := &MockReadCntBuffer{ctrl: ctrl}
+	mock.recorder = &MockReadCntBufferMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadCntBuffer) EXPECT() *MockReadCntBufferMockRecorder {
+	return m.recorder
+}
+
+// Ack mocks base method.
+func (m *MockReadCntBuffer) Ack(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ack", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ack indicates an expected call of Ack.
+func (mr *MockReadCntBufferMockRecorder) Ack(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ack", reflect.TypeOf((*MockReadCntBuffer)(nil).Ack), ctx)
+}
+
+// Incr mocks base method.
+func (m *MockReadCntBuffer) Incr(ctx context.Context, bizs []string, bizIds, cnts []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Incr", ctx, bizs, bizIds, cnts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Incr indicates an expected call of Incr.
+func (mr *MockReadCntBufferMockRecorder) Incr(ctx, bizs, bizIds, cnts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockReadCntBuffer)(nil).Incr), ctx, bizs, bizIds, cnts)
+}
+
+// Take mocks base method.
+func (m *MockReadCntBuffer) Take(ctx context.Context) ([]string, []int64, []int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].([]int64)
+	ret2, _ := ret[2].([]int64)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockReadCntBufferMockRecorder) Take(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockReadCntBuffer)(nil).Take), ctx)
+}
@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+//go:embed lua/read_cnt_take.lua
+var luaReadCntTake string
+
+const (
+	keyReadCntPending  = "interactive:read_cnt:pending"
+	keyReadCntFlushing = "interactive:read_cnt:flushing"
+)
+
+//go:generate mockgen -source=./read_cnt_buffer.go -package=cachemocks -destination=mocks/read_cnt_buffer.mock.go ReadCntBuffer
+
+// ReadCntBuffer 还没有写回数据库的阅读数。
+// 放在 Redis 里面，进程崩溃了也不会丢
+type ReadCntBuffer interface {
+	// Incr 累加阅读数，bizs、bizIds 和 cnts 一一对应
+	Incr(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) error
+	// Take 取出待写回的阅读数。
+	// 在 Ack 之前再调用，返回的还是同一批，所以写回失败了可以重试
+	Take(ctx context.Context) (bizs []string, bizIds []int64, cnts []int64, err error)
+	// Ack 确认 Take 取出的那一批已经写回数据库了
+	Ack(ctx context.Context) error
+}
+
+type RedisReadCntBuffer struct {
+	client redis.Cmdable
+}
+
+func NewRedisReadCntBuffer(client redis.Cmdable) ReadCntBuffer {
+	return &RedisReadCntBuffer{client: client}
+}
+
+func (r *RedisReadCntBuffer) Incr(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) error {
+	pipe := r.client.TxPipeline()
+	for i := range bizs {
+		pipe.HIncrBy(ctx, keyReadCntPending, r.field(bizs[i], bizIds[i]), cnts[i])
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisReadCntBuffer) Take(ctx context.Context) ([]string, []int64, []int64, error) {
+	vals, err := r.client.Eval(ctx, luaReadCntTake, []string{keyReadCntPending, keyReadCntFlushing}).StringSlice()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// HGETALL 返回的是 field1, value1, field2, value2...
+	bizs := make([]string, 0, len(vals)/2)
+	bizIds := make([]int64, 0, len(vals)/2)
+	cnts := make([]int64, 0, len(vals)/2)
+	for i := 0; i+1 < len(vals); i += 2 {
+		biz, bizId, ok := r.parseField(vals[i])
+		cnt, er := strconv.ParseInt(vals[i+1], 10, 64)
+		if !ok || er != nil || cnt == 0 {
+			continue
+		}
+		bizs = append(bizs, biz)
+		bizIds = append(bizIds, bizId)
+		cnts = append(cnts, cnt)
+	}
+	return bizs, bizIds, cnts, nil
+}
+
+func (r *RedisReadCntBuffer) Ack(ctx context.Context) error {
+	return r.client.Del(ctx, keyReadCntFlushing).Err()
+}
+
+func (r *RedisReadCntBuffer) field(biz string, bizId int64) string {
+	return fmt.Sprintf("%s:%d", biz, bizId)
+}
+
+// parseField biz 里面也可能有冒号，所以按照最后一个冒号来切
+func (r *RedisReadCntBuffer) parseField(field string) (string, int64, bool) {
+	idx := strings.LastIndexByte(field, ':')
+	if idx < 0 {
+		return "", 0, false
+	}
+	bizId, err := strconv.ParseInt(field[idx+1:], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return field[:idx], bizId, true
+}
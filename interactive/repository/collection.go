@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/mrhelloboy/wehook/interactive/domain"
+	"github.com/mrhelloboy/wehook/interactive/repository/cache"
+	"github.com/mrhelloboy/wehook/interactive/repository/dao"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+var ErrCollectionNotFound = dao.ErrRecordNotFound
+
+//go:generate mockgen -source=./collection.go -package=repomocks -destination=mocks/collection.mock.go CollectionRepository
+type CollectionRepository interface {
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	Rename(ctx context.Context, id, uid int64, name string) error
+	GetById(ctx context.Context, id int64) (domain.Collection, error)
+	// List 用户的收藏夹，带上每个收藏夹的内容数量
+	List(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error)
+	ListItems(ctx context.Context, cid int64, offset, limit int) ([]domain.CollectionItem, error)
+	// Delete 删除收藏夹，返回被一并取消收藏的内容
+	Delete(ctx context.Context, id, uid int64) ([]domain.CollectionItem, error)
+}
+
+type cachedCollectionRepo struct {
+	dao   dao.CollectionDAO
+	cache cache.InteractiveCache
+	l     logger.Logger
+}
+
+func NewCachedCollectionRepo(dao dao.CollectionDAO, cache cache.InteractiveCache, l logger.Logger) CollectionRepository {
+	return &cachedCollectionRepo{
+		dao:   dao,
+		cache: cache,
+		l:     l,
+	}
+}
+
+func (c *cachedCollectionRepo) Create(ctx context.Context, col domain.Collection) (int64, error) {
+	return c.dao.Insert(ctx, dao.Collection{
+		Name: col.Name,
+		Uid:  col.Uid,
+	})
+}
+
+func (c *cachedCollectionRepo) Rename(ctx context.Context, id, uid int64, name string) error {
+	return c.dao.UpdateName(ctx, id, uid, name)
+}
+
+func (c *cachedCollectionRepo) GetById(ctx context.Context, id int64) (domain.Collection, error) {
+	col, err := c.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return c.toDomain(col), nil
+}
+
+func (c *cachedCollectionRepo) List(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error) {
+	cols, err := c.dao.ListByUid(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(cols) == 0 {
+		return []domain.Collection{}, nil
+	}
+	cnts, err := c.dao.CountItems(ctx, slice.Map[dao.Collection, int64](cols, func(idx int, src dao.Collection) int64 {
+		return src.Id
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Collection, domain.Collection](cols, func(idx int, src dao.Collection) domain.Collection {
+		res := c.toDomain(src)
+		res.ItemCnt = cnts[src.Id]
+		return res
+	}), nil
+}
+
+func (c *cachedCollectionRepo) ListItems(ctx context.Context, cid int64, offset, limit int) ([]domain.CollectionItem, error) {
+	items, err := c.dao.ListItems(ctx, cid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.UserCollectionBiz, domain.CollectionItem](items, func(idx int, src dao.UserCollectionBiz) domain.CollectionItem {
+		return c.itemToDomain(src)
+	}), nil
+}
+
+func (c *cachedCollectionRepo) Delete(ctx context.Context, id, uid int64) ([]domain.CollectionItem, error) {
+	items, err := c.dao.Delete(ctx, id, uid)
+	if err != nil {
+		return nil, err
+	}
+	// 数据库已经扣减了收藏数，缓存失败只能等过期
+	for _, item := range items {
+		er := c.cache.DecrCollectCntIfPresent(ctx, item.Biz, item.BizId)
+		if er != nil {
+			c.l.Error("扣减缓存收藏数失败",
+				logger.String("biz", item.Biz),
+				logger.Int64("biz_id", item.BizId),
+				logger.Error(er))
+		}
+	}
+	return slice.Map[dao.UserCollectionBiz, domain.CollectionItem](items, func(idx int, src dao.UserCollectionBiz) domain.CollectionItem {
+		return c.itemToDomain(src)
+	}), nil
+}
+
+func (c *cachedCollectionRepo) toDomain(col dao.Collection) domain.Collection {
+	return domain.Collection{
+		Id:    col.Id,
+		Uid:   col.Uid,
+		Name:  col.Name,
+		Ctime: time.UnixMilli(col.Ctime),
+		Utime: time.UnixMilli(col.Utime),
+	}
+}
+
+func (c *cachedCollectionRepo) itemToDomain(item dao.UserCollectionBiz) domain.CollectionItem {
+	return domain.CollectionItem{
+		Cid:   item.Cid,
+		Biz:   item.Biz,
+		BizId: item.BizId,
+		Ctime: time.UnixMilli(item.Ctime),
+	}
+}
@@ -0,0 +1,138 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=./collection.go -package=daomocks -destination=mocks/collection.mock.go CollectionDAO
+
+// CollectionDAO 收藏夹，收藏的内容还是放在 UserCollectionBiz 里面
+type CollectionDAO interface {
+	Insert(ctx context.Context, c Collection) (int64, error)
+	// UpdateName 只能修改自己的收藏夹，找不到返回 ErrRecordNotFound
+	UpdateName(ctx context.Context, id, uid int64, name string) error
+	GetById(ctx context.Context, id int64) (Collection, error)
+	ListByUid(ctx context.Context, uid int64, offset, limit int) ([]Collection, error)
+	// CountItems 每个收藏夹里面有多少内容，没有内容的收藏夹不在结果里面
+	CountItems(ctx context.Context, cids []int64) (map[int64]int64, error)
+	ListItems(ctx context.Context, cid int64, offset, limit int) ([]UserCollectionBiz, error)
+	// Delete 删除收藏夹和里面的内容，并且扣减对应的收藏数，返回被删除的内容
+	Delete(ctx context.Context, id, uid int64) ([]UserCollectionBiz, error)
+}
+
+type GORMCollectionDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMCollectionDAO(db *gorm.DB) CollectionDAO {
+	return &GORMCollectionDAO{db: db}
+}
+
+func (g *GORMCollectionDAO) Insert(ctx context.Context, c Collection) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := g.db.WithContext(ctx).Create(&c).Error
+	return c.Id, err
+}
+
+func (g *GORMCollectionDAO) UpdateName(ctx context.Context, id, uid int64, name string) error {
+	res := g.db.WithContext(ctx).Model(&Collection{}).
+		Where("id = ? AND uid = ?", id, uid).
+		Updates(map[string]any{
+			"name":  name,
+			"utime": time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+func (g *GORMCollectionDAO) GetById(ctx context.Context, id int64) (Collection, error) {
+	var res Collection
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&res).Error
+	return res, err
+}
+
+func (g *GORMCollectionDAO) ListByUid(ctx context.Context, uid int64, offset, limit int) ([]Collection, error) {
+	var res []Collection
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).
+		Order("id ASC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCollectionDAO) CountItems(ctx context.Context, cids []int64) (map[int64]int64, error) {
+	type Cnt struct {
+		Cid int64
+		Cnt int64
+	}
+	var cnts []Cnt
+	err := g.db.WithContext(ctx).Model(&UserCollectionBiz{}).
+		Select("cid, COUNT(*) AS cnt").
+		Where("cid IN ?", cids).
+		Group("cid").
+		Scan(&cnts).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]int64, len(cnts))
+	for _, c := range cnts {
+		res[c.Cid] = c.Cnt
+	}
+	return res, nil
+}
+
+func (g *GORMCollectionDAO) ListItems(ctx context.Context, cid int64, offset, limit int) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	// 最近收藏的在前面
+	err := g.db.WithContext(ctx).Where("cid = ?", cid).
+		Order("id DESC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCollectionDAO) Delete(ctx context.Context, id, uid int64) ([]UserCollectionBiz, error) {
+	var items []UserCollectionBiz
+	now := time.Now().UnixMilli()
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND uid = ?", id, uid).Delete(&Collection{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		err := tx.Where("cid = ? AND uid = ?", id, uid).Find(&items).Error
+		if err != nil || len(items) == 0 {
+			return err
+		}
+		err = tx.Where("cid = ? AND uid = ?", id, uid).Delete(&UserCollectionBiz{}).Error
+		if err != nil {
+			return err
+		}
+		// 收藏数减一，和取消收藏保持一致
+		for _, item := range items {
+			err = tx.Model(&Interactive{}).
+				Where("biz = ? AND biz_id = ? AND collect_cnt > 0", item.Biz, item.BizId).
+				Updates(map[string]any{
+					"collect_cnt": gorm.Expr("collect_cnt - 1"),
+					"utime":       now,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return items, err
+}
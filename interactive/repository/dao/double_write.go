@@ -69,6 +69,39 @@ func (d *DoubleWriteDAO) IncrReadCnt(ctx context.Context, biz string, bizId int6
 	}
 }
 
+// write 按照当前的双写模式执行写操作，和 IncrReadCnt 一样，后写的那边失败不算失败
+func (d *DoubleWriteDAO) write(fn func(dao InteractiveDAO) error) error {
+	switch d.pattern.Load() {
+	case patternSrcOnly:
+		return fn(d.src)
+	case patternSrcFirst:
+		err := fn(d.src)
+		if err != nil {
+			return err
+		}
+		err = fn(d.dst)
+		if err != nil {
+			// log
+			// dst 写失败，不被认为是失败
+		}
+		return nil
+	case patternDstOnly:
+		return fn(d.dst)
+	case patternDstFirst:
+		err := fn(d.dst)
+		if err != nil {
+			return err
+		}
+		err = fn(d.src)
+		if err != nil {
+			// log
+		}
+		return nil
+	default:
+		return errors.New("未知的双写模式")
+	}
+}
+
 func (d *DoubleWriteDAO) UpdatePattern(pattern string) {
 	d.pattern.Store(pattern)
 }
@@ -98,12 +131,18 @@ func (d *DoubleWriteDAO) InsertCollectionBiz(ctx context.Context, cb UserCollect
 	panic("implement me")
 }
 
-func (d *DoubleWriteDAO) GetCollectionInfo(ctx context.Context, biz string, bizId, uid int64) (UserCollectionBiz, error) {
+func (d *DoubleWriteDAO) DeleteCollectionBiz(ctx context.Context, biz string, bizId, cid, uid int64) error {
+	return d.write(func(dao InteractiveDAO) error {
+		return dao.DeleteCollectionBiz(ctx, biz, bizId, cid, uid)
+	})
+}
+
+func (d *DoubleWriteDAO) GetCollectionInfo(ctx context.Context, biz string, bizId, cid, uid int64) (UserCollectionBiz, error) {
 	// TODO implement me
 	panic("implement me")
 }
 
-func (d *DoubleWriteDAO) BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64) error {
+func (d *DoubleWriteDAO) BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64, cnts []int64) error {
 	// TODO implement me
 	panic("implement me")
 }
@@ -112,3 +151,15 @@ func (d *DoubleWriteDAO) GetByIds(ctx context.Context, biz string, ids []int64)
 	// TODO implement me
 	panic("implement me")
 }
+
+func (d *DoubleWriteDAO) DeleteBiz(ctx context.Context, biz string, bizId int64) error {
+	return d.write(func(dao InteractiveDAO) error {
+		return dao.DeleteBiz(ctx, biz, bizId)
+	})
+}
+
+func (d *DoubleWriteDAO) IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int64) error {
+	return d.write(func(dao InteractiveDAO) error {
+		return dao.IncrCommentCnt(ctx, biz, bizId, delta)
+	})
+}
@@ -0,0 +1,73 @@
+package dao_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mrhelloboy/wehook/interactive/repository/dao"
+	daomocks "github.com/mrhelloboy/wehook/interactive/repository/dao/mocks"
+)
+
+func TestDoubleWriteDAO_DeleteCollectionBiz(t *testing.T) {
+	testCases := []struct {
+		name    string
+		pattern string
+		mock    func(ctrl *gomock.Controller) (dao.InteractiveDAO, dao.InteractiveDAO)
+		wantErr error
+	}{
+		{
+			name:    "只写源表",
+			pattern: "SRC_ONLY",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, dao.InteractiveDAO) {
+				src := daomocks.NewMockInteractiveDAO(ctrl)
+				src.EXPECT().DeleteCollectionBiz(gomock.Any(), "article", int64(1), int64(2), int64(3)).Return(nil)
+				return src, daomocks.NewMockInteractiveDAO(ctrl)
+			},
+		},
+		{
+			name:    "先写源表，目标表失败不算失败",
+			pattern: "SRC_FIRST",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, dao.InteractiveDAO) {
+				src := daomocks.NewMockInteractiveDAO(ctrl)
+				dst := daomocks.NewMockInteractiveDAO(ctrl)
+				src.EXPECT().DeleteCollectionBiz(gomock.Any(), "article", int64(1), int64(2), int64(3)).Return(nil)
+				dst.EXPECT().DeleteCollectionBiz(gomock.Any(), "article", int64(1), int64(2), int64(3)).
+					Return(errors.New("dst error"))
+				return src, dst
+			},
+		},
+		{
+			name:    "先写目标表，目标表失败就不写源表",
+			pattern: "DST_FIRST",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, dao.InteractiveDAO) {
+				dst := daomocks.NewMockInteractiveDAO(ctrl)
+				dst.EXPECT().DeleteCollectionBiz(gomock.Any(), "article", int64(1), int64(2), int64(3)).
+					Return(errors.New("dst error"))
+				return daomocks.NewMockInteractiveDAO(ctrl), dst
+			},
+			wantErr: errors.New("dst error"),
+		},
+		{
+			name:    "未知模式",
+			pattern: "UNKNOWN",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, dao.InteractiveDAO) {
+				return daomocks.NewMockInteractiveDAO(ctrl), daomocks.NewMockInteractiveDAO(ctrl)
+			},
+			wantErr: errors.New("未知的双写模式"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d := dao.NewDoubleWriteDAO(tc.mock(ctrl))
+			d.UpdatePattern(tc.pattern)
+			err := d.DeleteCollectionBiz(context.Background(), "article", 1, 2, 3)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
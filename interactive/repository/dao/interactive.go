@@ -2,6 +2,7 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"github.com/mrhelloboy/wehook/pkg/migrator"
 	"time"
 
@@ -10,7 +11,11 @@ import (
 	"gorm.io/gorm"
 )
 
-var ErrRecordNotFound = gorm.ErrRecordNotFound
+var (
+	ErrRecordNotFound = gorm.ErrRecordNotFound
+	// ErrDuplicateCollection 已经收藏过了，同一个资源只能收藏到一个收藏夹里面
+	ErrDuplicateCollection = errors.New("重复收藏")
+)
 
 //go:generate mockgen -source=./interactive.go -package=daomocks -destination=mocks/interactive.mock.go InteractiveDAO
 
@@ -20,10 +25,19 @@ type InteractiveDAO interface {
 	GetLikeInfo(ctx context.Context, biz string, bizId, uid int64) (UserLikeBiz, error)
 	DeleteLikeInfo(ctx context.Context, biz string, bizId, uid int64) error
 	Get(ctx context.Context, biz string, bizId int64) (Interactive, error)
+	// InsertCollectionBiz 已经收藏过的返回 ErrDuplicateCollection，收藏数不变
 	InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) error
-	GetCollectionInfo(ctx context.Context, biz string, bizId, uid int64) (UserCollectionBiz, error)
-	BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64) error
+	// DeleteCollectionBiz 取消收藏，cid 为 0 表示不限收藏夹，没有收藏过的返回 ErrRecordNotFound
+	DeleteCollectionBiz(ctx context.Context, biz string, bizId, cid, uid int64) error
+	// GetCollectionInfo cid 为 0 表示不限收藏夹
+	GetCollectionInfo(ctx context.Context, biz string, bizId, cid, uid int64) (UserCollectionBiz, error)
+	// BatchIncrReadCnt 批量增加阅读数，bizs、ids 和 cnts 一一对应，第 i 个资源的阅读数加 cnts[i]
+	BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64, cnts []int64) error
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]Interactive, error)
+	// DeleteBiz 资源被彻底删除了，计数、点赞和收藏记录都删掉
+	DeleteBiz(ctx context.Context, biz string, bizId int64) error
+	// IncrCommentCnt 评论数加 delta，delta 可以是负数，评论数最少减到 0
+	IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int64) error
 }
 
 type gormInteractiveDAO struct {
@@ -37,18 +51,18 @@ func (g *gormInteractiveDAO) GetByIds(ctx context.Context, biz string, ids []int
 }
 
 // BatchIncrReadCnt 批量增加阅读数
-// 尽管 BatchIncrReadCnt 在循环中逐个调用 IncrReadCnt 方法，
+// 尽管 BatchIncrReadCnt 在循环中逐个更新，
 // 但通过事务管理和数据库内部对批量操作的优化，实现了在批量更新阅读量场景下的高效
-func (g *gormInteractiveDAO) BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64) error {
+func (g *gormInteractiveDAO) BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64, cnts []int64) error {
 	// 为什么快？
 	// A：十条消息调用十次 IncrReadCnt，
 	// B: 就是批量
 	// 事务本身的开销，A 是 B 的十倍
 	// 刷新 redolog, undolog, binlog 到磁盘，A 是十次，B 是一次
 	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		txDAO := NewGormInteractiveDAO(tx)
+		txDAO := &gormInteractiveDAO{db: tx}
 		for i := range bizs {
-			err := txDAO.IncrReadCnt(ctx, bizs[i], ids[i])
+			err := txDAO.incrReadCnt(ctx, bizs[i], ids[i], cnts[i])
 			if err != nil {
 				// 记下日志
 				// 或者 return err
@@ -82,10 +96,13 @@ func (g *gormInteractiveDAO) InsertCollectionBiz(ctx context.Context, cb UserCol
 	cb.Utime = now
 	cb.Ctime = now
 	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// 插入收藏记录
-		err := tx.WithContext(ctx).Create(&cb).Error
-		if err != nil {
-			return err
+		// 插入收藏记录，已经收藏过的就什么都不做，避免收藏数被重复累加
+		res := tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&cb)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrDuplicateCollection
 		}
 		// 更新收藏数
 		return tx.Clauses(clause.OnConflict{DoUpdates: clause.Assignments(map[string]any{
@@ -101,10 +118,39 @@ func (g *gormInteractiveDAO) InsertCollectionBiz(ctx context.Context, cb UserCol
 	})
 }
 
-func (g *gormInteractiveDAO) GetCollectionInfo(ctx context.Context, biz string, bizId int64, uid int64) (UserCollectionBiz, error) {
+func (g *gormInteractiveDAO) DeleteCollectionBiz(ctx context.Context, biz string, bizId, cid, uid int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Where("biz = ? AND biz_id = ? AND uid = ?", biz, bizId, uid)
+		if cid > 0 {
+			query = query.Where("cid = ?", cid)
+		}
+		res := query.Delete(&UserCollectionBiz{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			// 没收藏过，收藏数也不能减
+			return ErrRecordNotFound
+		}
+		// 收藏数减一
+		return tx.Model(&Interactive{}).
+			Where("biz = ? AND biz_id = ? AND collect_cnt > 0", biz, bizId).
+			Updates(map[string]any{
+				"collect_cnt": gorm.Expr("collect_cnt - 1"),
+				"utime":       now,
+			}).Error
+	})
+}
+
+func (g *gormInteractiveDAO) GetCollectionInfo(ctx context.Context, biz string, bizId, cid, uid int64) (UserCollectionBiz, error) {
 	var res UserCollectionBiz
-	err := g.db.WithContext(ctx).
-		Where("biz = ? AND biz_id = ? AND uid = ?", biz, bizId, uid).First(&res).Error
+	query := g.db.WithContext(ctx).
+		Where("biz = ? AND biz_id = ? AND uid = ?", biz, bizId, uid)
+	if cid > 0 {
+		query = query.Where("cid = ?", cid)
+	}
+	err := query.First(&res).Error
 	return res, err
 }
 
@@ -167,19 +213,59 @@ func (g *gormInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, biz
 	})
 }
 
+func (g *gormInteractiveDAO) DeleteBiz(ctx context.Context, biz string, bizId int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("biz = ? AND biz_id = ?", biz, bizId).Delete(&UserLikeBiz{}).Error
+		if err != nil {
+			return err
+		}
+		// 收藏夹里面的内容数是实时统计的，删掉收藏记录就可以了
+		err = tx.Where("biz = ? AND biz_id = ?", biz, bizId).Delete(&UserCollectionBiz{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("biz = ? AND biz_id = ?", biz, bizId).Delete(&Interactive{}).Error
+	})
+}
+
+func (g *gormInteractiveDAO) IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int64) error {
+	now := time.Now().UnixMilli()
+	// 还没有记录的时候，减少评论数没有意义，直接记 0
+	cnt := delta
+	if cnt < 0 {
+		cnt = 0
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"comment_cnt": gorm.Expr("GREATEST(comment_cnt + ?, 0)", delta),
+			"utime":       now,
+		}),
+	}).Create(&Interactive{
+		BizId:      bizId,
+		Biz:        biz,
+		CommentCnt: cnt,
+		Ctime:      now,
+		Utime:      now,
+	}).Error
+}
+
 // IncrReadCnt 增加阅读量(新增或者更新）
 func (g *gormInteractiveDAO) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+	return g.incrReadCnt(ctx, biz, bizId, 1)
+}
+
+func (g *gormInteractiveDAO) incrReadCnt(ctx context.Context, biz string, bizId int64, cnt int64) error {
 	now := time.Now().UnixMilli()
 	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
 		DoUpdates: clause.Assignments(map[string]any{
 			// 使用 SQL 表达式更新，可以解决并发问题，保证数据一致性
-			"read_cnt": gorm.Expr("read_cnt + 1"),
-			"utime":    time.Now().UnixMilli(),
+			"read_cnt": gorm.Expr("read_cnt + ?", cnt),
+			"utime":    now,
 		}),
 	}).Create(&Interactive{
 		BizId:   bizId,
 		Biz:     biz,
-		ReadCnt: 1,
+		ReadCnt: cnt,
 		Ctime:   now,
 		Utime:   now,
 	}).Error
@@ -198,6 +284,7 @@ type Interactive struct {
 	ReadCnt    int64
 	LikeCnt    int64
 	CollectCnt int64
+	CommentCnt int64
 	Ctime      int64
 	Utime      int64
 }
@@ -227,7 +314,7 @@ type UserLikeBiz struct {
 type Collection struct {
 	Id    int64  `gorm:"primary_key,autoIncrement"`
 	Name  string `gorm:"type=varchar(1024)"`
-	Uid   int64
+	Uid   int64  `gorm:"index"`
 	Ctime int64
 	Utime int64
 }
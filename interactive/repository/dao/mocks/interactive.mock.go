@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: ./interactive.go
+// Source: interactive/repository/dao/interactive.go
 //
 // Generated by this command:
 //
-//	mockgen -source=./interactive.go -package=daomocks -destination=mocks/interactive.mock.go InteractiveDAO
+//	mockgen -source=interactive/repository/dao/interactive.go -package=daomocks -destination=interactive/repository/dao/mocks/interactive.mock.go
 //
 
 // Package daomocks is a generated GoMock package.
@@ -13,7 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
-	dao "github.com/mrhelloboy/wehook/internal/repository/dao"
+	dao "github.com/mrhelloboy/wehook/interactive/repository/dao"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +40,48 @@ func (m *MockInteractiveDAO) EXPECT() *MockInteractiveDAOMockRecorder {
 	return m.recorder
 }
 
+// BatchIncrReadCnt mocks base method.
+func (m *MockInteractiveDAO) BatchIncrReadCnt(ctx context.Context, bizs []string, ids, cnts []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrReadCnt", ctx, bizs, ids, cnts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrReadCnt indicates an expected call of BatchIncrReadCnt.
+func (mr *MockInteractiveDAOMockRecorder) BatchIncrReadCnt(ctx, bizs, ids, cnts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).BatchIncrReadCnt), ctx, bizs, ids, cnts)
+}
+
+// DeleteBiz mocks base method.
+func (m *MockInteractiveDAO) DeleteBiz(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBiz", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBiz indicates an expected call of DeleteBiz.
+func (mr *MockInteractiveDAOMockRecorder) DeleteBiz(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBiz", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteBiz), ctx, biz, bizId)
+}
+
+// DeleteCollectionBiz mocks base method.
+func (m *MockInteractiveDAO) DeleteCollectionBiz(ctx context.Context, biz string, bizId, cid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectionBiz", ctx, biz, bizId, cid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollectionBiz indicates an expected call of DeleteCollectionBiz.
+func (mr *MockInteractiveDAOMockRecorder) DeleteCollectionBiz(ctx, biz, bizId, cid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectionBiz", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteCollectionBiz), ctx, biz, bizId, cid, uid)
+}
+
 // DeleteLikeInfo mocks base method.
 func (m *MockInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, bizId, uid int64) error {
 	m.ctrl.T.Helper()
@@ -69,19 +111,34 @@ func (mr *MockInteractiveDAOMockRecorder) Get(ctx, biz, bizId any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractiveDAO)(nil).Get), ctx, biz, bizId)
 }
 
+// GetByIds mocks base method.
+func (m *MockInteractiveDAO) GetByIds(ctx context.Context, biz string, ids []int64) ([]dao.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, biz, ids)
+	ret0, _ := ret[0].([]dao.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockInteractiveDAOMockRecorder) GetByIds(ctx, biz, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveDAO)(nil).GetByIds), ctx, biz, ids)
+}
+
 // GetCollectionInfo mocks base method.
-func (m *MockInteractiveDAO) GetCollectionInfo(ctx context.Context, biz string, bizId, uid int64) (dao.UserCollectionBiz, error) {
+func (m *MockInteractiveDAO) GetCollectionInfo(ctx context.Context, biz string, bizId, cid, uid int64) (dao.UserCollectionBiz, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCollectionInfo", ctx, biz, bizId, uid)
+	ret := m.ctrl.Call(m, "GetCollectionInfo", ctx, biz, bizId, cid, uid)
 	ret0, _ := ret[0].(dao.UserCollectionBiz)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCollectionInfo indicates an expected call of GetCollectionInfo.
-func (mr *MockInteractiveDAOMockRecorder) GetCollectionInfo(ctx, biz, bizId, uid any) *gomock.Call {
+func (mr *MockInteractiveDAOMockRecorder) GetCollectionInfo(ctx, biz, bizId, cid, uid any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).GetCollectionInfo), ctx, biz, bizId, uid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).GetCollectionInfo), ctx, biz, bizId, cid, uid)
 }
 
 // GetLikeInfo mocks base method.
@@ -99,6 +156,20 @@ func (mr *MockInteractiveDAOMockRecorder) GetLikeInfo(ctx, biz, bizId, uid any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikeInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).GetLikeInfo), ctx, biz, bizId, uid)
 }
 
+// IncrCommentCnt mocks base method.
+func (m *MockInteractiveDAO) IncrCommentCnt(ctx context.Context, biz string, bizId, delta int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrCommentCnt", ctx, biz, bizId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrCommentCnt indicates an expected call of IncrCommentCnt.
+func (mr *MockInteractiveDAOMockRecorder) IncrCommentCnt(ctx, biz, bizId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCommentCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).IncrCommentCnt), ctx, biz, bizId, delta)
+}
+
 // IncrReadCnt mocks base method.
 func (m *MockInteractiveDAO) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
 	m.ctrl.T.Helper()
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ecodeclub/ekit/slice"
 
@@ -11,6 +12,28 @@ import (
 	"github.com/mrhelloboy/wehook/pkg/logger"
 )
 
+var (
+	ErrDuplicateCollection    = dao.ErrDuplicateCollection
+	ErrCollectionItemNotFound = dao.ErrRecordNotFound
+)
+
+// BatchIncrReadCntError 批量增加阅读数的时候，数据库已经更新成功，
+// 但是这些资源的缓存没有更新成功，要等缓存过期才会恢复
+type BatchIncrReadCntError struct {
+	Bizs   []string
+	BizIds []int64
+	// Err 其中一个缓存错误
+	Err error
+}
+
+func (e *BatchIncrReadCntError) Error() string {
+	return fmt.Sprintf("%d 个资源的阅读数缓存更新失败: %v", len(e.BizIds), e.Err)
+}
+
+func (e *BatchIncrReadCntError) Unwrap() error {
+	return e.Err
+}
+
 //go:generate mockgen -source=./interactive.go -package=repomocks -destination=mocks/interactive.mock.go InteractiveRepository
 type InteractiveRepository interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
@@ -18,11 +41,18 @@ type InteractiveRepository interface {
 	BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64) error
 	IncrLike(ctx context.Context, biz string, bizId, uid int64) error
 	DecrLike(ctx context.Context, biz string, bizId, uid int64) error
+	// AddCollectionItem 已经收藏过的返回 ErrDuplicateCollection
 	AddCollectionItem(ctx context.Context, biz string, bizId, cid int64, uid int64) error
+	// DeleteCollectionItem cid 为 0 表示不限收藏夹，没有收藏过的返回 ErrCollectionItemNotFound
+	DeleteCollectionItem(ctx context.Context, biz string, bizId, cid int64, uid int64) error
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
 	Liked(ctx context.Context, biz string, id int64, uid int64) (bool, error)
 	Collected(ctx context.Context, biz string, id int64, uid int64) (bool, error)
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error)
+	// DeleteBiz 删除资源的所有交互数据
+	DeleteBiz(ctx context.Context, biz string, bizId int64) error
+	// IncrCommentCnt 评论数加 delta，delta 为负数表示评论被删除
+	IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int64) error
 }
 
 type cachedInteractiveRepo struct {
@@ -32,17 +62,63 @@ type cachedInteractiveRepo struct {
 }
 
 // BatchIncrReadCnt 批量增加阅读数
-// bizs 和 bizIds 的长度必须相等，且一一对应
+// bizs 和 bizIds 的长度必须相等，且一一对应。
+// 同一批里面重复的资源会先合并成一次 +n，数据库和缓存都只更新一次。
+// 数据库更新成功但是部分缓存更新失败的时候，返回 *BatchIncrReadCntError，
+// 这时候不能重试，不然数据库的阅读数会被重复累加
 func (c *cachedInteractiveRepo) BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64) error {
-	err := c.dao.BatchIncrReadCnt(ctx, bizs, bizIds)
+	aggBizs, aggIds, cnts := aggregateReadCnt(bizs, bizIds)
+	if len(aggIds) == 0 {
+		return nil
+	}
+	err := c.dao.BatchIncrReadCnt(ctx, aggBizs, aggIds, cnts)
 	if err != nil {
 		return err
 	}
-	// todo: 需要批量的去修改 Redis，所以 lua 脚本需要修改 或者 该用 pipeline
-	// c.cache.IncrReadCntIfPresent()
+	return batchIncrReadCntCache(ctx, c.cache, aggBizs, aggIds, cnts)
+}
+
+// batchIncrReadCntCache 批量更新缓存里面的阅读数，部分失败的返回 *BatchIncrReadCntError
+func batchIncrReadCntCache(ctx context.Context, c cache.InteractiveCache, bizs []string, bizIds []int64, cnts []int64) error {
+	errs := c.BatchIncrReadCntIfPresent(ctx, bizs, bizIds, cnts)
+	var failed BatchIncrReadCntError
+	for i, er := range errs {
+		if er != nil {
+			failed.Bizs = append(failed.Bizs, bizs[i])
+			failed.BizIds = append(failed.BizIds, bizIds[i])
+			failed.Err = er
+		}
+	}
+	if len(failed.BizIds) > 0 {
+		return &failed
+	}
 	return nil
 }
 
+// aggregateReadCnt 合并重复的资源，保持第一次出现的顺序
+func aggregateReadCnt(bizs []string, bizIds []int64) ([]string, []int64, []int64) {
+	type key struct {
+		biz   string
+		bizId int64
+	}
+	idx := make(map[key]int, len(bizIds))
+	resBizs := make([]string, 0, len(bizIds))
+	resIds := make([]int64, 0, len(bizIds))
+	cnts := make([]int64, 0, len(bizIds))
+	for i := range bizIds {
+		k := key{biz: bizs[i], bizId: bizIds[i]}
+		if j, ok := idx[k]; ok {
+			cnts[j]++
+			continue
+		}
+		idx[k] = len(resIds)
+		resBizs = append(resBizs, k.biz)
+		resIds = append(resIds, k.bizId)
+		cnts = append(cnts, 1)
+	}
+	return resBizs, resIds, cnts
+}
+
 func (c *cachedInteractiveRepo) GetByIds(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error) {
 	vals, err := c.dao.GetByIds(ctx, biz, ids)
 	if err != nil {
@@ -75,6 +151,30 @@ func (c *cachedInteractiveRepo) AddCollectionItem(ctx context.Context, biz strin
 	return c.cache.IncrCollectCntIfPresent(ctx, biz, bizId)
 }
 
+func (c *cachedInteractiveRepo) DeleteCollectionItem(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error {
+	err := c.dao.DeleteCollectionBiz(ctx, biz, bizId, cid, uid)
+	if err != nil {
+		return err
+	}
+	return c.cache.DecrCollectCntIfPresent(ctx, biz, bizId)
+}
+
+func (c *cachedInteractiveRepo) DeleteBiz(ctx context.Context, biz string, bizId int64) error {
+	err := c.dao.DeleteBiz(ctx, biz, bizId)
+	if err != nil {
+		return err
+	}
+	return c.cache.Del(ctx, biz, bizId)
+}
+
+func (c *cachedInteractiveRepo) IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int64) error {
+	err := c.dao.IncrCommentCnt(ctx, biz, bizId, delta)
+	if err != nil {
+		return err
+	}
+	return c.cache.IncrCommentCntIfPresent(ctx, biz, bizId, delta)
+}
+
 func (c *cachedInteractiveRepo) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
 	// 从缓存中获取阅读数、点赞数和收藏数
 	intr, err := c.cache.Get(ctx, biz, bizId)
@@ -113,7 +213,7 @@ func (c *cachedInteractiveRepo) Liked(ctx context.Context, biz string, id int64,
 
 // Collected 用户是否收藏过
 func (c *cachedInteractiveRepo) Collected(ctx context.Context, biz string, bizId int64, uid int64) (bool, error) {
-	_, err := c.dao.GetCollectionInfo(ctx, biz, bizId, uid)
+	_, err := c.dao.GetCollectionInfo(ctx, biz, bizId, 0, uid)
 	switch err {
 	case nil:
 		return true, nil
@@ -157,6 +257,7 @@ func (c *cachedInteractiveRepo) toDomain(intr dao.Interactive) domain.Interactiv
 		BizId:      intr.BizId,
 		LikeCnt:    intr.LikeCnt,
 		CollectCnt: intr.CollectCnt,
+		CommentCnt: intr.CommentCnt,
 		ReadCnt:    intr.ReadCnt,
 	}
 }
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mrhelloboy/wehook/interactive/repository/cache"
+	cachemocks "github.com/mrhelloboy/wehook/interactive/repository/cache/mocks"
+	"github.com/mrhelloboy/wehook/interactive/repository/dao"
+	daomocks "github.com/mrhelloboy/wehook/interactive/repository/dao/mocks"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+func TestCachedInteractiveRepo_BatchIncrReadCnt(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache)
+		bizs    []string
+		bizIds  []int64
+		wantErr error
+	}{
+		{
+			name: "合并重复的资源",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().BatchIncrReadCnt(gomock.Any(),
+					[]string{"article", "article", "comment"},
+					[]int64{1, 2, 1},
+					[]int64{2, 1, 1}).Return(nil)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().BatchIncrReadCntIfPresent(gomock.Any(),
+					[]string{"article", "article", "comment"},
+					[]int64{1, 2, 1},
+					[]int64{2, 1, 1}).Return([]error{nil, nil, nil})
+				return d, c
+			},
+			bizs:   []string{"article", "article", "comment", "article"},
+			bizIds: []int64{1, 2, 1, 1},
+		},
+		{
+			name: "数据库失败",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().BatchIncrReadCnt(gomock.Any(), []string{"article"}, []int64{1}, []int64{1}).
+					Return(errors.New("db error"))
+				return d, cachemocks.NewMockInteractiveCache(ctrl)
+			},
+			bizs:    []string{"article"},
+			bizIds:  []int64{1},
+			wantErr: errors.New("db error"),
+		},
+		{
+			name: "部分缓存失败",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().BatchIncrReadCnt(gomock.Any(), []string{"article", "article"}, []int64{1, 2}, []int64{1, 1}).
+					Return(nil)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().BatchIncrReadCntIfPresent(gomock.Any(), []string{"article", "article"}, []int64{1, 2}, []int64{1, 1}).
+					Return([]error{nil, errors.New("redis error")})
+				return d, c
+			},
+			bizs:   []string{"article", "article"},
+			bizIds: []int64{1, 2},
+			wantErr: &BatchIncrReadCntError{
+				Bizs:   []string{"article"},
+				BizIds: []int64{2},
+				Err:    errors.New("redis error"),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepo(d, c, &logger.NopLogger{})
+			err := repo.BatchIncrReadCnt(context.Background(), tc.bizs, tc.bizIds)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
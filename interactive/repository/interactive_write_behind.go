@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mrhelloboy/wehook/interactive/repository/cache"
+	"github.com/mrhelloboy/wehook/interactive/repository/dao"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+// WriteBehindInteractiveRepo 阅读数先累加到 ReadCntBuffer 里面，
+// 再由 Flush 定时批量写回数据库，热门文章的阅读不会每次都更新数据库。
+// 缓存里面的阅读数是实时的；缓存没有命中的时候，从数据库拿到的阅读数会少掉还没写回的部分。
+// 其它的方法直接交给被装饰的 InteractiveRepository
+type WriteBehindInteractiveRepo struct {
+	InteractiveRepository
+	dao    dao.InteractiveDAO
+	cache  cache.InteractiveCache
+	buffer cache.ReadCntBuffer
+	l      logger.Logger
+}
+
+func NewWriteBehindInteractiveRepo(repo InteractiveRepository, dao dao.InteractiveDAO,
+	cache cache.InteractiveCache, buffer cache.ReadCntBuffer, l logger.Logger) *WriteBehindInteractiveRepo {
+	return &WriteBehindInteractiveRepo{
+		InteractiveRepository: repo,
+		dao:                   dao,
+		cache:                 cache,
+		buffer:                buffer,
+		l:                     l,
+	}
+}
+
+func (w *WriteBehindInteractiveRepo) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+	return w.BatchIncrReadCnt(ctx, []string{biz}, []int64{bizId})
+}
+
+// BatchIncrReadCnt 和 cachedInteractiveRepo 一样，
+// 累加到 buffer 成功之后缓存部分失败，返回 *BatchIncrReadCntError，不能重试
+func (w *WriteBehindInteractiveRepo) BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64) error {
+	aggBizs, aggIds, cnts := aggregateReadCnt(bizs, bizIds)
+	if len(aggIds) == 0 {
+		return nil
+	}
+	err := w.buffer.Incr(ctx, aggBizs, aggIds, cnts)
+	if err != nil {
+		return err
+	}
+	return batchIncrReadCntCache(ctx, w.cache, aggBizs, aggIds, cnts)
+}
+
+// Flush 把累加的阅读数写回数据库。
+// 写回失败的下一次 Flush 会重新写；写回成功但是 Ack 失败的，下一次会重复写，
+// 也就是说阅读数不会丢，但是极端情况下会多算一批
+func (w *WriteBehindInteractiveRepo) Flush(ctx context.Context) error {
+	bizs, bizIds, cnts, err := w.buffer.Take(ctx)
+	if err != nil {
+		return err
+	}
+	if len(bizIds) > 0 {
+		err = w.dao.BatchIncrReadCnt(ctx, bizs, bizIds, cnts)
+		if err != nil {
+			return err
+		}
+	}
+	return w.buffer.Ack(ctx)
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mrhelloboy/wehook/interactive/repository/cache"
+	cachemocks "github.com/mrhelloboy/wehook/interactive/repository/cache/mocks"
+	"github.com/mrhelloboy/wehook/interactive/repository/dao"
+	daomocks "github.com/mrhelloboy/wehook/interactive/repository/dao/mocks"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+func TestWriteBehindInteractiveRepo_BatchIncrReadCnt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 不会直接写数据库
+	d := daomocks.NewMockInteractiveDAO(ctrl)
+	c := cachemocks.NewMockInteractiveCache(ctrl)
+	c.EXPECT().BatchIncrReadCntIfPresent(gomock.Any(), []string{"article", "article"}, []int64{1, 2}, []int64{2, 1}).
+		Return([]error{nil, nil})
+	buffer := cachemocks.NewMockReadCntBuffer(ctrl)
+	buffer.EXPECT().Incr(gomock.Any(), []string{"article", "article"}, []int64{1, 2}, []int64{2, 1}).Return(nil)
+
+	repo := NewWriteBehindInteractiveRepo(nil, d, c, buffer, &logger.NopLogger{})
+	err := repo.BatchIncrReadCnt(context.Background(), []string{"article", "article", "article"}, []int64{1, 2, 1})
+	assert.NoError(t, err)
+}
+
+func TestWriteBehindInteractiveRepo_Flush(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.ReadCntBuffer)
+		wantErr error
+	}{
+		{
+			name: "写回成功",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.ReadCntBuffer) {
+				buffer := cachemocks.NewMockReadCntBuffer(ctrl)
+				buffer.EXPECT().Take(gomock.Any()).
+					Return([]string{"article", "article"}, []int64{1, 2}, []int64{10, 3}, nil)
+				buffer.EXPECT().Ack(gomock.Any()).Return(nil)
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().BatchIncrReadCnt(gomock.Any(), []string{"article", "article"}, []int64{1, 2}, []int64{10, 3}).
+					Return(nil)
+				return d, buffer
+			},
+		},
+		{
+			name: "没有要写回的",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.ReadCntBuffer) {
+				buffer := cachemocks.NewMockReadCntBuffer(ctrl)
+				buffer.EXPECT().Take(gomock.Any()).Return([]string{}, []int64{}, []int64{}, nil)
+				buffer.EXPECT().Ack(gomock.Any()).Return(nil)
+				return daomocks.NewMockInteractiveDAO(ctrl), buffer
+			},
+		},
+		{
+			name: "写回数据库失败，不能确认",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.ReadCntBuffer) {
+				buffer := cachemocks.NewMockReadCntBuffer(ctrl)
+				buffer.EXPECT().Take(gomock.Any()).
+					Return([]string{"article"}, []int64{1}, []int64{10}, nil)
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().BatchIncrReadCnt(gomock.Any(), []string{"article"}, []int64{1}, []int64{10}).
+					Return(errors.New("db error"))
+				return d, buffer
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, buffer := tc.mock(ctrl)
+			repo := NewWriteBehindInteractiveRepo(nil, d, nil, buffer, &logger.NopLogger{})
+			err := repo.Flush(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mrhelloboy/wehook/interactive/domain"
+	"github.com/mrhelloboy/wehook/interactive/events"
+	"github.com/mrhelloboy/wehook/interactive/repository"
+)
+
+// ErrCollectionNotFound 收藏夹不存在，或者不是自己的
+var ErrCollectionNotFound = repository.ErrCollectionNotFound
+
+func (i *interactiveSrv) CreateCollection(ctx context.Context, uid int64, name string) (int64, error) {
+	return i.colRepo.Create(ctx, domain.Collection{
+		Uid:  uid,
+		Name: name,
+	})
+}
+
+func (i *interactiveSrv) RenameCollection(ctx context.Context, id, uid int64, name string) error {
+	return i.colRepo.Rename(ctx, id, uid, name)
+}
+
+func (i *interactiveSrv) DeleteCollection(ctx context.Context, id, uid int64) error {
+	items, err := i.colRepo.Delete(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	// 和取消收藏一样，通知下游收藏数变了
+	for _, item := range items {
+		i.produceCollectEvent(events.CollectEvent{
+			Biz: item.Biz, BizId: item.BizId, Uid: uid, Cid: id, Collected: false,
+		})
+	}
+	return nil
+}
+
+func (i *interactiveSrv) ListCollections(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error) {
+	return i.colRepo.List(ctx, uid, offset, limit)
+}
+
+func (i *interactiveSrv) ListCollectionItems(ctx context.Context, cid, uid int64, offset, limit int) ([]domain.CollectionItem, error) {
+	if _, err := i.getCollection(ctx, cid, uid); err != nil {
+		return nil, err
+	}
+	return i.colRepo.ListItems(ctx, cid, offset, limit)
+}
+
+// getCollection 查找 uid 自己的收藏夹，别人的收藏夹也当作不存在
+func (i *interactiveSrv) getCollection(ctx context.Context, cid, uid int64) (domain.Collection, error) {
+	col, err := i.colRepo.GetById(ctx, cid)
+	if errors.Is(err, repository.ErrCollectionNotFound) || (err == nil && col.Uid != uid) {
+		return domain.Collection{}, ErrCollectionNotFound
+	}
+	return col, err
+}
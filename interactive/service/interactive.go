@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/mrhelloboy/wehook/interactive/domain"
+	"github.com/mrhelloboy/wehook/interactive/events"
 	"github.com/mrhelloboy/wehook/interactive/repository"
 	"github.com/mrhelloboy/wehook/pkg/logger"
 
@@ -17,19 +20,40 @@ type InteractiveService interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
 	Like(ctx context.Context, biz string, id int64, uid int64) error
 	CancelLike(ctx context.Context, biz string, id int64, uid int64) error
+	// Collect 收藏到 cid 收藏夹，cid 为 0 表示默认收藏夹
 	Collect(ctx context.Context, biz string, bizId, cid, uid int64) error
+	// CancelCollect 取消收藏，cid 为 0 表示不管在哪个收藏夹
+	CancelCollect(ctx context.Context, biz string, bizId, cid, uid int64) error
 	Get(ctx context.Context, biz string, bizId, uid int64) (domain.Interactive, error)
 	GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error)
+	// DeleteBiz 资源被彻底删除的时候调用，删除之后再调用 Get 拿到的是零值
+	DeleteBiz(ctx context.Context, biz string, bizId int64) error
+	// IncrCommentCnt 评论数加 delta，评论被删除的时候 delta 为负数
+	IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int64) error
+
+	// 收藏夹管理，只能操作自己的收藏夹
+
+	CreateCollection(ctx context.Context, uid int64, name string) (int64, error)
+	RenameCollection(ctx context.Context, id, uid int64, name string) error
+	// DeleteCollection 删除收藏夹，里面的内容也一并取消收藏
+	DeleteCollection(ctx context.Context, id, uid int64) error
+	ListCollections(ctx context.Context, uid int64, offset, limit int) ([]domain.Collection, error)
+	ListCollectionItems(ctx context.Context, cid, uid int64, offset, limit int) ([]domain.CollectionItem, error)
 }
 
 type interactiveSrv struct {
 	interRepo repository.InteractiveRepository
+	colRepo   repository.CollectionRepository
+	producer  events.Producer
 	l         logger.Logger
 }
 
-func NewInteractiveService(interRepo repository.InteractiveRepository, l logger.Logger) InteractiveService {
+func NewInteractiveService(interRepo repository.InteractiveRepository, colRepo repository.CollectionRepository,
+	producer events.Producer, l logger.Logger) InteractiveService {
 	return &interactiveSrv{
 		interRepo: interRepo,
+		colRepo:   colRepo,
+		producer:  producer,
 		l:         l,
 	}
 }
@@ -46,8 +70,42 @@ func (i *interactiveSrv) GetByIds(ctx context.Context, biz string, bizIds []int6
 	return res, nil
 }
 
+func (i *interactiveSrv) DeleteBiz(ctx context.Context, biz string, bizId int64) error {
+	return i.interRepo.DeleteBiz(ctx, biz, bizId)
+}
+
+func (i *interactiveSrv) IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int64) error {
+	return i.interRepo.IncrCommentCnt(ctx, biz, bizId, delta)
+}
+
 func (i *interactiveSrv) Collect(ctx context.Context, biz string, bizId, cid, uid int64) error {
-	return i.interRepo.AddCollectionItem(ctx, biz, bizId, cid, uid)
+	if cid > 0 {
+		// 不能收藏到别人的收藏夹里面
+		if _, err := i.getCollection(ctx, cid, uid); err != nil {
+			return err
+		}
+	}
+	err := i.interRepo.AddCollectionItem(ctx, biz, bizId, cid, uid)
+	if errors.Is(err, repository.ErrDuplicateCollection) {
+		// 重复收藏，收藏数没有变，当成功处理
+		return nil
+	}
+	if err == nil {
+		i.produceCollectEvent(events.CollectEvent{Biz: biz, BizId: bizId, Uid: uid, Cid: cid, Collected: true})
+	}
+	return err
+}
+
+func (i *interactiveSrv) CancelCollect(ctx context.Context, biz string, bizId, cid, uid int64) error {
+	err := i.interRepo.DeleteCollectionItem(ctx, biz, bizId, cid, uid)
+	if errors.Is(err, repository.ErrCollectionItemNotFound) {
+		// 本来就没有收藏，收藏数没有变，当成功处理
+		return nil
+	}
+	if err == nil {
+		i.produceCollectEvent(events.CollectEvent{Biz: biz, BizId: bizId, Uid: uid, Cid: cid, Collected: false})
+	}
+	return err
 }
 
 func (i *interactiveSrv) Get(ctx context.Context, biz string, bizId, uid int64) (domain.Interactive, error) {
@@ -84,12 +142,44 @@ func (i *interactiveSrv) Get(ctx context.Context, biz string, bizId, uid int64)
 
 // Like 点赞
 func (i *interactiveSrv) Like(ctx context.Context, biz string, id int64, uid int64) error {
-	return i.interRepo.IncrLike(ctx, biz, id, uid)
+	err := i.interRepo.IncrLike(ctx, biz, id, uid)
+	if err == nil {
+		i.produceLikeEvent(events.LikeEvent{Biz: biz, BizId: id, Uid: uid, Liked: true})
+	}
+	return err
 }
 
 // CancelLike 取消点赞
 func (i *interactiveSrv) CancelLike(ctx context.Context, biz string, id int64, uid int64) error {
-	return i.interRepo.DecrLike(ctx, biz, id, uid)
+	err := i.interRepo.DecrLike(ctx, biz, id, uid)
+	if err == nil {
+		i.produceLikeEvent(events.LikeEvent{Biz: biz, BizId: id, Uid: uid, Liked: false})
+	}
+	return err
+}
+
+// produceLikeEvent 异步发送点赞事件，发送失败不影响点赞本身
+func (i *interactiveSrv) produceLikeEvent(evt events.LikeEvent) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := i.producer.ProduceLikeEvent(ctx, evt)
+		if er != nil {
+			i.l.Error("发送点赞事件失败", logger.Int64("bizId", evt.BizId), logger.Error(er))
+		}
+	}()
+}
+
+// produceCollectEvent 异步发送收藏事件，发送失败不影响收藏本身
+func (i *interactiveSrv) produceCollectEvent(evt events.CollectEvent) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		er := i.producer.ProduceCollectEvent(ctx, evt)
+		if er != nil {
+			i.l.Error("发送收藏事件失败", logger.Int64("bizId", evt.BizId), logger.Error(er))
+		}
+	}()
 }
 
 // IncrReadCnt 增加阅读量
@@ -26,9 +26,13 @@ var thirdPartySet = wire.NewSet(
 
 var interactiveSvcProvider = wire.NewSet(
 	service.NewInteractiveService,
-	repository.NewCachedInteractiveRepo,
+	ioc.InitInteractiveRepo,
+	wire.Bind(new(repository.InteractiveRepository), new(*repository.WriteBehindInteractiveRepo)),
+	repository.NewCachedCollectionRepo,
 	dao.NewGormInteractiveDAO,
+	dao.NewGORMCollectionDAO,
 	cache.NewRedisInteractiveCache,
+	cache.NewRedisReadCntBuffer,
 )
 
 var migratorProvider = wire.NewSet(
@@ -43,9 +47,12 @@ func InitAPP() *App {
 		interactiveSvcProvider,
 		migratorProvider,
 		events.NewInteractiveReadEventConsumer,
+		events.NewInteractiveReadEventV1Consumer,
+		events.NewKafkaProducer,
 		grpc.NewInteractiveServiceServer,
 		ioc.NewConsumers,
 		ioc.InitGRPCxServer,
+		ioc.InitReadCntFlushJob,
 		wire.Struct(new(App), "*"),
 	)
 	return new(App)
@@ -28,21 +28,28 @@ func InitAPP() *App {
 	interactiveDAO := dao.NewGormInteractiveDAO(db)
 	cmdable := ioc.InitRedis()
 	interactiveCache := cache.NewRedisInteractiveCache(cmdable)
-	interactiveRepository := repository.NewCachedInteractiveRepo(interactiveDAO, interactiveCache, logger)
-	interactiveService := service.NewInteractiveService(interactiveRepository, logger)
+	readCntBuffer := cache.NewRedisReadCntBuffer(cmdable)
+	writeBehindInteractiveRepo := ioc.InitInteractiveRepo(interactiveDAO, interactiveCache, readCntBuffer, logger)
+	collectionDAO := dao.NewGORMCollectionDAO(db)
+	collectionRepository := repository.NewCachedCollectionRepo(collectionDAO, interactiveCache, logger)
+	client := ioc.InitKafka()
+	syncProducer := ioc.InitSyncProducer(client)
+	producer := events.NewKafkaProducer(syncProducer)
+	interactiveService := service.NewInteractiveService(writeBehindInteractiveRepo, collectionRepository, producer, logger)
 	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
 	server := ioc.InitGRPCxServer(logger, interactiveServiceServer)
-	client := ioc.InitKafka()
-	interactiveReadEventConsumer := events.NewInteractiveReadEventConsumer(client, interactiveRepository, logger)
+	interactiveReadEventConsumer := events.NewInteractiveReadEventConsumer(client, writeBehindInteractiveRepo, logger)
+	interactiveReadEventV1Consumer := events.NewInteractiveReadEventV1Consumer(client, writeBehindInteractiveRepo, logger)
 	consumer := ioc.InitFixDataConsumer(logger, srcDB, dstDB, client)
-	v := ioc.NewConsumers(interactiveReadEventConsumer, consumer)
-	syncProducer := ioc.InitSyncProducer(client)
-	producer := ioc.InitMigradatorProducer(syncProducer)
-	ginxServer := ioc.InitMigratorWeb(logger, srcDB, dstDB, doubleWritePool, producer)
+	v := ioc.NewConsumers(interactiveReadEventConsumer, interactiveReadEventV1Consumer, consumer)
+	eventsProducer := ioc.InitMigradatorProducer(syncProducer)
+	ginxServer := ioc.InitMigratorWeb(logger, srcDB, dstDB, doubleWritePool, eventsProducer)
+	readCntFlushJob := ioc.InitReadCntFlushJob(writeBehindInteractiveRepo, cmdable, logger)
 	app := &App{
-		server:    server,
-		consumers: v,
-		webAdmin:  ginxServer,
+		server:       server,
+		consumers:    v,
+		webAdmin:     ginxServer,
+		readCntFlush: readCntFlushJob,
 	}
 	return app
 }
@@ -51,6 +58,6 @@ func InitAPP() *App {
 
 var thirdPartySet = wire.NewSet(ioc.InitDST, ioc.InitSRC, ioc.InitBizDB, ioc.InitDoubleWritePool, ioc.InitLogger, ioc.InitKafka, ioc.InitSyncProducer, ioc.InitRedis)
 
-var interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, repository.NewCachedInteractiveRepo, dao.NewGormInteractiveDAO, cache.NewRedisInteractiveCache)
+var interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, ioc.InitInteractiveRepo, wire.Bind(new(repository.InteractiveRepository), new(*repository.WriteBehindInteractiveRepo)), repository.NewCachedCollectionRepo, dao.NewGormInteractiveDAO, dao.NewGORMCollectionDAO, cache.NewRedisInteractiveCache, cache.NewRedisReadCntBuffer)
 
 var migratorProvider = wire.NewSet(ioc.InitMigratorWeb, ioc.InitFixDataConsumer, ioc.InitMigradatorProducer)
@@ -1,25 +1,44 @@
 package domain
 
-import "time"
+import (
+	"time"
+)
 
 type Article struct {
-	Id      int64
-	Title   string
+	Id    int64
+	Title string
+	// Content Markdown 格式，给读者看的时候渲染成 HTML
 	Content string
-	Author  Author
-	Status  ArticleStatus
-	Ctime   time.Time
-	Utime   time.Time
+	// Tags 标签，顺序就是作者填写的顺序
+	Tags     []string
+	Category string
+	// Cover 封面图片的 URL
+	Cover string
+	// Summary 作者填写的摘要，为空的时候从内容里面生成
+	Summary string
+	// Abstract 列表里面展示的摘要，保存的时候生成，作者填了 Summary 就是 Summary
+	Abstract string
+	Author   Author
+	Status   ArticleStatus
+	Ctime    time.Time
+	Utime    time.Time
 }
 
-// Abstract 返回文章摘要
-func (a Article) Abstract() string {
-	// 考虑中文问题
-	cs := []rune(a.Content)
-	if len(cs) < 100 {
-		return a.Content
-	}
-	return string(cs[:100])
+// ArticleCursor 按照 (Utime, Id) 从新到旧翻页的位置，就是上一页最后一篇的 Utime 和 Id。
+// 零值表示从最新的开始
+type ArticleCursor struct {
+	Utime time.Time
+	Id    int64
+}
+
+func (c ArticleCursor) IsZero() bool {
+	return c.Utime.IsZero() && c.Id == 0
+}
+
+// TagCount 标签和使用这个标签的已发表文章数
+type TagCount struct {
+	Tag string
+	Cnt int64
 }
 
 type Author struct {
@@ -34,6 +53,8 @@ const (
 	ArticleStatusUnpublished
 	ArticleStatusPublished
 	ArticleStatusPrivate
+	// ArticleStatusDeleted 在回收站里面，过了保留期会被彻底删除
+	ArticleStatusDeleted
 )
 
 // ToUint8 converts the status to uint8.
@@ -55,7 +76,52 @@ func (s ArticleStatus) String() string {
 		return "unpublished"
 	case ArticleStatusPublished:
 		return "published"
+	case ArticleStatusDeleted:
+		return "deleted"
 	default:
 		return "unknown"
 	}
 }
+
+// ArticleRevision 文章的一个历史版本
+type ArticleRevision struct {
+	Id        int64
+	ArticleId int64
+	AuthorId  int64
+	Title     string
+	Content   string
+	Status    ArticleStatus
+	Ctime     time.Time
+}
+
+// ArticleRevisionDiff 两个版本之间的差异，unified diff 格式
+type ArticleRevisionDiff struct {
+	From        ArticleRevision
+	To          ArticleRevision
+	TitleDiff   string
+	ContentDiff string
+}
+
+type PublishScheduleStatus uint8
+
+const (
+	PublishScheduleStatusUnknown PublishScheduleStatus = iota
+	PublishScheduleStatusPending
+	PublishScheduleStatusDone
+	PublishScheduleStatusCancelled
+	// PublishScheduleStatusFailed 重试了好几次都没有发表成功
+	PublishScheduleStatusFailed
+)
+
+// PublishSchedule 文章的定时发表
+type PublishSchedule struct {
+	Id          int64
+	ArticleId   int64
+	AuthorId    int64
+	PublishTime time.Time
+	Status      PublishScheduleStatus
+	// Retries 发表失败了几次
+	Retries int
+	Ctime   time.Time
+	Utime   time.Time
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// Comment 评论，只有两层：RootId 为 0 的是根评论，
+// 回复都挂在根评论下面，Pid 是直接回复的那一条
+type Comment struct {
+	Id      int64
+	Biz     string
+	BizId   int64
+	Uid     int64
+	RootId  int64
+	Pid     int64
+	Content string
+	// Pinned 被作者置顶，只有根评论可以置顶
+	Pinned bool
+	// ReplyCnt 根评论下面的回复数，查询根评论列表的时候才有
+	ReplyCnt int64
+	Ctime    time.Time
+	Utime    time.Time
+}
+
+// IsRoot 是否是根评论
+func (c Comment) IsRoot() bool {
+	return c.RootId == 0
+}
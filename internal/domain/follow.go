@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// FollowRelation Follower 关注了 Followee
+type FollowRelation struct {
+	Id       int64
+	Follower int64
+	Followee int64
+	Ctime    time.Time
+}
+
+// FollowStatics 关注和粉丝的数量
+type FollowStatics struct {
+	Uid int64
+	// Followers 粉丝数
+	Followers int64
+	// Followees 关注了多少人
+	Followees int64
+}
+
+// FeedItem 推到某个用户收件箱里面的一篇文章
+type FeedItem struct {
+	Uid    int64
+	Aid    int64
+	Author int64
+	// Utime 文章在线上库的更新时间，和文章列表一样按照它排序
+	Utime time.Time
+}
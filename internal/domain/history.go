@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// HistoryRecord 阅读记录，同一个用户对同一个资源只保留一条
+type HistoryRecord struct {
+	Uid   int64
+	Biz   string
+	BizId int64
+	// ReadTime 最后一次阅读的时间
+	ReadTime time.Time
+}
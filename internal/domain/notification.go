@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// NotificationType 通知的类型
+type NotificationType uint8
+
+const (
+	NotificationTypeUnknown NotificationType = iota
+	// NotificationTypeLike 点赞了你的资源
+	NotificationTypeLike
+	// NotificationTypeCollect 收藏了你的资源
+	NotificationTypeCollect
+	// NotificationTypeComment 评论了你的资源
+	NotificationTypeComment
+	// NotificationTypeReply 回复了你的评论
+	NotificationTypeReply
+)
+
+func (t NotificationType) ToUint8() uint8 {
+	return uint8(t)
+}
+
+// Notification 站内通知。同一个资源上同一类还没读的通知会合并成一条，
+// 比如 "张三等 12 人赞了你的帖子"，读了之后再来的会是新的一条
+type Notification struct {
+	Id int64
+	// Uid 接收通知的人
+	Uid   int64
+	Type  NotificationType
+	Biz   string
+	BizId int64
+	// LastActor 最近一个触发通知的人
+	LastActor     int64
+	LastActorName string
+	// ActorCnt 合并了多少个不同的人
+	ActorCnt int64
+	Read     bool
+	Utime    time.Time
+}
@@ -0,0 +1,13 @@
+package domain
+
+// RankingAction 会影响实时榜单分数的行为
+type RankingAction uint8
+
+const (
+	RankingActionUnknown RankingAction = iota
+	RankingActionRead
+	RankingActionLike
+	RankingActionCancelLike
+	RankingActionCollect
+	RankingActionCancelCollect
+)
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// ArticleSortBy 搜索结果的排序方式
+type ArticleSortBy uint8
+
+const (
+	// ArticleSortByUtime 按更新时间倒序，默认
+	ArticleSortByUtime ArticleSortBy = iota
+	// ArticleSortByPopularity 按热度（阅读、点赞、收藏）倒序
+	ArticleSortByPopularity
+)
+
+// ArticleSearchQuery 文章搜索条件，零值的字段表示不过滤
+type ArticleSearchQuery struct {
+	// Keyword 关键字，多个关键字用空白分隔，标题或者内容要包含全部关键字
+	Keyword  string
+	AuthorId int64
+	Status   ArticleStatus
+	// Start 和 End 是对更新时间的过滤，左闭右开
+	Start  time.Time
+	End    time.Time
+	SortBy ArticleSortBy
+	Offset int
+	Limit  int
+}
@@ -3,11 +3,16 @@ package domain
 import "time"
 
 type User struct {
-	Id         int64
-	Email      string
-	Password   string
-	Phone      string
-	Nickname   string
+	Id       int64
+	Email    string
+	Password string
+	Phone    string
+	Nickname string
+	// Birthday 零值表示没有填
+	Birthday time.Time
+	AboutMe  string
+	// Avatar 头像的地址
+	Avatar     string
 	WechatInfo WechatInfo
 	Ctime      time.Time
 }
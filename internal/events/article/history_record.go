@@ -2,22 +2,33 @@ package article
 
 import (
 	"context"
+	"time"
 
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository"
 	"github.com/mrhelloboy/wehook/pkg/logger"
 	"github.com/mrhelloboy/wehook/pkg/saramax"
 
 	"github.com/IBM/sarama"
 )
 
+// readEvents read_article 和 read_article_v1 两种消息的字段并集，按照 topic 区分是哪一种
+type readEvents struct {
+	ReadEvent
+	ReadEventV1
+}
+
 type HistoryReadEventConsumer struct {
 	client sarama.Client
+	repo   repository.HistoryRecordRepository
 	l      logger.Logger
 }
 
-func NewHistoryReadEventConsumer(client sarama.Client, l logger.Logger,
+func NewHistoryReadEventConsumer(client sarama.Client, repo repository.HistoryRecordRepository, l logger.Logger,
 ) *HistoryReadEventConsumer {
 	return &HistoryReadEventConsumer{
 		client: client,
+		repo:   repo,
 		l:      l,
 	}
 }
@@ -29,8 +40,8 @@ func (r *HistoryReadEventConsumer) Start() error {
 	}
 	go func() {
 		err := cg.Consume(context.Background(),
-			[]string{"read_article"},
-			saramax.NewHandler[ReadEvent](r.l, r.Consume))
+			[]string{topicReadEvent, topicReadEventV1},
+			saramax.NewHandler[readEvents](r.l, r.Consume))
 		if err != nil {
 			r.l.Error("退出了消费循环异常", logger.Error(err))
 		}
@@ -38,10 +49,42 @@ func (r *HistoryReadEventConsumer) Start() error {
 	return err
 }
 
-// Consume 这个不是幂等的
-func (r *HistoryReadEventConsumer) Consume(msg *sarama.ConsumerMessage, t ReadEvent) error {
-	// ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	// defer cancel()
-	// return r.repo.Add(ctx, t.Aid, t.Uid)
-	panic("implement me")
+// Consume 阅读时间只会往后更新，所以重复消费是幂等的，批量消息部分失败整条重试也没问题
+func (r *HistoryReadEventConsumer) Consume(msg *sarama.ConsumerMessage, evts readEvents) error {
+	if msg.Topic != topicReadEventV1 {
+		return r.record(msg, evts.ReadEvent)
+	}
+	if len(evts.Uids) != len(evts.Aids) {
+		r.l.Error("批量阅读事件的 Uids 和 Aids 长度不一致",
+			logger.Int64("uids", int64(len(evts.Uids))),
+			logger.Int64("aids", int64(len(evts.Aids))))
+		return nil
+	}
+	for i := range evts.Aids {
+		err := r.record(msg, ReadEvent{Uid: evts.Uids[i], Aid: evts.Aids[i]})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *HistoryReadEventConsumer) record(msg *sarama.ConsumerMessage, t ReadEvent) error {
+	if t.Uid <= 0 {
+		// 没有登录的用户不记录
+		return nil
+	}
+	// 用消息的时间作为阅读时间，积压的消息也能记对时间
+	readTime := msg.Timestamp
+	if readTime.IsZero() {
+		readTime = time.Now()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return r.repo.AddRecord(ctx, domain.HistoryRecord{
+		Uid:      t.Uid,
+		Biz:      "article",
+		BizId:    t.Aid,
+		ReadTime: readTime,
+	})
 }
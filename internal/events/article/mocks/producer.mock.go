@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: producer.go
+// Source: internal/events/article/producer.go
 //
 // Generated by this command:
 //
-//	mockgen -source=producer.go -destination=mocks/producer.mock.go -package=evtArtMock Producer
+//	mockgen -source=internal/events/article/producer.go -package=evtArtMock -destination=internal/events/article/mocks/producer.mock.go
 //
 
 // Package evtArtMock is a generated GoMock package.
@@ -40,6 +40,34 @@ func (m *MockProducer) EXPECT() *MockProducerMockRecorder {
 	return m.recorder
 }
 
+// ProduceCommentEvent mocks base method.
+func (m *MockProducer) ProduceCommentEvent(ctx context.Context, evt article.CommentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceCommentEvent", ctx, evt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProduceCommentEvent indicates an expected call of ProduceCommentEvent.
+func (mr *MockProducerMockRecorder) ProduceCommentEvent(ctx, evt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceCommentEvent", reflect.TypeOf((*MockProducer)(nil).ProduceCommentEvent), ctx, evt)
+}
+
+// ProducePublishEvent mocks base method.
+func (m *MockProducer) ProducePublishEvent(ctx context.Context, evt article.PublishEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProducePublishEvent", ctx, evt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProducePublishEvent indicates an expected call of ProducePublishEvent.
+func (mr *MockProducerMockRecorder) ProducePublishEvent(ctx, evt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProducePublishEvent", reflect.TypeOf((*MockProducer)(nil).ProducePublishEvent), ctx, evt)
+}
+
 // ProduceReadEvent mocks base method.
 func (m *MockProducer) ProduceReadEvent(ctx context.Context, evt article.ReadEvent) error {
 	m.ctrl.T.Helper()
@@ -55,9 +83,11 @@ func (mr *MockProducerMockRecorder) ProduceReadEvent(ctx, evt any) *gomock.Call
 }
 
 // ProduceReadEventV1 mocks base method.
-func (m *MockProducer) ProduceReadEventV1(ctx context.Context, evts article.ReadEventV1) {
+func (m *MockProducer) ProduceReadEventV1(ctx context.Context, evts article.ReadEventV1) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "ProduceReadEventV1", ctx, evts)
+	ret := m.ctrl.Call(m, "ProduceReadEventV1", ctx, evts)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // ProduceReadEventV1 indicates an expected call of ProduceReadEventV1.
@@ -3,6 +3,7 @@ package article
 import (
 	"context"
 	"encoding/json"
+	"errors"
 
 	"github.com/IBM/sarama"
 )
@@ -10,9 +11,20 @@ import (
 //go:generate mockgen -source=producer.go -destination=mocks/producer.mock.go -package=evtArtMock Producer
 type Producer interface {
 	ProduceReadEvent(ctx context.Context, evt ReadEvent) error
-	ProduceReadEventV1(ctx context.Context, evts ReadEventV1)
+	ProduceReadEventV1(ctx context.Context, evts ReadEventV1) error
+	ProducePublishEvent(ctx context.Context, evt PublishEvent) error
+	ProduceCommentEvent(ctx context.Context, evt CommentEvent) error
 }
 
+const (
+	topicReadEvent    = "read_article"
+	topicReadEventV1  = "read_article_v1"
+	topicPublishEvent = "article_published"
+	topicCommentEvent = "comment_event"
+)
+
+var errInvalidReadEventV1 = errors.New("批量阅读事件的 Uids 和 Aids 长度不一致")
+
 type kafkaProducer struct {
 	producer sarama.SyncProducer
 }
@@ -29,16 +41,56 @@ func (k *kafkaProducer) ProduceReadEvent(ctx context.Context, evt ReadEvent) err
 		return err
 	}
 	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
-		Topic: "read_article",
+		Topic: topicReadEvent,
 		Value: sarama.ByteEncoder(data),
 	})
 	return err
 }
 
 // ProduceReadEventV1 批量阅读文章事件(批量方式)
-func (k *kafkaProducer) ProduceReadEventV1(ctx context.Context, evts ReadEventV1) {
-	// TODO implement me
-	panic("implement me")
+// 一批事件合并成一条消息发送到 read_article_v1，由消费者拆开处理
+func (k *kafkaProducer) ProduceReadEventV1(ctx context.Context, evts ReadEventV1) error {
+	if len(evts.Uids) != len(evts.Aids) {
+		return errInvalidReadEventV1
+	}
+	if len(evts.Aids) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(evts)
+	if err != nil {
+		return err
+	}
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topicReadEventV1,
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
+
+// ProducePublishEvent 发表文章事件，用来把文章推到粉丝的关注流里面
+func (k *kafkaProducer) ProducePublishEvent(ctx context.Context, evt PublishEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topicPublishEvent,
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
+
+// ProduceCommentEvent 发表评论事件，用来通知帖子的作者或者被回复的人
+func (k *kafkaProducer) ProduceCommentEvent(ctx context.Context, evt CommentEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topicCommentEvent,
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
 }
 
 type ReadEvent struct {
@@ -46,7 +98,26 @@ type ReadEvent struct {
 	Aid int64
 }
 
+// ReadEventV1 批量阅读事件，Uids 和 Aids 按下标一一对应，
+// 即第 i 个事件是 Uids[i] 阅读了 Aids[i]。
+// 序列化成 JSON：{"Uids":[1,2],"Aids":[10,20]}
 type ReadEventV1 struct {
 	Uids []int64
 	Aids []int64
 }
+
+// PublishEvent 作者 Uid 发表了文章 Aid，重新发表也会发
+type PublishEvent struct {
+	Aid int64
+	Uid int64
+}
+
+// CommentEvent Uid 在资源上发表了评论 Cid
+type CommentEvent struct {
+	Biz   string
+	BizId int64
+	Uid   int64
+	Cid   int64
+	// ReplyTo 被回复的评论的作者，根评论为 0
+	ReplyTo int64
+}
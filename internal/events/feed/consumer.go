@@ -0,0 +1,59 @@
+package feed
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/mrhelloboy/wehook/internal/service"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+	"github.com/mrhelloboy/wehook/pkg/saramax"
+)
+
+const topicPublishEvent = "article_published"
+
+// PublishEvent 和 article 包里面的发表事件一样
+type PublishEvent struct {
+	Aid int64
+	Uid int64
+}
+
+// PublishEventConsumer 消费发表事件，把文章推到粉丝的收件箱里面
+type PublishEventConsumer struct {
+	client sarama.Client
+	svc    service.FeedService
+	l      logger.Logger
+}
+
+func NewPublishEventConsumer(client sarama.Client, svc service.FeedService, l logger.Logger) *PublishEventConsumer {
+	return &PublishEventConsumer{
+		client: client,
+		svc:    svc,
+		l:      l,
+	}
+}
+
+func (c *PublishEventConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("feed_push", c.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicPublishEvent},
+			saramax.NewHandler[PublishEvent](c.l, c.Consume))
+		if err != nil {
+			c.l.Error("退出了消费循环异常", logger.Error(err))
+		}
+	}()
+	return err
+}
+
+// Consume 收件箱按照 (uid, aid) 去重，重复消费是幂等的
+func (c *PublishEventConsumer) Consume(msg *sarama.ConsumerMessage, evt PublishEvent) error {
+	// 要分批查粉丝、写收件箱，给长一点的时间
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	return c.svc.Push(ctx, evt.Aid)
+}
@@ -0,0 +1,101 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/service"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+	"github.com/mrhelloboy/wehook/pkg/saramax"
+)
+
+const (
+	topicLikeEvent    = "like_event"
+	topicCollectEvent = "collect_event"
+	topicCommentEvent = "comment_event"
+)
+
+// InteractionEvent 点赞、收藏、评论事件的字段并集，按照 topic 区分是哪一种
+type InteractionEvent struct {
+	Biz   string
+	BizId int64
+	Uid   int64
+	// Liked 点赞事件
+	Liked bool
+	// Collected 收藏事件
+	Collected bool
+	// ReplyTo 评论事件，被回复的评论的作者，根评论为 0
+	ReplyTo int64
+}
+
+// InteractionEventConsumer 消费交互事件，给资源的作者或者被回复的人发站内通知
+type InteractionEventConsumer struct {
+	client sarama.Client
+	svc    service.NotificationService
+	l      logger.Logger
+}
+
+func NewInteractionEventConsumer(client sarama.Client, svc service.NotificationService, l logger.Logger) *InteractionEventConsumer {
+	return &InteractionEventConsumer{
+		client: client,
+		svc:    svc,
+		l:      l,
+	}
+}
+
+func (c *InteractionEventConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("notification", c.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicLikeEvent, topicCollectEvent, topicCommentEvent},
+			saramax.NewHandler[InteractionEvent](c.l, c.Consume))
+		if err != nil {
+			c.l.Error("退出了消费循环异常", logger.Error(err))
+		}
+	}()
+	return err
+}
+
+// Consume 同一个人重复消费不会重复计数，取消点赞、取消收藏不通知
+func (c *InteractionEventConsumer) Consume(msg *sarama.ConsumerMessage, evt InteractionEvent) error {
+	n, ok := c.toNotification(msg.Topic, evt)
+	if !ok {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return c.svc.Notify(ctx, n)
+}
+
+func (c *InteractionEventConsumer) toNotification(topic string, evt InteractionEvent) (domain.Notification, bool) {
+	n := domain.Notification{
+		Biz:       evt.Biz,
+		BizId:     evt.BizId,
+		LastActor: evt.Uid,
+	}
+	switch topic {
+	case topicLikeEvent:
+		n.Type = domain.NotificationTypeLike
+		return n, evt.Liked
+	case topicCollectEvent:
+		n.Type = domain.NotificationTypeCollect
+		return n, evt.Collected
+	case topicCommentEvent:
+		// 回复通知被回复的人，根评论通知资源的作者
+		if evt.ReplyTo > 0 {
+			n.Type = domain.NotificationTypeReply
+			n.Uid = evt.ReplyTo
+		} else {
+			n.Type = domain.NotificationTypeComment
+		}
+		return n, true
+	default:
+		return domain.Notification{}, false
+	}
+}
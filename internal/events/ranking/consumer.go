@@ -0,0 +1,120 @@
+package ranking
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/service"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+	"github.com/mrhelloboy/wehook/pkg/saramax"
+)
+
+const (
+	topicReadEvent    = "read_article"
+	topicReadEventV1  = "read_article_v1"
+	topicLikeEvent    = "like_event"
+	topicCollectEvent = "collect_event"
+)
+
+// InteractionEvent 阅读、点赞、收藏事件的字段并集，按照 topic 区分是哪一种
+type InteractionEvent struct {
+	Uid int64
+	// Aid 阅读事件
+	Aid int64
+	// Uids 和 Aids 批量阅读事件，按下标一一对应
+	Uids []int64
+	Aids []int64
+	// Biz 和 BizId 点赞、收藏事件
+	Biz       string
+	BizId     int64
+	Liked     bool
+	Collected bool
+}
+
+// RealtimeRankingConsumer 消费交互事件，增量更新实时榜单
+type RealtimeRankingConsumer struct {
+	client sarama.Client
+	svc    service.RealtimeRankingService
+	l      logger.Logger
+}
+
+func NewRealtimeRankingConsumer(client sarama.Client, svc service.RealtimeRankingService, l logger.Logger) *RealtimeRankingConsumer {
+	return &RealtimeRankingConsumer{
+		client: client,
+		svc:    svc,
+		l:      l,
+	}
+}
+
+func (r *RealtimeRankingConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("ranking_realtime", r.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicReadEvent, topicReadEventV1, topicLikeEvent, topicCollectEvent},
+			saramax.NewHandler[InteractionEvent](r.l, r.Consume))
+		if err != nil {
+			r.l.Error("退出了消费循环异常", logger.Error(err))
+		}
+	}()
+	return err
+}
+
+// Consume 这个不是幂等的，重复消费会让分数偏高，对榜单来说可以接受
+func (r *RealtimeRankingConsumer) Consume(msg *sarama.ConsumerMessage, evt InteractionEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if msg.Topic == topicReadEventV1 {
+		return r.consumeBatchRead(ctx, evt)
+	}
+	aid, action := r.toAction(msg.Topic, evt)
+	if action == domain.RankingActionUnknown {
+		return nil
+	}
+	return r.svc.Incr(ctx, aid, action)
+}
+
+func (r *RealtimeRankingConsumer) consumeBatchRead(ctx context.Context, evt InteractionEvent) error {
+	if len(evt.Uids) != len(evt.Aids) {
+		r.l.Error("批量阅读事件的 Uids 和 Aids 长度不一致",
+			logger.Int64("uids", int64(len(evt.Uids))),
+			logger.Int64("aids", int64(len(evt.Aids))))
+		return nil
+	}
+	for _, aid := range evt.Aids {
+		if err := r.svc.Incr(ctx, aid, domain.RankingActionRead); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RealtimeRankingConsumer) toAction(topic string, evt InteractionEvent) (int64, domain.RankingAction) {
+	switch topic {
+	case topicReadEvent:
+		return evt.Aid, domain.RankingActionRead
+	case topicLikeEvent:
+		if evt.Biz != "article" {
+			return 0, domain.RankingActionUnknown
+		}
+		if evt.Liked {
+			return evt.BizId, domain.RankingActionLike
+		}
+		return evt.BizId, domain.RankingActionCancelLike
+	case topicCollectEvent:
+		if evt.Biz != "article" {
+			return 0, domain.RankingActionUnknown
+		}
+		if evt.Collected {
+			return evt.BizId, domain.RankingActionCollect
+		}
+		return evt.BizId, domain.RankingActionCancelCollect
+	default:
+		return 0, domain.RankingActionUnknown
+	}
+}
@@ -0,0 +1,424 @@
+//go:build e2e
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	dao "github.com/mrhelloboy/wehook/internal/repository/dao/article"
+)
+
+// AuthorDAOTestSuite GORM 和 MongoDB 的 AuthorDAO 共用的测试套件
+// 只通过 DAO 的接口读写，不假设 ID 的生成方式（自增或者雪花算法）
+type AuthorDAOTestSuite struct {
+	suite.Suite
+	dao dao.AuthorDAO
+	// reset 清空所有数据，每一个测试方法执行后执行
+	reset func(t *testing.T)
+}
+
+func (s *AuthorDAOTestSuite) TearDownTest() {
+	s.reset(s.T())
+}
+
+func (s *AuthorDAOTestSuite) TestInsertAndGet() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	id, err := s.dao.Insert(ctx, dao.Article{
+		Title:    "标题",
+		Content:  "内容",
+		Abstract: "摘要",
+		AuthorId: 123,
+		Status:   domain.ArticleStatusUnpublished.ToUint8(),
+	})
+	require.NoError(t, err)
+	assert.True(t, id > 0)
+
+	art, err := s.dao.GetById(ctx, id)
+	require.NoError(t, err)
+	assert.True(t, art.Ctime > 0)
+	assert.Equal(t, art.Ctime, art.Utime)
+	// 内容放在对象存储里面的时候才有 key
+	art.Ctime, art.Utime, art.ContentKey = 0, 0, ""
+	assert.Equal(t, dao.Article{
+		Id:       id,
+		Title:    "标题",
+		Content:  "内容",
+		Abstract: "摘要",
+		AuthorId: 123,
+		Status:   domain.ArticleStatusUnpublished.ToUint8(),
+	}, art)
+
+	// 新建的时候记第一个版本
+	revs, err := s.dao.ListRevisions(ctx, id, 123, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, revs, 1)
+	assert.Equal(t, "内容", revs[0].Content)
+
+	_, err = s.dao.GetById(ctx, id+1)
+	assert.Equal(t, dao.ErrRecordNotFound, err)
+	_, err = s.dao.GetPubById(ctx, id)
+	assert.Equal(t, dao.ErrRecordNotFound, err)
+}
+
+func (s *AuthorDAOTestSuite) TestUpdateById() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	id := s.insert(ctx, 123, "标题")
+
+	// 修改他人的帖子
+	err := s.dao.UpdateById(ctx, dao.Article{Id: id, Title: "新标题", Content: "新内容", AuthorId: 789})
+	assert.Error(t, err)
+
+	err = s.dao.UpdateById(ctx, dao.Article{
+		Id:       id,
+		Title:    "新标题",
+		Content:  "新内容",
+		AuthorId: 123,
+		Status:   domain.ArticleStatusUnpublished.ToUint8(),
+	})
+	require.NoError(t, err)
+	art, err := s.dao.GetById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "新标题", art.Title)
+	assert.Equal(t, "新内容", art.Content)
+	assert.True(t, art.Utime >= art.Ctime)
+
+	revs, err := s.dao.ListRevisions(ctx, id, 123, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, revs, 2)
+	// 新的在前
+	assert.Equal(t, "新内容", revs[0].Content)
+	rev, err := s.dao.GetRevision(ctx, revs[1].Id)
+	require.NoError(t, err)
+	assert.Equal(t, revs[1], rev)
+}
+
+func (s *AuthorDAOTestSuite) TestSync() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	id, err := s.dao.Sync(ctx, dao.Article{
+		Title:    "标题",
+		Content:  "内容",
+		AuthorId: 123,
+		Status:   domain.ArticleStatusPublished.ToUint8(),
+	})
+	require.NoError(t, err)
+	pub, err := s.dao.GetPubById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "标题", pub.Title)
+	assert.Equal(t, domain.ArticleStatusPublished.ToUint8(), pub.Status)
+
+	// 再次发表更新线上库
+	_, err = s.dao.Sync(ctx, dao.Article{
+		Id:       id,
+		Title:    "新标题",
+		Content:  "新内容",
+		AuthorId: 123,
+		Status:   domain.ArticleStatusPublished.ToUint8(),
+	})
+	require.NoError(t, err)
+	pub, err = s.dao.GetPubById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "新标题", pub.Title)
+
+	// 他人不能发表
+	_, err = s.dao.Sync(ctx, dao.Article{Id: id, Title: "x", AuthorId: 789})
+	assert.Error(t, err)
+
+	// 撤回
+	err = s.dao.SyncStatus(ctx, id, 123, domain.ArticleStatusPrivate.ToUint8())
+	require.NoError(t, err)
+	art, err := s.dao.GetById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ArticleStatusPrivate.ToUint8(), art.Status)
+	pub, err = s.dao.GetPubById(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ArticleStatusPrivate.ToUint8(), pub.Status)
+
+	err = s.dao.SyncStatus(ctx, id, 789, domain.ArticleStatusPrivate.ToUint8())
+	assert.Error(t, err)
+}
+
+func (s *AuthorDAOTestSuite) TestList() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	id1 := s.insert(ctx, 123, "1")
+	id2 := s.insert(ctx, 123, "2")
+	id3 := s.insert(ctx, 123, "3")
+	s.insert(ctx, 789, "他人的")
+	_, err := s.dao.Sync(ctx, dao.Article{Id: id2, Title: "2", AuthorId: 123,
+		Status: domain.ArticleStatusPublished.ToUint8()})
+	require.NoError(t, err)
+
+	// 最近修改的在前，下一页从上一页的最后一篇开始
+	arts, err := s.dao.GetByAuthor(ctx, 123, dao.Cursor{}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id2, id3}, s.ids(arts))
+	// 列表展示用摘要
+	assert.Equal(t, "摘要", arts[1].Abstract)
+	arts, err = s.dao.GetByAuthor(ctx, 123, dao.Cursor{Utime: arts[1].Utime, Id: arts[1].Id}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id1}, s.ids(arts))
+
+	arts, err = s.dao.ListAfterId(ctx, id1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id2, id3}, s.ids(arts))
+
+	pubs, err := s.dao.ListPubAfterId(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, pubs, 1)
+	assert.Equal(t, id2, pubs[0].Id)
+
+	// 发表之后撤回成仅自己可见的也不要
+	_, err = s.dao.Sync(ctx, dao.Article{Id: id3, Title: "3", AuthorId: 123,
+		Status: domain.ArticleStatusPublished.ToUint8()})
+	require.NoError(t, err)
+	require.NoError(t, s.dao.SyncStatus(ctx, id3, 123, domain.ArticleStatusPrivate.ToUint8()))
+
+	// 只有公开发表的，只给 utime 就是早于这个时间的
+	arts, err = s.dao.ListPub(ctx, dao.Cursor{Utime: time.Now().Add(time.Second).UnixMilli()}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id2}, s.ids(arts))
+	arts, err = s.dao.ListPub(ctx, dao.Cursor{}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id2}, s.ids(arts))
+	arts, err = s.dao.ListPub(ctx, dao.Cursor{Utime: 1}, 10)
+	require.NoError(t, err)
+	assert.Empty(t, arts)
+}
+
+func (s *AuthorDAOTestSuite) TestTrash() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	draftId := s.insert(ctx, 123, "草稿")
+	pubId, err := s.dao.Sync(ctx, dao.Article{Title: "发表", Content: "内容", AuthorId: 123,
+		Status: domain.ArticleStatusPublished.ToUint8()})
+	require.NoError(t, err)
+
+	// 他人不能删除
+	assert.Equal(t, dao.ErrRecordNotFound, s.dao.Delete(ctx, draftId, 789))
+	require.NoError(t, s.dao.Delete(ctx, draftId, 123))
+	time.Sleep(time.Millisecond * 2)
+	require.NoError(t, s.dao.Delete(ctx, pubId, 123))
+	// 不能重复删除
+	assert.Equal(t, dao.ErrRecordNotFound, s.dao.Delete(ctx, pubId, 123))
+
+	pub, err := s.dao.GetPubById(ctx, pubId)
+	require.NoError(t, err)
+	assert.Equal(t, dao.StatusDeleted, pub.Status)
+
+	// 回收站里面的不出现在列表里，也不能修改
+	arts, err := s.dao.GetByAuthor(ctx, 123, dao.Cursor{}, 10)
+	require.NoError(t, err)
+	assert.Empty(t, arts)
+	arts, err = s.dao.ListPub(ctx, dao.Cursor{}, 10)
+	require.NoError(t, err)
+	assert.Empty(t, arts)
+	assert.Error(t, s.dao.UpdateById(ctx, dao.Article{Id: draftId, Title: "x", AuthorId: 123}))
+	assert.Error(t, s.dao.SyncStatus(ctx, pubId, 123, domain.ArticleStatusPublished.ToUint8()))
+
+	// 最近删除的在前
+	arts, err = s.dao.GetDeletedByAuthor(ctx, 123, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{pubId, draftId}, s.ids(arts))
+	arts, err = s.dao.ListDeletedBefore(ctx, time.Now().Add(time.Second).UnixMilli(), 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{draftId, pubId}, s.ids(arts))
+	arts, err = s.dao.ListDeletedBefore(ctx, time.Now().Add(time.Second).UnixMilli(), 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{pubId}, s.ids(arts))
+	arts, err = s.dao.ListDeletedBefore(ctx, 0, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, arts)
+
+	// 发表过的恢复成仅自己可见，没发表过的恢复成草稿
+	assert.Equal(t, dao.ErrRecordNotFound, s.dao.Restore(ctx, pubId, 789))
+	require.NoError(t, s.dao.Restore(ctx, pubId, 123))
+	require.NoError(t, s.dao.Restore(ctx, draftId, 123))
+	assert.Equal(t, dao.ErrRecordNotFound, s.dao.Restore(ctx, draftId, 123))
+	art, err := s.dao.GetById(ctx, pubId)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ArticleStatusPrivate.ToUint8(), art.Status)
+	pub, err = s.dao.GetPubById(ctx, pubId)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ArticleStatusPrivate.ToUint8(), pub.Status)
+	art, err = s.dao.GetById(ctx, draftId)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ArticleStatusUnpublished.ToUint8(), art.Status)
+
+	// 只能彻底删除回收站里面的
+	assert.Equal(t, dao.ErrRecordNotFound, s.dao.Purge(ctx, pubId))
+	require.NoError(t, s.dao.Delete(ctx, pubId, 123))
+	require.NoError(t, s.dao.Purge(ctx, pubId))
+	_, err = s.dao.GetById(ctx, pubId)
+	assert.Equal(t, dao.ErrRecordNotFound, err)
+	_, err = s.dao.GetPubById(ctx, pubId)
+	assert.Equal(t, dao.ErrRecordNotFound, err)
+	revs, err := s.dao.ListRevisions(ctx, pubId, 123, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, revs)
+}
+
+func (s *AuthorDAOTestSuite) TestTags() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	publish := func(id int64, tags []string, category string) int64 {
+		id, err := s.dao.Sync(ctx, dao.Article{Id: id, Title: "标题", Content: "内容", AuthorId: 123,
+			Tags: tags, Category: category, Cover: "https://example.com/cover.png", Summary: "摘要",
+			Status: domain.ArticleStatusPublished.ToUint8()})
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond * 2)
+		return id
+	}
+	id1 := publish(0, []string{"Go", "后端"}, "技术")
+	id2 := publish(0, []string{"Go", "Gin"}, "技术")
+	id3 := publish(0, []string{"生活"}, "随笔")
+	// 草稿里面的标签不算
+	_, err := s.dao.Insert(ctx, dao.Article{Title: "草稿", AuthorId: 123, Tags: dao.Tags{"Go"},
+		Status: domain.ArticleStatusUnpublished.ToUint8()})
+	require.NoError(t, err)
+
+	pub, err := s.dao.GetPubById(ctx, id1)
+	require.NoError(t, err)
+	assert.Equal(t, dao.Tags{"Go", "后端"}, pub.Tags)
+	assert.Equal(t, "技术", pub.Category)
+	assert.Equal(t, "https://example.com/cover.png", pub.Cover)
+	assert.Equal(t, "摘要", pub.Summary)
+
+	// 最近修改的在前
+	arts, err := s.dao.ListPubByTag(ctx, "Go", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id2, id1}, s.ids(arts))
+	arts, err = s.dao.ListPubByTag(ctx, "Go", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id1}, s.ids(arts))
+	arts, err = s.dao.ListPubByCategory(ctx, "技术", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id2, id1}, s.ids(arts))
+	arts, err = s.dao.ListPubByCategory(ctx, "随笔", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id3}, s.ids(arts))
+
+	cnts, err := s.dao.CountPubTags(ctx, "", 2)
+	require.NoError(t, err)
+	require.Len(t, cnts, 2)
+	assert.Equal(t, dao.TagCount{Tag: "Go", Cnt: 2}, cnts[0])
+	cnts, err = s.dao.CountPubTags(ctx, "G", 10)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []dao.TagCount{{Tag: "Go", Cnt: 2}, {Tag: "Gin", Cnt: 1}}, cnts)
+	// 前缀里面的通配符按照普通字符处理
+	cnts, err = s.dao.CountPubTags(ctx, "%", 10)
+	require.NoError(t, err)
+	assert.Empty(t, cnts)
+
+	// 重新发表替换原来的标签
+	publish(id1, []string{"后端"}, "架构")
+	arts, err = s.dao.ListPubByTag(ctx, "Go", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id2}, s.ids(arts))
+	arts, err = s.dao.ListPubByCategory(ctx, "架构", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id1}, s.ids(arts))
+
+	// 撤回的不出现
+	require.NoError(t, s.dao.SyncStatus(ctx, id2, 123, domain.ArticleStatusPrivate.ToUint8()))
+	arts, err = s.dao.ListPubByTag(ctx, "Go", 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, arts)
+	cnts, err = s.dao.CountPubTags(ctx, "G", 10)
+	require.NoError(t, err)
+	assert.Empty(t, cnts)
+}
+
+func (s *AuthorDAOTestSuite) TestListPubByCursor() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	publish := func(author int64) int64 {
+		id, err := s.dao.Sync(ctx, dao.Article{Title: "标题", Content: "内容", AuthorId: author,
+			Status: domain.ArticleStatusPublished.ToUint8()})
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond * 2)
+		return id
+	}
+	id1 := publish(123)
+	id2 := publish(789)
+	id3 := publish(123)
+	id4 := publish(123)
+	s.insert(ctx, 123, "草稿")
+	// 撤回的不出现
+	require.NoError(t, s.dao.SyncStatus(ctx, id4, 123, domain.ArticleStatusPrivate.ToUint8()))
+
+	// 一页一页往后翻，直到取不满
+	walk := func(list func(cur dao.Cursor) ([]dao.Article, error)) []int64 {
+		var res []int64
+		var cur dao.Cursor
+		for {
+			arts, err := list(cur)
+			require.NoError(t, err)
+			res = append(res, s.ids(arts)...)
+			if len(arts) < 2 {
+				return res
+			}
+			last := arts[len(arts)-1]
+			cur = dao.Cursor{Utime: last.Utime, Id: last.Id}
+		}
+	}
+	assert.Equal(t, []int64{id3, id2, id1}, walk(func(cur dao.Cursor) ([]dao.Article, error) {
+		return s.dao.ListPubByCursor(ctx, cur, 2)
+	}))
+	assert.Equal(t, []int64{id3, id1}, walk(func(cur dao.Cursor) ([]dao.Article, error) {
+		return s.dao.ListPubByAuthor(ctx, 123, cur, 2)
+	}))
+
+	// 重新发表的排到最前面
+	_, err := s.dao.Sync(ctx, dao.Article{Id: id1, Title: "新标题", Content: "内容", AuthorId: 123,
+		Status: domain.ArticleStatusPublished.ToUint8()})
+	require.NoError(t, err)
+	arts, err := s.dao.ListPubByCursor(ctx, dao.Cursor{}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{id1, id3, id2}, s.ids(arts))
+}
+
+// insert 插入一篇草稿，间隔一下保证 utime 不一样
+func (s *AuthorDAOTestSuite) insert(ctx context.Context, author int64, title string) int64 {
+	id, err := s.dao.Insert(ctx, dao.Article{
+		Title:    title,
+		Content:  "内容",
+		Abstract: "摘要",
+		AuthorId: author,
+		Status:   domain.ArticleStatusUnpublished.ToUint8(),
+	})
+	require.NoError(s.T(), err)
+	time.Sleep(time.Millisecond * 2)
+	return id
+}
+
+func (s *AuthorDAOTestSuite) ids(arts []dao.Article) []int64 {
+	res := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		res = append(res, art.Id)
+	}
+	return res
+}
@@ -73,6 +73,8 @@ func (s *ArticleMongoHandlerTestSuite) TearDownTest() {
 	assert.NoError(s.T(), err)
 	_, err = s.mdb.Collection("published_articles").DeleteMany(ctx, bson.D{})
 	assert.NoError(s.T(), err)
+	_, err = s.mdb.Collection("article_revisions").DeleteMany(ctx, bson.D{})
+	assert.NoError(s.T(), err)
 }
 
 func (s *ArticleMongoHandlerTestSuite) TestEdit() {
@@ -511,3 +513,22 @@ func (s *ArticleMongoHandlerTestSuite) TestPublish() {
 func TestMongoArticle(t *testing.T) {
 	suite.Run(t, &ArticleMongoHandlerTestSuite{})
 }
+
+func TestMongoAuthorDAO(t *testing.T) {
+	mdb := startup.InitTestMongoDB()
+	err := dao.InitCollection(mdb)
+	require.NoError(t, err)
+	node, err := snowflake.NewNode(1)
+	require.NoError(t, err)
+	suite.Run(t, &AuthorDAOTestSuite{
+		dao: dao.NewMongoDBAuthorDAO(mdb, node),
+		reset: func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+			defer cancel()
+			for _, col := range []string{"articles", "published_articles", "article_revisions"} {
+				_, err := mdb.Collection(col).DeleteMany(ctx, bson.D{})
+				assert.NoError(t, err)
+			}
+		},
+	})
+}
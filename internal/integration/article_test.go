@@ -53,6 +53,7 @@ func (s *ArticleTestSuite) TearDownTest() {
 	// 清空所有数据，并且自增主键恢复到 1
 	s.db.Exec("TRUNCATE TABLE articles")
 	s.db.Exec("TRUNCATE TABLE published_articles")
+	s.db.Exec("TRUNCATE TABLE article_revisions")
 }
 
 func (s *ArticleTestSuite) TestEdit() {
@@ -496,3 +497,32 @@ type Article struct {
 	Title   string `json:"title"`
 	Content string `json:"content"`
 }
+
+func TestGORMAuthorDAO(t *testing.T) {
+	db := startup.InitTestDB()
+	suite.Run(t, &AuthorDAOTestSuite{
+		dao: dao.NewGormArticleDAO(db),
+		reset: func(t *testing.T) {
+			for _, table := range []string{"articles", "published_articles", "article_revisions", "publish_schedules"} {
+				err := db.Exec("TRUNCATE TABLE " + table).Error
+				assert.NoError(t, err)
+			}
+		},
+	})
+}
+
+// TestObjectStoreAuthorDAO 内容放在本地文件系统，行为要和直接放在数据库里面一致
+func TestObjectStoreAuthorDAO(t *testing.T) {
+	db := startup.InitTestDB()
+	store, err := dao.NewLocalContentStore(t.TempDir())
+	require.NoError(t, err)
+	suite.Run(t, &AuthorDAOTestSuite{
+		dao: dao.NewObjectStoreAuthorDAO(dao.NewGormArticleDAO(db), store),
+		reset: func(t *testing.T) {
+			for _, table := range []string{"articles", "published_articles", "article_revisions", "publish_schedules"} {
+				err := db.Exec("TRUNCATE TABLE " + table).Error
+				assert.NoError(t, err)
+			}
+		},
+	})
+}
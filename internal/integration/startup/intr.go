@@ -0,0 +1,16 @@
+package startup
+
+import (
+	intrv1 "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// InitIntrGRPCClient 集成测试直接连本地启动的 interactive 服务，不走注册中心
+func InitIntrGRPCClient() intrv1.InteractiveServiceClient {
+	cc, err := grpc.NewClient("localhost:8090", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(err)
+	}
+	return intrv1.NewInteractiveServiceClient(cc)
+}
@@ -5,6 +5,7 @@ package startup
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
+	eventsArt "github.com/mrhelloboy/wehook/internal/events/article"
 	"github.com/mrhelloboy/wehook/internal/repository"
 	"github.com/mrhelloboy/wehook/internal/repository/article"
 	"github.com/mrhelloboy/wehook/internal/repository/cache"
@@ -17,19 +18,65 @@ import (
 )
 
 var (
-	thirdProvider   = wire.NewSet(InitRedis, InitTestDB, InitLog)
-	userSvcProvider = wire.NewSet(
+	thirdProvider    = wire.NewSet(InitRedis, InitTestDB, InitLog, InitKafka, ioc.NewSyncProducer)
+	userRepoProvider = wire.NewSet(
 		dao.NewUserDAO,
 		cache.NewUserCache,
-		repository.NewUserRepository,
+		repository.NewUserRepository)
+	userSvcProvider = wire.NewSet(
+		userRepoProvider,
 		service.NewUserSvc)
 
-	articlSvcProvider = wire.NewSet(
-		daoArt.NewGormArticleDAO,
-		// daoArt.NewGormReaderDAO,
+	articleRepoProvider = wire.NewSet(
+		cache.NewRedisArticleCache,
+		ioc.InitArticleIndex,
 		article.NewCachedAuthorRepo,
 		// article.NewCachedReaderRepo,
+	)
+
+	articleSvcProvider = wire.NewSet(
+		eventsArt.NewKafkaProducer,
 		service.NewArticleSvc,
+		// 榜单
+		service.NewBatchRankingSrv,
+		service.NewRealtimeRankingSrv,
+		repository.NewCachedRankingRepo,
+		cache.NewRankingRedisCache,
+		cache.NewRankingLocalCache,
+		cache.NewRankingRealtimeRedisCache,
+		ioc.InitRankingStrategies,
+		InitIntrGRPCClient,
+	)
+
+	// articleExtHdlProvider 文章相关的其它 handler：历史记录、搜索、定时发表、回收站
+	articleExtHdlProvider = wire.NewSet(
+		dao.NewGORMHistoryRecordDAO,
+		repository.NewHistoryRecordRepo,
+		service.NewHistoryService,
+		web.NewHistoryHandler,
+		dao.NewGORMCommentDAO,
+		repository.NewCommentRepo,
+		service.NewCommentSvc,
+		web.NewCommentHandler,
+		dao.NewGORMFollowRelationDAO,
+		dao.NewGORMFeedDAO,
+		repository.NewFollowRepo,
+		repository.NewFeedRepo,
+		service.NewFollowSvc,
+		service.NewFeedSvc,
+		web.NewFollowHandler,
+		dao.NewGORMNotificationDAO,
+		repository.NewNotificationRepo,
+		service.NewNotificationSvc,
+		web.NewNotificationHandler,
+		service.NewArticleSearchSvc,
+		web.NewSearchHandler,
+		daoArt.NewGORMPublishScheduleDAO,
+		article.NewPublishScheduleRepo,
+		service.NewArticleScheduleSvc,
+		web.NewArticleScheduleHandler,
+		service.NewArticleTrashSvc,
+		web.NewArticleTrashHandler,
 	)
 )
 
@@ -37,12 +84,16 @@ func InitWebServer() *gin.Engine {
 	wire.Build(
 		thirdProvider,
 		userSvcProvider,
-		articlSvcProvider,
+		daoArt.NewGormArticleDAO,
+		articleRepoProvider,
+		articleSvcProvider,
+		articleExtHdlProvider,
 		cache.NewCodeCache,
 		repository.NewCachedCodeRepository,
 		// service 部分
 		// 集成测试我们显式指定使用内存实现
 		ioc.InitSMSService,
+		ioc.InitEmailService,
 
 		// 指定啥也不干的 wechat service
 		InitPhantomWechatService,
@@ -69,8 +120,9 @@ func InitWebServer() *gin.Engine {
 func InitArticleHandler(dao daoArt.AuthorDAO) *web.ArticleHandler {
 	wire.Build(
 		thirdProvider,
-		article.NewCachedAuthorRepo,
-		service.NewArticleSvc,
+		userRepoProvider,
+		articleRepoProvider,
+		articleSvcProvider,
 		web.NewArticleHandler,
 	)
 	return new(web.ArticleHandler)
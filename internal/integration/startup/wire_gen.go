@@ -9,6 +9,7 @@ package startup
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
+	article3 "github.com/mrhelloboy/wehook/internal/events/article"
 	"github.com/mrhelloboy/wehook/internal/repository"
 	article2 "github.com/mrhelloboy/wehook/internal/repository/article"
 	"github.com/mrhelloboy/wehook/internal/repository/cache"
@@ -36,23 +37,82 @@ func InitWebServer() *gin.Engine {
 	codeCache := cache.NewCodeCache(cmdable)
 	codeRepository := repository.NewCachedCodeRepository(codeCache)
 	smsService := ioc.InitSMSService()
-	codeService := service.NewCodeSvc(codeRepository, smsService)
+	emailService := ioc.InitEmailService()
+	codeService := service.NewCodeSvc(codeRepository, smsService, emailService)
 	userHandler := web.NewUserHandler(userService, codeService, cmdable, handler)
 	wechatService := InitPhantomWechatService(logger)
 	oAuth2WechatHandler := web.NewOAuth2WechatHandler(wechatService, userService, handler)
 	authorDAO := article.NewGormArticleDAO(gormDB)
-	authorRepository := article2.NewCachedAuthorRepo(authorDAO)
-	articleService := service.NewArticleSvc(authorRepository, logger)
-	articleHandler := web.NewArticleHandler(articleService, logger)
-	engine := ioc.InitGin(v, userHandler, oAuth2WechatHandler, articleHandler)
+	articleCache := cache.NewRedisArticleCache(cmdable)
+	articleIndex := ioc.InitArticleIndex(gormDB, authorDAO, logger)
+	authorRepository := article2.NewCachedAuthorRepo(authorDAO, userRepository, articleCache, articleIndex, logger)
+	client := InitKafka()
+	syncProducer := ioc.NewSyncProducer(client)
+	producer := article3.NewKafkaProducer(syncProducer)
+	articleService := service.NewArticleSvc(authorRepository, logger, producer)
+	interactiveServiceClient := InitIntrGRPCClient()
+	rankingRedisCache := cache.NewRankingRedisCache(cmdable)
+	rankingLocalCache := cache.NewRankingLocalCache()
+	rankingRealtimeCache := cache.NewRankingRealtimeRedisCache(cmdable)
+	rankingRepository := repository.NewCachedRankingRepo(rankingRedisCache, rankingLocalCache, rankingRealtimeCache)
+	rankingStrategies := ioc.InitRankingStrategies()
+	rankingService := service.NewBatchRankingSrv(articleService, interactiveServiceClient, rankingRepository, rankingStrategies)
+	realtimeRankingService := service.NewRealtimeRankingSrv(rankingRepository, authorRepository, logger)
+	articleHandler := web.NewArticleHandler(articleService, rankingService, realtimeRankingService, interactiveServiceClient, logger)
+	historyRecordDAO := dao.NewGORMHistoryRecordDAO(gormDB)
+	historyRecordRepository := repository.NewHistoryRecordRepo(historyRecordDAO)
+	historyService := service.NewHistoryService(historyRecordRepository)
+	historyHandler := web.NewHistoryHandler(historyService, logger)
+	searchService := service.NewArticleSearchSvc(authorRepository, interactiveServiceClient, logger)
+	searchHandler := web.NewSearchHandler(searchService, logger)
+	publishScheduleDAO := article.NewGORMPublishScheduleDAO(gormDB)
+	publishScheduleRepository := article2.NewPublishScheduleRepo(publishScheduleDAO)
+	articleScheduleService := service.NewArticleScheduleSvc(articleService, publishScheduleRepository, logger)
+	articleScheduleHandler := web.NewArticleScheduleHandler(articleScheduleService, logger)
+	articleTrashService := service.NewArticleTrashSvc(authorRepository, interactiveServiceClient, logger)
+	articleTrashHandler := web.NewArticleTrashHandler(articleTrashService, logger)
+	commentDAO := dao.NewGORMCommentDAO(gormDB)
+	commentRepository := repository.NewCommentRepo(commentDAO)
+	commentService := service.NewCommentSvc(commentRepository, authorRepository, interactiveServiceClient, producer, logger)
+	commentHandler := web.NewCommentHandler(commentService, logger)
+	followRelationDAO := dao.NewGORMFollowRelationDAO(gormDB)
+	followRepository := repository.NewFollowRepo(followRelationDAO)
+	feedDAO := dao.NewGORMFeedDAO(gormDB)
+	feedRepository := repository.NewFeedRepo(feedDAO)
+	followService := service.NewFollowSvc(followRepository, userRepository, feedRepository, logger)
+	feedService := service.NewFeedSvc(feedRepository, followRepository, authorRepository)
+	followHandler := web.NewFollowHandler(followService, feedService, logger)
+	notificationDAO := dao.NewGORMNotificationDAO(gormDB)
+	notificationRepository := repository.NewNotificationRepo(notificationDAO)
+	notificationService := service.NewNotificationSvc(notificationRepository, authorRepository, userRepository, logger)
+	notificationHandler := web.NewNotificationHandler(notificationService, logger)
+	engine := ioc.InitGin(v, userHandler, oAuth2WechatHandler, articleHandler, historyHandler, searchHandler, articleScheduleHandler, articleTrashHandler, commentHandler, followHandler, notificationHandler)
 	return engine
 }
 
 func InitArticleHandler(dao2 article.AuthorDAO) *web.ArticleHandler {
-	authorRepository := article2.NewCachedAuthorRepo(dao2)
+	gormDB := InitTestDB()
+	userDAO := dao.NewUserDAO(gormDB)
+	cmdable := InitRedis()
+	userCache := cache.NewUserCache(cmdable)
+	userRepository := repository.NewUserRepository(userDAO, userCache)
+	articleCache := cache.NewRedisArticleCache(cmdable)
 	logger := InitLog()
-	articleService := service.NewArticleSvc(authorRepository, logger)
-	articleHandler := web.NewArticleHandler(articleService, logger)
+	articleIndex := ioc.InitArticleIndex(gormDB, dao2, logger)
+	authorRepository := article2.NewCachedAuthorRepo(dao2, userRepository, articleCache, articleIndex, logger)
+	client := InitKafka()
+	syncProducer := ioc.NewSyncProducer(client)
+	producer := article3.NewKafkaProducer(syncProducer)
+	articleService := service.NewArticleSvc(authorRepository, logger, producer)
+	interactiveServiceClient := InitIntrGRPCClient()
+	rankingRedisCache := cache.NewRankingRedisCache(cmdable)
+	rankingLocalCache := cache.NewRankingLocalCache()
+	rankingRealtimeCache := cache.NewRankingRealtimeRedisCache(cmdable)
+	rankingRepository := repository.NewCachedRankingRepo(rankingRedisCache, rankingLocalCache, rankingRealtimeCache)
+	rankingStrategies := ioc.InitRankingStrategies()
+	rankingService := service.NewBatchRankingSrv(articleService, interactiveServiceClient, rankingRepository, rankingStrategies)
+	realtimeRankingService := service.NewRealtimeRankingSrv(rankingRepository, authorRepository, logger)
+	articleHandler := web.NewArticleHandler(articleService, rankingService, realtimeRankingService, interactiveServiceClient, logger)
 	return articleHandler
 }
 
@@ -73,24 +133,19 @@ func InitJwtHdl() jwt.Handler {
 	return handler
 }
 
-func InitInteractiveService() service.InteractiveService {
-	gormDB := InitTestDB()
-	interactiveDAO := dao.NewGormInteractiveDAO(gormDB)
-	cmdable := InitRedis()
-	interactiveCache := cache.NewRedisInteractiveCache(cmdable)
-	logger := InitLog()
-	interactiveRepository := repository.NewCachedInteractiveRepo(interactiveDAO, interactiveCache, logger)
-	interactiveService := service.NewInteractiveService(interactiveRepository, logger)
-	return interactiveService
-}
-
 // wire.go:
 
 var (
-	thirdProvider   = wire.NewSet(InitRedis, InitTestDB, InitLog)
-	userSvcProvider = wire.NewSet(dao.NewUserDAO, cache.NewUserCache, repository.NewUserRepository, service.NewUserSvc)
+	thirdProvider    = wire.NewSet(InitRedis, InitTestDB, InitLog, InitKafka, ioc.NewSyncProducer)
+	userRepoProvider = wire.NewSet(dao.NewUserDAO, cache.NewUserCache, repository.NewUserRepository)
+	userSvcProvider  = wire.NewSet(
+		userRepoProvider, service.NewUserSvc,
+	)
+
+	articleRepoProvider = wire.NewSet(cache.NewRedisArticleCache, ioc.InitArticleIndex, article2.NewCachedAuthorRepo)
 
-	articlSvcProvider = wire.NewSet(article.NewGormArticleDAO, article2.NewCachedAuthorRepo, service.NewArticleSvc)
+	articleSvcProvider = wire.NewSet(article3.NewKafkaProducer, service.NewArticleSvc, service.NewBatchRankingSrv, service.NewRealtimeRankingSrv, repository.NewCachedRankingRepo, cache.NewRankingRedisCache, cache.NewRankingLocalCache, cache.NewRankingRealtimeRedisCache, ioc.InitRankingStrategies, InitIntrGRPCClient)
 
-	interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, repository.NewCachedInteractiveRepo, dao.NewGormInteractiveDAO, cache.NewRedisInteractiveCache)
+	// articleExtHdlProvider 文章相关的其它 handler：历史记录、搜索、定时发表、回收站
+	articleExtHdlProvider = wire.NewSet(dao.NewGORMHistoryRecordDAO, repository.NewHistoryRecordRepo, service.NewHistoryService, web.NewHistoryHandler, dao.NewGORMCommentDAO, repository.NewCommentRepo, service.NewCommentSvc, web.NewCommentHandler, dao.NewGORMFollowRelationDAO, dao.NewGORMFeedDAO, repository.NewFollowRepo, repository.NewFeedRepo, service.NewFollowSvc, service.NewFeedSvc, web.NewFollowHandler, dao.NewGORMNotificationDAO, repository.NewNotificationRepo, service.NewNotificationSvc, web.NewNotificationHandler, service.NewArticleSearchSvc, web.NewSearchHandler, article.NewGORMPublishScheduleDAO, article2.NewPublishScheduleRepo, service.NewArticleScheduleSvc, web.NewArticleScheduleHandler, service.NewArticleTrashSvc, web.NewArticleTrashHandler)
 )
@@ -2,6 +2,7 @@ package job
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -51,14 +52,17 @@ type Scheduler struct {
 	svc     service.JobService
 	l       logger.Logger
 	limiter *semaphore.Weighted
+	// idleInterval 没抢到任务的时候，隔多久再抢
+	idleInterval time.Duration
 }
 
 func NewScheduler(svc service.JobService, l logger.Logger) *Scheduler {
 	return &Scheduler{
-		svc:     svc,
-		l:       l,
-		execs:   make(map[string]Executor),
-		limiter: semaphore.NewWeighted(200),
+		svc:          svc,
+		l:            l,
+		execs:        make(map[string]Executor),
+		limiter:      semaphore.NewWeighted(200),
+		idleInterval: time.Second,
 	}
 }
 
@@ -81,12 +85,24 @@ func (s *Scheduler) Schedule(ctx context.Context) error {
 		j, err := s.svc.Preempt(dbCtx)
 		cancel()
 		if err != nil {
-			// 继续下一轮抢占
-			s.l.Error("抢占任务失败", logger.Error(err))
+			// 没有可以执行的任务，或者数据库出问题了，歇一会再进行下一轮抢占
+			s.limiter.Release(1)
+			if !errors.Is(err, service.ErrNoJob) {
+				s.l.Error("抢占任务失败", logger.Error(err))
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(s.idleInterval):
+			}
+			continue
 		}
 		exec, ok := s.execs[j.Executor]
 		if !ok {
 			s.l.Error("未找到对应的执行器", logger.String("executor", j.Executor))
+			s.limiter.Release(1)
+			if err1 := j.CancelFunc(); err1 != nil {
+				s.l.Error("释放任务失败", logger.Error(err1), logger.Int64("job_id", j.Id))
+			}
 			continue
 		}
 		// 执行
@@ -13,6 +13,7 @@ import (
 
 type RankingJob struct {
 	svc       service.RankingService
+	ranking   string // 榜单名
 	timeout   time.Duration
 	client    *rlock.Client
 	key       string
@@ -22,7 +23,7 @@ type RankingJob struct {
 }
 
 func (r *RankingJob) Name() string {
-	return "ranking"
+	return "ranking:" + r.ranking
 }
 
 func (r *RankingJob) Run() error {
@@ -59,7 +60,7 @@ func (r *RankingJob) Run() error {
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
 	defer cancel()
-	return r.svc.TopN(ctx)
+	return r.svc.TopN(ctx, r.ranking)
 }
 
 func (r *RankingJob) Close() error {
@@ -72,13 +73,15 @@ func (r *RankingJob) Close() error {
 	return lock.Unlock(ctx)
 }
 
-func NewRankingJob(svc service.RankingService, timeout time.Duration, client *rlock.Client, l logger.Logger) *RankingJob {
+func NewRankingJob(svc service.RankingService, ranking string, timeout time.Duration, client *rlock.Client, l logger.Logger) *RankingJob {
 	// 根据数据量来设置，如果要是七天内的帖子数量很多，就需要设置长一些
+	// 每个榜单一把锁，不同榜单可以在不同的节点上计算
 	return &RankingJob{
 		svc:       svc,
+		ranking:   ranking,
 		timeout:   timeout,
 		client:    client,
-		key:       "rlock:cron_job:ranking",
+		key:       "rlock:cron_job:ranking:" + ranking,
 		l:         l,
 		localLock: &sync.Mutex{},
 	}
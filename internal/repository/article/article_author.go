@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"github.com/mrhelloboy/wehook/internal/repository/cache"
+	"github.com/mrhelloboy/wehook/internal/repository/search"
 	"github.com/mrhelloboy/wehook/pkg/logger"
+	"github.com/mrhelloboy/wehook/pkg/markdownx"
 
 	"github.com/mrhelloboy/wehook/internal/repository"
 
@@ -21,30 +23,156 @@ type AuthorRepository interface {
 	Update(ctx context.Context, art domain.Article) error
 	Sync(ctx context.Context, art domain.Article) (int64, error)
 	SyncStatus(ctx context.Context, id int64, author int64, status domain.ArticleStatus) error
-	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
-	ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error)
+	// List 作者自己的文章，按照 (utime, id) 倒序，从 cursor 的下一条开始
+	List(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	// ListPub 线上库里公开发表的文章，按照 (utime, id) 倒序，从 cursor 的下一条开始
+	ListPub(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
 	GetById(ctx context.Context, id int64) (domain.Article, error)
 	GetPublishedById(ctx context.Context, id int64) (domain.Article, error)
+	// Search 在制作库的索引里搜索
+	Search(ctx context.Context, q domain.ArticleSearchQuery) ([]domain.Article, error)
+	// SearchPub 在线上库的索引里搜索
+	SearchPub(ctx context.Context, q domain.ArticleSearchQuery) ([]domain.Article, error)
+	ListRevisions(ctx context.Context, artId int64, author int64, offset int, limit int) ([]domain.ArticleRevision, error)
+	GetRevision(ctx context.Context, id int64) (domain.ArticleRevision, error)
+
+	// 回收站
+	Delete(ctx context.Context, id int64, author int64) error
+	Restore(ctx context.Context, id int64, author int64) error
+	ListTrash(ctx context.Context, author int64, offset int, limit int) ([]domain.Article, error)
+	ListExpiredTrash(ctx context.Context, before time.Time, offset int, limit int) ([]domain.Article, error)
+	Purge(ctx context.Context, id int64) error
+
+	// 标签和分类，只包括公开发表的文章
+	ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]domain.Article, error)
+	ListPubByCategory(ctx context.Context, category string, offset int, limit int) ([]domain.Article, error)
+	CountPubTags(ctx context.Context, prefix string, limit int) ([]domain.TagCount, error)
+
+	// ListPubByAuthor 某个作者公开发表的文章，按照 (utime, id) 倒序，带作者昵称
+	ListPubByAuthor(ctx context.Context, author int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	// Timeline 全站公开发表的文章，按照 (utime, id) 倒序，带作者昵称
+	Timeline(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	// ListPubByAuthors since 里面这些作者在对应的时间之后发表（或者重新发表）的文章，
+	// 按照 (utime, id) 倒序，带作者昵称，不走缓存
+	ListPubByAuthors(ctx context.Context, since map[int64]time.Time, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	// ListPubByIds 公开发表的文章，带作者昵称，不保证顺序，不是公开发表的不在结果里面
+	ListPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error)
 }
 
+// ErrArticleNotFound 文章不存在，或者不在预期的状态，比如恢复不在回收站里面的文章
+var ErrArticleNotFound = daoArt.ErrRecordNotFound
+
+var ErrRevisionNotFound = daoArt.ErrRecordNotFound
+
 type cachedAuthorRepo struct {
 	dao      daoArt.AuthorDAO
 	userRepo repository.UserRepository
 	cache    cache.ArticleCache
+	index    search.ArticleIndex
 	l        logger.Logger
 }
 
-func NewCachedAuthorRepo(dao daoArt.AuthorDAO, userRepo repository.UserRepository, c cache.ArticleCache, l logger.Logger) AuthorRepository {
+func NewCachedAuthorRepo(dao daoArt.AuthorDAO, userRepo repository.UserRepository, c cache.ArticleCache,
+	index search.ArticleIndex, l logger.Logger) AuthorRepository {
 	return &cachedAuthorRepo{
 		dao:      dao,
 		userRepo: userRepo,
 		cache:    c,
+		index:    index,
 		l:        l,
 	}
 }
 
-func (c *cachedAuthorRepo) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error) {
-	res, err := c.dao.ListPub(ctx, start, offset, limit)
+func (c *cachedAuthorRepo) Search(ctx context.Context, q domain.ArticleSearchQuery) ([]domain.Article, error) {
+	return c.index.Search(ctx, search.IndexAuthor, q)
+}
+
+func (c *cachedAuthorRepo) SearchPub(ctx context.Context, q domain.ArticleSearchQuery) ([]domain.Article, error) {
+	return c.index.Search(ctx, search.IndexPublished, q)
+}
+
+func (c *cachedAuthorRepo) ListPub(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	res, err := c.dao.ListPub(ctx, c.toCursor(cursor), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src daoArt.Article) domain.Article {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *cachedAuthorRepo) ListRevisions(ctx context.Context, artId int64, author int64, offset int, limit int) ([]domain.ArticleRevision, error) {
+	res, err := c.dao.ListRevisions(ctx, artId, author, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src daoArt.ArticleRevision) domain.ArticleRevision {
+		return c.revisionToDomain(src)
+	}), nil
+}
+
+func (c *cachedAuthorRepo) GetRevision(ctx context.Context, id int64) (domain.ArticleRevision, error) {
+	rev, err := c.dao.GetRevision(ctx, id)
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	return c.revisionToDomain(rev), nil
+}
+
+func (c *cachedAuthorRepo) Delete(ctx context.Context, id int64, author int64) error {
+	err := c.dao.Delete(ctx, id, author)
+	if err != nil {
+		return err
+	}
+	c.delCache(ctx, id, author)
+	c.refreshIndex(ctx, id, true)
+	return nil
+}
+
+func (c *cachedAuthorRepo) Restore(ctx context.Context, id int64, author int64) error {
+	err := c.dao.Restore(ctx, id, author)
+	if err != nil {
+		return err
+	}
+	c.delCache(ctx, id, author)
+	c.refreshIndex(ctx, id, true)
+	return nil
+}
+
+func (c *cachedAuthorRepo) ListTrash(ctx context.Context, author int64, offset int, limit int) ([]domain.Article, error) {
+	res, err := c.dao.GetDeletedByAuthor(ctx, author, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src daoArt.Article) domain.Article {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *cachedAuthorRepo) ListExpiredTrash(ctx context.Context, before time.Time, offset int, limit int) ([]domain.Article, error) {
+	res, err := c.dao.ListDeletedBefore(ctx, before.UnixMilli(), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src daoArt.Article) domain.Article {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *cachedAuthorRepo) Purge(ctx context.Context, id int64) error {
+	err := c.dao.Purge(ctx, id)
+	if err != nil {
+		return err
+	}
+	// 删除的时候已经清理过缓存和索引，这里兜底
+	c.delIndex(ctx, id)
+	_ = c.cache.Del(ctx, id)
+	_ = c.cache.DelPub(ctx, id)
+	return nil
+}
+
+func (c *cachedAuthorRepo) ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]domain.Article, error) {
+	res, err := c.dao.ListPubByTag(ctx, tag, offset, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -53,18 +181,168 @@ func (c *cachedAuthorRepo) ListPub(ctx context.Context, start time.Time, offset
 	}), nil
 }
 
+func (c *cachedAuthorRepo) ListPubByCategory(ctx context.Context, category string, offset int, limit int) ([]domain.Article, error) {
+	res, err := c.dao.ListPubByCategory(ctx, category, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src daoArt.Article) domain.Article {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *cachedAuthorRepo) CountPubTags(ctx context.Context, prefix string, limit int) ([]domain.TagCount, error) {
+	res, err := c.dao.CountPubTags(ctx, prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src daoArt.TagCount) domain.TagCount {
+		return domain.TagCount{Tag: src.Tag, Cnt: src.Cnt}
+	}), nil
+}
+
+// firstPageSize 作者和读者列表的第一页固定按照这个大小查询和缓存，
+// 请求的 limit 不超过这个大小就从里面截取。
+// 如果按照请求的 limit 缓存，limit 大的请求会拿到不满一页的数据，误以为没有下一页了
+const firstPageSize = 100
+
+func (c *cachedAuthorRepo) ListPubByAuthor(ctx context.Context, author int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	return c.listPub(ctx, author, cursor, limit, func(cur daoArt.Cursor, limit int) ([]daoArt.Article, error) {
+		return c.dao.ListPubByAuthor(ctx, author, cur, limit)
+	})
+}
+
+func (c *cachedAuthorRepo) Timeline(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	return c.listPub(ctx, 0, cursor, limit, func(cur daoArt.Cursor, limit int) ([]daoArt.Article, error) {
+		return c.dao.ListPubByCursor(ctx, cur, limit)
+	})
+}
+
+func (c *cachedAuthorRepo) ListPubByAuthors(ctx context.Context, since map[int64]time.Time, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	if len(since) == 0 {
+		return nil, nil
+	}
+	utimes := make(map[int64]int64, len(since))
+	for author, t := range since {
+		utimes[author] = t.UnixMilli()
+	}
+	res, err := c.dao.ListPubByAuthors(ctx, utimes, c.toCursor(cursor), limit)
+	if err != nil {
+		return nil, err
+	}
+	return c.toPubDomains(ctx, res), nil
+}
+
+func (c *cachedAuthorRepo) ListPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	res, err := c.dao.ListPubByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return c.toPubDomains(ctx, res), nil
+}
+
+// toPubDomains 转成给读者看的文章，带上作者昵称
+func (c *cachedAuthorRepo) toPubDomains(ctx context.Context, arts []daoArt.Article) []domain.Article {
+	data := slice.Map[daoArt.Article, domain.Article](arts, func(idx int, src daoArt.Article) domain.Article {
+		return c.toDomain(src)
+	})
+	c.fillAuthors(ctx, data)
+	return data
+}
+
+// listPub 第一页走缓存，author 为 0 的时候是全站的时间线
+func (c *cachedAuthorRepo) listPub(ctx context.Context, author int64, cursor domain.ArticleCursor, limit int,
+	list func(cur daoArt.Cursor, limit int) ([]daoArt.Article, error)) ([]domain.Article, error) {
+	firstPage := cursor.IsZero() && limit <= firstPageSize
+	size := limit
+	if firstPage {
+		data, err := c.cache.GetPubFirstPage(ctx, author)
+		if err == nil {
+			return c.head(data, limit), nil
+		}
+		size = firstPageSize
+	}
+	res, err := list(c.toCursor(cursor), size)
+	if err != nil {
+		return nil, err
+	}
+	data := c.toPubDomains(ctx, res)
+	if firstPage {
+		if err = c.cache.SetPubFirstPage(ctx, author, data); err != nil {
+			c.l.Warn("回写读者第一页缓存失败", logger.Int64("author", author), logger.Error(err))
+		}
+	}
+	return c.head(data, limit), nil
+}
+
+func (c *cachedAuthorRepo) toCursor(cursor domain.ArticleCursor) daoArt.Cursor {
+	if cursor.IsZero() {
+		return daoArt.Cursor{}
+	}
+	return daoArt.Cursor{Utime: cursor.Utime.UnixMilli(), Id: cursor.Id}
+}
+
+func (c *cachedAuthorRepo) head(arts []domain.Article, limit int) []domain.Article {
+	if len(arts) > limit {
+		return arts[:limit]
+	}
+	return arts
+}
+
+// fillAuthors 补上作者昵称，同一个作者只查一次。查不到只记录日志，昵称留空
+func (c *cachedAuthorRepo) fillAuthors(ctx context.Context, arts []domain.Article) {
+	names := make(map[int64]string, len(arts))
+	for i := range arts {
+		id := arts[i].Author.Id
+		name, ok := names[id]
+		if !ok {
+			u, err := c.userRepo.FindById(ctx, id)
+			if err != nil {
+				c.l.Warn("查询作者失败", logger.Int64("author", id), logger.Error(err))
+			}
+			name = u.Nickname
+			names[id] = name
+		}
+		arts[i].Author.Name = name
+	}
+}
+
+// delPubFirstPage 线上库变了，作者的列表和全站的时间线都要删掉
+func (c *cachedAuthorRepo) delPubFirstPage(ctx context.Context, author int64) {
+	for _, key := range []int64{author, 0} {
+		if err := c.cache.DelPubFirstPage(ctx, key); err != nil {
+			c.l.Warn("删除读者第一页缓存失败", logger.Int64("author", key), logger.Error(err))
+		}
+	}
+}
+
+// delCache 状态变了，作者和读者两边的缓存都要删掉
+func (c *cachedAuthorRepo) delCache(ctx context.Context, id int64, author int64) {
+	if err := c.cache.DelFirstPage(ctx, author); err != nil {
+		c.l.Warn("删除第一页缓存失败", logger.Int64("author", author), logger.Error(err))
+	}
+	c.delPubFirstPage(ctx, author)
+	if err := c.cache.Del(ctx, id); err != nil {
+		c.l.Warn("删除文章缓存失败", logger.Int64("id", id), logger.Error(err))
+	}
+	if err := c.cache.DelPub(ctx, id); err != nil {
+		c.l.Warn("删除线上文章缓存失败", logger.Int64("id", id), logger.Error(err))
+	}
+}
+
 func (c *cachedAuthorRepo) Update(ctx context.Context, art domain.Article) error {
 	defer func() {
 		// 清空缓存
 		_ = c.cache.DelFirstPage(ctx, art.Author.Id)
 	}()
-	return c.dao.UpdateById(ctx, daoArt.Article{
-		Id:       art.Id,
-		Title:    art.Title,
-		Content:  art.Content,
-		AuthorId: art.Author.Id,
-		Status:   art.Status.ToUint8(),
-	})
+	err := c.dao.UpdateById(ctx, c.toEntity(art))
+	if err == nil {
+		c.refreshIndex(ctx, art.Id, false)
+	}
+	return err
 }
 
 func (c *cachedAuthorRepo) Create(ctx context.Context, art domain.Article) (int64, error) {
@@ -72,12 +350,12 @@ func (c *cachedAuthorRepo) Create(ctx context.Context, art domain.Article) (int6
 		// 清空缓存
 		_ = c.cache.DelFirstPage(ctx, art.Author.Id)
 	}()
-	return c.dao.Insert(ctx, daoArt.Article{
-		Title:    art.Title,
-		Content:  art.Content,
-		AuthorId: art.Author.Id,
-		Status:   art.Status.ToUint8(),
-	})
+	art.Id = 0
+	id, err := c.dao.Insert(ctx, c.toEntity(art))
+	if err == nil {
+		c.refreshIndex(ctx, id, false)
+	}
+	return id, err
 }
 
 func (c *cachedAuthorRepo) Sync(ctx context.Context, art domain.Article) (int64, error) {
@@ -86,20 +364,56 @@ func (c *cachedAuthorRepo) Sync(ctx context.Context, art domain.Article) (int64,
 	if err == nil {
 		// 删除旧缓存（第一页数据已经增多，所以删除缓存）
 		_ = c.cache.DelFirstPage(ctx, art.Author.Id)
+		c.delPubFirstPage(ctx, art.Author.Id)
 		err := c.cache.Set(ctx, art)
 		if err != nil {
 			c.l.Warn("同步文章时，缓存失败", logger.Error(err))
 		}
+		c.refreshIndex(ctx, id, true)
 	}
 	return id, err
 }
 
 func (c *cachedAuthorRepo) SyncStatus(ctx context.Context, id int64, author int64, status domain.ArticleStatus) error {
-	return c.dao.SyncStatus(ctx, id, author, status.ToUint8())
+	err := c.dao.SyncStatus(ctx, id, author, status.ToUint8())
+	if err == nil {
+		c.delPubFirstPage(ctx, author)
+		c.refreshIndex(ctx, id, true)
+	}
+	return err
+}
+
+// refreshIndex 以数据库为准刷新索引，pub 为 true 的时候连线上库的索引一起刷新。
+// 索引失败只记录日志，不影响写操作本身
+func (c *cachedAuthorRepo) refreshIndex(ctx context.Context, id int64, pub bool) {
+	art, err := c.dao.GetById(ctx, id)
+	if err == nil && art.Status == daoArt.StatusDeleted {
+		// 回收站里面的搜不到
+		c.delIndex(ctx, id)
+		return
+	}
+	if err == nil {
+		err = c.index.Upsert(ctx, search.IndexAuthor, c.toDomain(art))
+	}
+	if err != nil {
+		c.l.Warn("更新制作库索引失败", logger.Int64("id", id), logger.Error(err))
+	}
+	if !pub {
+		return
+	}
+	pubArt, err := c.dao.GetPubById(ctx, id)
+	if err == nil {
+		err = c.index.Upsert(ctx, search.IndexPublished, c.toDomain(pubArt.Article))
+	}
+	if err != nil {
+		c.l.Warn("更新线上库索引失败", logger.Int64("id", id), logger.Error(err))
+	}
 }
 
-func (c *cachedAuthorRepo) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
-	if offset == 0 && limit <= 100 {
+func (c *cachedAuthorRepo) List(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	firstPage := cursor.IsZero() && limit <= firstPageSize
+	size := limit
+	if firstPage {
 		data, err := c.cache.GetFirstPage(ctx, uid)
 		// 命中缓存
 		if err == nil {
@@ -107,10 +421,11 @@ func (c *cachedAuthorRepo) List(ctx context.Context, uid int64, offset int, limi
 			go func() {
 				c.preCache(ctx, data)
 			}()
-			return data, nil
+			return c.head(data, limit), nil
 		}
+		size = firstPageSize
 	}
-	res, err := c.dao.GetByAuthor(ctx, uid, offset, limit)
+	res, err := c.dao.GetByAuthor(ctx, uid, c.toCursor(cursor), size)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +434,7 @@ func (c *cachedAuthorRepo) List(ctx context.Context, uid int64, offset int, limi
 	})
 
 	// 缓存第一页数据
-	if offset == 0 && limit <= 100 {
+	if firstPage {
 		go func() {
 			err := c.cache.SetFirstPage(ctx, uid, data)
 			if err != nil {
@@ -129,7 +444,7 @@ func (c *cachedAuthorRepo) List(ctx context.Context, uid int64, offset int, limi
 		}()
 	}
 
-	return data, nil
+	return c.head(data, limit), nil
 }
 
 func (c *cachedAuthorRepo) GetById(ctx context.Context, id int64) (domain.Article, error) {
@@ -147,36 +462,52 @@ func (c *cachedAuthorRepo) GetPublishedById(ctx context.Context, id int64) (doma
 	}
 	// 获取作者信息
 	author, err := c.userRepo.FindById(ctx, art.AuthorId)
-	res := domain.Article{
-		Id:      art.Id,
-		Title:   art.Title,
-		Content: art.Content,
-		Author: domain.Author{
-			Id:   author.Id,
-			Name: author.Nickname,
-		},
-		Status: domain.ArticleStatus(art.Status),
-		Ctime:  time.UnixMilli(art.Ctime),
-		Utime:  time.UnixMilli(art.Utime),
+	res := c.toDomain(art.Article)
+	res.Author = domain.Author{
+		Id:   author.Id,
+		Name: author.Nickname,
 	}
 	return res, nil
 }
 
+func (c *cachedAuthorRepo) delIndex(ctx context.Context, id int64) {
+	for _, index := range []string{search.IndexAuthor, search.IndexPublished} {
+		if err := c.index.Delete(ctx, index, id); err != nil {
+			c.l.Warn("删除索引失败", logger.String("index", index), logger.Int64("id", id), logger.Error(err))
+		}
+	}
+}
+
 func (c *cachedAuthorRepo) toEntity(article domain.Article) daoArt.Article {
 	return daoArt.Article{
 		Id:       article.Id,
 		Title:    article.Title,
 		Content:  article.Content,
 		AuthorId: article.Author.Id,
+		Tags:     article.Tags,
+		Category: article.Category,
+		Cover:    article.Cover,
+		Summary:  article.Summary,
+		Abstract: abstractOf(article.Summary, article.Content),
 		Status:   article.Status.ToUint8(),
 	}
 }
 
 func (c *cachedAuthorRepo) toDomain(art daoArt.Article) domain.Article {
+	abstract := art.Abstract
+	if abstract == "" {
+		// 加摘要字段之前保存的老数据，现算一次
+		abstract = abstractOf(art.Summary, art.Content)
+	}
 	return domain.Article{
-		Id:      art.Id,
-		Title:   art.Title,
-		Content: art.Content,
+		Id:       art.Id,
+		Title:    art.Title,
+		Content:  art.Content,
+		Tags:     art.Tags,
+		Category: art.Category,
+		Cover:    art.Cover,
+		Summary:  art.Summary,
+		Abstract: abstract,
 		Author: domain.Author{
 			Id: art.AuthorId,
 		},
@@ -186,14 +517,44 @@ func (c *cachedAuthorRepo) toDomain(art daoArt.Article) domain.Article {
 	}
 }
 
+func (c *cachedAuthorRepo) revisionToDomain(rev daoArt.ArticleRevision) domain.ArticleRevision {
+	return domain.ArticleRevision{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		AuthorId:  rev.AuthorId,
+		Title:     rev.Title,
+		Content:   rev.Content,
+		Status:    domain.ArticleStatus(rev.Status),
+		Ctime:     time.UnixMilli(rev.Ctime),
+	}
+}
+
 func (c *cachedAuthorRepo) preCache(ctx context.Context, data []domain.Article) {
 	// 预缓存，且只缓存第一条数据
 	// 这里只预加载长度小于1M的数据
 	// 因为缓存数据是存放在内存中的，如果数据过大会占用过多内存
-	if len(data) > 0 && len(data[0].Content) < 1024*1024 {
+	// 内容放在对象存储里面的时候，列表里面没有内容，不预缓存
+	if len(data) > 0 && data[0].Content != "" && len(data[0].Content) < 1024*1024 {
 		err := c.cache.Set(ctx, data[0])
 		if err != nil {
 			c.l.Error("提前预加载缓存失败", logger.Error(err))
 		}
 	}
 }
+
+// abstractLen 摘要最多多少个字
+const abstractLen = 100
+
+// abstractOf 作者填了摘要就用作者的，
+// 否则去掉内容里面的 Markdown 和 HTML 标记，取前 100 个字
+func abstractOf(summary string, content string) string {
+	if summary != "" {
+		return summary
+	}
+	// 考虑中文问题
+	cs := []rune(markdownx.PlainText(content))
+	if len(cs) < abstractLen {
+		return string(cs)
+	}
+	return string(cs[:abstractLen])
+}
@@ -0,0 +1,39 @@
+package article
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAbstractOf(t *testing.T) {
+	testCases := []struct {
+		name    string
+		summary string
+		content string
+		want    string
+	}{
+		{
+			name:    "作者填了摘要",
+			summary: "作者的摘要",
+			content: "内容",
+			want:    "作者的摘要",
+		},
+		{
+			name:    "去掉 Markdown 标记",
+			content: "# 标题\n\n**加粗**的内容",
+			want:    "标题 加粗的内容",
+		},
+		{
+			name:    "按字截断",
+			content: strings.Repeat("中", 120),
+			want:    strings.Repeat("中", 100),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, abstractOf(tc.summary, tc.content))
+		})
+	}
+}
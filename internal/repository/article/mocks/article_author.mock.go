@@ -12,6 +12,7 @@ package repomocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/mrhelloboy/wehook/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +41,21 @@ func (m *MockAuthorRepository) EXPECT() *MockAuthorRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountPubTags mocks base method.
+func (m *MockAuthorRepository) CountPubTags(ctx context.Context, prefix string, limit int) ([]domain.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPubTags", ctx, prefix, limit)
+	ret0, _ := ret[0].([]domain.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPubTags indicates an expected call of CountPubTags.
+func (mr *MockAuthorRepositoryMockRecorder) CountPubTags(ctx, prefix, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPubTags", reflect.TypeOf((*MockAuthorRepository)(nil).CountPubTags), ctx, prefix, limit)
+}
+
 // Create mocks base method.
 func (m *MockAuthorRepository) Create(ctx context.Context, art domain.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +71,20 @@ func (mr *MockAuthorRepositoryMockRecorder) Create(ctx, art any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuthorRepository)(nil).Create), ctx, art)
 }
 
+// Delete mocks base method.
+func (m *MockAuthorRepository) Delete(ctx context.Context, id, author int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, author)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAuthorRepositoryMockRecorder) Delete(ctx, id, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAuthorRepository)(nil).Delete), ctx, id, author)
+}
+
 // GetById mocks base method.
 func (m *MockAuthorRepository) GetById(ctx context.Context, id int64) (domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -85,19 +115,227 @@ func (mr *MockAuthorRepositoryMockRecorder) GetPublishedById(ctx, id any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedById", reflect.TypeOf((*MockAuthorRepository)(nil).GetPublishedById), ctx, id)
 }
 
+// GetRevision mocks base method.
+func (m *MockAuthorRepository) GetRevision(ctx context.Context, id int64) (domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, id)
+	ret0, _ := ret[0].(domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockAuthorRepositoryMockRecorder) GetRevision(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockAuthorRepository)(nil).GetRevision), ctx, id)
+}
+
 // List mocks base method.
-func (m *MockAuthorRepository) List(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+func (m *MockAuthorRepository) List(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, uid, offset, limit)
+	ret := m.ctrl.Call(m, "List", ctx, uid, cursor, limit)
 	ret0, _ := ret[0].([]domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockAuthorRepositoryMockRecorder) List(ctx, uid, offset, limit any) *gomock.Call {
+func (mr *MockAuthorRepositoryMockRecorder) List(ctx, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAuthorRepository)(nil).List), ctx, uid, cursor, limit)
+}
+
+// ListExpiredTrash mocks base method.
+func (m *MockAuthorRepository) ListExpiredTrash(ctx context.Context, before time.Time, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredTrash", ctx, before, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredTrash indicates an expected call of ListExpiredTrash.
+func (mr *MockAuthorRepositoryMockRecorder) ListExpiredTrash(ctx, before, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredTrash", reflect.TypeOf((*MockAuthorRepository)(nil).ListExpiredTrash), ctx, before, offset, limit)
+}
+
+// ListPub mocks base method.
+func (m *MockAuthorRepository) ListPub(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPub", ctx, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPub indicates an expected call of ListPub.
+func (mr *MockAuthorRepositoryMockRecorder) ListPub(ctx, cursor, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAuthorRepository)(nil).List), ctx, uid, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockAuthorRepository)(nil).ListPub), ctx, cursor, limit)
+}
+
+// ListPubByAuthor mocks base method.
+func (m *MockAuthorRepository) ListPubByAuthor(ctx context.Context, author int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByAuthor", ctx, author, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByAuthor indicates an expected call of ListPubByAuthor.
+func (mr *MockAuthorRepositoryMockRecorder) ListPubByAuthor(ctx, author, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByAuthor", reflect.TypeOf((*MockAuthorRepository)(nil).ListPubByAuthor), ctx, author, cursor, limit)
+}
+
+// ListPubByAuthors mocks base method.
+func (m *MockAuthorRepository) ListPubByAuthors(ctx context.Context, since map[int64]time.Time, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByAuthors", ctx, since, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByAuthors indicates an expected call of ListPubByAuthors.
+func (mr *MockAuthorRepositoryMockRecorder) ListPubByAuthors(ctx, since, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByAuthors", reflect.TypeOf((*MockAuthorRepository)(nil).ListPubByAuthors), ctx, since, cursor, limit)
+}
+
+// ListPubByCategory mocks base method.
+func (m *MockAuthorRepository) ListPubByCategory(ctx context.Context, category string, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByCategory", ctx, category, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByCategory indicates an expected call of ListPubByCategory.
+func (mr *MockAuthorRepositoryMockRecorder) ListPubByCategory(ctx, category, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByCategory", reflect.TypeOf((*MockAuthorRepository)(nil).ListPubByCategory), ctx, category, offset, limit)
+}
+
+// ListPubByIds mocks base method.
+func (m *MockAuthorRepository) ListPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByIds", ctx, ids)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByIds indicates an expected call of ListPubByIds.
+func (mr *MockAuthorRepositoryMockRecorder) ListPubByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByIds", reflect.TypeOf((*MockAuthorRepository)(nil).ListPubByIds), ctx, ids)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockAuthorRepository) ListPubByTag(ctx context.Context, tag string, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByTag", ctx, tag, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockAuthorRepositoryMockRecorder) ListPubByTag(ctx, tag, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockAuthorRepository)(nil).ListPubByTag), ctx, tag, offset, limit)
+}
+
+// ListRevisions mocks base method.
+func (m *MockAuthorRepository) ListRevisions(ctx context.Context, artId, author int64, offset, limit int) ([]domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, artId, author, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockAuthorRepositoryMockRecorder) ListRevisions(ctx, artId, author, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockAuthorRepository)(nil).ListRevisions), ctx, artId, author, offset, limit)
+}
+
+// ListTrash mocks base method.
+func (m *MockAuthorRepository) ListTrash(ctx context.Context, author int64, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, author, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockAuthorRepositoryMockRecorder) ListTrash(ctx, author, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockAuthorRepository)(nil).ListTrash), ctx, author, offset, limit)
+}
+
+// Purge mocks base method.
+func (m *MockAuthorRepository) Purge(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockAuthorRepositoryMockRecorder) Purge(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockAuthorRepository)(nil).Purge), ctx, id)
+}
+
+// Restore mocks base method.
+func (m *MockAuthorRepository) Restore(ctx context.Context, id, author int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, author)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockAuthorRepositoryMockRecorder) Restore(ctx, id, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAuthorRepository)(nil).Restore), ctx, id, author)
+}
+
+// Search mocks base method.
+func (m *MockAuthorRepository) Search(ctx context.Context, q domain.ArticleSearchQuery) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, q)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockAuthorRepositoryMockRecorder) Search(ctx, q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockAuthorRepository)(nil).Search), ctx, q)
+}
+
+// SearchPub mocks base method.
+func (m *MockAuthorRepository) SearchPub(ctx context.Context, q domain.ArticleSearchQuery) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPub", ctx, q)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPub indicates an expected call of SearchPub.
+func (mr *MockAuthorRepositoryMockRecorder) SearchPub(ctx, q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPub", reflect.TypeOf((*MockAuthorRepository)(nil).SearchPub), ctx, q)
 }
 
 // Sync mocks base method.
@@ -129,6 +367,21 @@ func (mr *MockAuthorRepositoryMockRecorder) SyncStatus(ctx, id, author, status a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockAuthorRepository)(nil).SyncStatus), ctx, id, author, status)
 }
 
+// Timeline mocks base method.
+func (m *MockAuthorRepository) Timeline(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Timeline", ctx, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Timeline indicates an expected call of Timeline.
+func (mr *MockAuthorRepositoryMockRecorder) Timeline(ctx, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Timeline", reflect.TypeOf((*MockAuthorRepository)(nil).Timeline), ctx, cursor, limit)
+}
+
 // Update mocks base method.
 func (m *MockAuthorRepository) Update(ctx context.Context, art domain.Article) error {
 	m.ctrl.T.Helper()
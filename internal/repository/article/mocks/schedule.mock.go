@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/article/schedule.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/article/schedule.go -package=repomocks -destination=internal/repository/article/mocks/schedule.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/mrhelloboy/wehook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPublishScheduleRepository is a mock of PublishScheduleRepository interface.
+type MockPublishScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublishScheduleRepositoryMockRecorder
+}
+
+// MockPublishScheduleRepositoryMockRecorder is the mock recorder for MockPublishScheduleRepository.
+type MockPublishScheduleRepositoryMockRecorder struct {
+	mock *MockPublishScheduleRepository
+}
+
+// NewMockPublishScheduleRepository creates a new mock instance.
+func NewMockPublishScheduleRepository(ctrl *gomock.Controller) *MockPublishScheduleRepository {
+	mock := &MockPublishScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockPublishScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublishScheduleRepository) EXPECT() *MockPublishScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockPublishScheduleRepository) Cancel(ctx context.Context, artId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, artId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockPublishScheduleRepositoryMockRecorder) Cancel(ctx, artId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockPublishScheduleRepository)(nil).Cancel), ctx, artId, uid)
+}
+
+// FindDue mocks base method.
+func (m *MockPublishScheduleRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]domain.PublishSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDue", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.PublishSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDue indicates an expected call of FindDue.
+func (mr *MockPublishScheduleRepositoryMockRecorder) FindDue(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDue", reflect.TypeOf((*MockPublishScheduleRepository)(nil).FindDue), ctx, now, limit)
+}
+
+// IncrRetries mocks base method.
+func (m *MockPublishScheduleRepository) IncrRetries(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrRetries", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrRetries indicates an expected call of IncrRetries.
+func (mr *MockPublishScheduleRepositoryMockRecorder) IncrRetries(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrRetries", reflect.TypeOf((*MockPublishScheduleRepository)(nil).IncrRetries), ctx, id)
+}
+
+// ListPending mocks base method.
+func (m *MockPublishScheduleRepository) ListPending(ctx context.Context, uid int64, offset, limit int) ([]domain.PublishSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.PublishSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockPublishScheduleRepositoryMockRecorder) ListPending(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockPublishScheduleRepository)(nil).ListPending), ctx, uid, offset, limit)
+}
+
+// MarkDone mocks base method.
+func (m *MockPublishScheduleRepository) MarkDone(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDone", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDone indicates an expected call of MarkDone.
+func (mr *MockPublishScheduleRepositoryMockRecorder) MarkDone(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDone", reflect.TypeOf((*MockPublishScheduleRepository)(nil).MarkDone), ctx, id)
+}
+
+// MarkFailed mocks base method.
+func (m *MockPublishScheduleRepository) MarkFailed(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockPublishScheduleRepositoryMockRecorder) MarkFailed(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockPublishScheduleRepository)(nil).MarkFailed), ctx, id)
+}
+
+// Set mocks base method.
+func (m *MockPublishScheduleRepository) Set(ctx context.Context, s domain.PublishSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockPublishScheduleRepositoryMockRecorder) Set(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockPublishScheduleRepository)(nil).Set), ctx, s)
+}
+
+// UpdatePublishTime mocks base method.
+func (m *MockPublishScheduleRepository) UpdatePublishTime(ctx context.Context, artId, uid int64, publishTime time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePublishTime", ctx, artId, uid, publishTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePublishTime indicates an expected call of UpdatePublishTime.
+func (mr *MockPublishScheduleRepositoryMockRecorder) UpdatePublishTime(ctx, artId, uid, publishTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePublishTime", reflect.TypeOf((*MockPublishScheduleRepository)(nil).UpdatePublishTime), ctx, artId, uid, publishTime)
+}
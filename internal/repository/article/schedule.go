@@ -0,0 +1,93 @@
+package article
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	daoArt "github.com/mrhelloboy/wehook/internal/repository/dao/article"
+)
+
+var ErrPublishScheduleNotFound = daoArt.ErrRecordNotFound
+
+type PublishScheduleRepository interface {
+	Set(ctx context.Context, s domain.PublishSchedule) error
+	ListPending(ctx context.Context, uid int64, offset int, limit int) ([]domain.PublishSchedule, error)
+	UpdatePublishTime(ctx context.Context, artId int64, uid int64, publishTime time.Time) error
+	Cancel(ctx context.Context, artId int64, uid int64) error
+	FindDue(ctx context.Context, now time.Time, limit int) ([]domain.PublishSchedule, error)
+	MarkDone(ctx context.Context, id int64) error
+	IncrRetries(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64) error
+}
+
+type publishScheduleRepo struct {
+	dao daoArt.PublishScheduleDAO
+}
+
+func NewPublishScheduleRepo(dao daoArt.PublishScheduleDAO) PublishScheduleRepository {
+	return &publishScheduleRepo{dao: dao}
+}
+
+func (r *publishScheduleRepo) Set(ctx context.Context, s domain.PublishSchedule) error {
+	return r.dao.Upsert(ctx, daoArt.PublishSchedule{
+		ArticleId:   s.ArticleId,
+		AuthorId:    s.AuthorId,
+		PublishTime: s.PublishTime.UnixMilli(),
+	})
+}
+
+func (r *publishScheduleRepo) ListPending(ctx context.Context, uid int64, offset int, limit int) ([]domain.PublishSchedule, error) {
+	res, err := r.dao.ListPending(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src daoArt.PublishSchedule) domain.PublishSchedule {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *publishScheduleRepo) UpdatePublishTime(ctx context.Context, artId int64, uid int64, publishTime time.Time) error {
+	return r.dao.UpdatePublishTime(ctx, artId, uid, publishTime.UnixMilli())
+}
+
+func (r *publishScheduleRepo) Cancel(ctx context.Context, artId int64, uid int64) error {
+	return r.dao.Cancel(ctx, artId, uid)
+}
+
+func (r *publishScheduleRepo) FindDue(ctx context.Context, now time.Time, limit int) ([]domain.PublishSchedule, error) {
+	res, err := r.dao.FindDue(ctx, now.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src daoArt.PublishSchedule) domain.PublishSchedule {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *publishScheduleRepo) MarkDone(ctx context.Context, id int64) error {
+	return r.dao.MarkDone(ctx, id)
+}
+
+func (r *publishScheduleRepo) IncrRetries(ctx context.Context, id int64) error {
+	return r.dao.IncrRetries(ctx, id)
+}
+
+func (r *publishScheduleRepo) MarkFailed(ctx context.Context, id int64) error {
+	return r.dao.MarkFailed(ctx, id)
+}
+
+func (r *publishScheduleRepo) toDomain(s daoArt.PublishSchedule) domain.PublishSchedule {
+	return domain.PublishSchedule{
+		Id:          s.Id,
+		ArticleId:   s.ArticleId,
+		AuthorId:    s.AuthorId,
+		PublishTime: time.UnixMilli(s.PublishTime),
+		Status:      domain.PublishScheduleStatus(s.Status),
+		Retries:     s.Retries,
+		Ctime:       time.UnixMilli(s.Ctime),
+		Utime:       time.UnixMilli(s.Utime),
+	}
+}
@@ -0,0 +1,53 @@
+package article
+
+import (
+	"context"
+
+	daoArt "github.com/mrhelloboy/wehook/internal/repository/dao/article"
+	"github.com/mrhelloboy/wehook/internal/repository/search"
+)
+
+// BuildArticleIndex 从数据库全量加载制作库和线上库到索引里。
+// 进程内的索引在启动的时候是空的，需要先调用它；数据库里面的索引第一次部署的时候也要调用一次。
+func BuildArticleIndex(ctx context.Context, dao daoArt.AuthorDAO, index search.ArticleIndex, batchSize int) error {
+	// 只是为了复用 toDomain
+	var repo cachedAuthorRepo
+	var maxId int64
+	for {
+		arts, err := dao.ListAfterId(ctx, maxId, batchSize)
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			maxId = art.Id
+			if art.Status == daoArt.StatusDeleted {
+				continue
+			}
+			if err = index.Upsert(ctx, search.IndexAuthor, repo.toDomain(art)); err != nil {
+				return err
+			}
+		}
+		if len(arts) < batchSize {
+			break
+		}
+	}
+	maxId = 0
+	for {
+		arts, err := dao.ListPubAfterId(ctx, maxId, batchSize)
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			maxId = art.Id
+			if art.Status == daoArt.StatusDeleted {
+				continue
+			}
+			if err = index.Upsert(ctx, search.IndexPublished, repo.toDomain(art.Article)); err != nil {
+				return err
+			}
+		}
+		if len(arts) < batchSize {
+			return nil
+		}
+	}
+}
@@ -16,12 +16,20 @@ type ArticleCache interface {
 	SetFirstPage(ctx context.Context, author int64, arts []domain.Article) error
 	DelFirstPage(ctx context.Context, author int64) error
 
+	// GetPubFirstPage 读者看到的某个作者已发表文章的第一页，author 为 0 的时候是全站的时间线。
+	// 同样只缓存摘要
+	GetPubFirstPage(ctx context.Context, author int64) ([]domain.Article, error)
+	SetPubFirstPage(ctx context.Context, author int64, arts []domain.Article) error
+	DelPubFirstPage(ctx context.Context, author int64) error
+
 	Set(ctx context.Context, art domain.Article) error
 	Get(ctx context.Context, id int64) (domain.Article, error)
+	Del(ctx context.Context, id int64) error
 
 	// SetPub 正常来说，创作者和读者的 Redis 集群要分开，因读者是一个核心中的核心
 	SetPub(ctx context.Context, art domain.Article) error
 	GetPub(ctx context.Context, id int64) (domain.Article, error)
+	DelPub(ctx context.Context, id int64) error
 }
 
 type RedisArticleCache struct {
@@ -43,11 +51,7 @@ func (r *RedisArticleCache) GetFirstPage(ctx context.Context, author int64) ([]d
 }
 
 func (r *RedisArticleCache) SetFirstPage(ctx context.Context, author int64, arts []domain.Article) error {
-	for i := range arts {
-		// 只缓存摘要
-		arts[i].Content = arts[i].Abstract()
-	}
-	bs, err := json.Marshal(arts)
+	bs, err := json.Marshal(r.abstracts(arts))
 	if err != nil {
 		return err
 	}
@@ -58,6 +62,28 @@ func (r *RedisArticleCache) DelFirstPage(ctx context.Context, author int64) erro
 	return r.client.Del(ctx, r.firstPageKey(author)).Err()
 }
 
+func (r *RedisArticleCache) GetPubFirstPage(ctx context.Context, author int64) ([]domain.Article, error) {
+	bs, err := r.client.Get(ctx, r.pubFirstPageKey(author)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var arts []domain.Article
+	err = json.Unmarshal(bs, &arts)
+	return arts, err
+}
+
+func (r *RedisArticleCache) SetPubFirstPage(ctx context.Context, author int64, arts []domain.Article) error {
+	bs, err := json.Marshal(r.abstracts(arts))
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.pubFirstPageKey(author), bs, time.Minute*10).Err()
+}
+
+func (r *RedisArticleCache) DelPubFirstPage(ctx context.Context, author int64) error {
+	return r.client.Del(ctx, r.pubFirstPageKey(author)).Err()
+}
+
 func (r *RedisArticleCache) Set(ctx context.Context, art domain.Article) error {
 	data, err := json.Marshal(art)
 	if err != nil {
@@ -76,6 +102,10 @@ func (r *RedisArticleCache) Get(ctx context.Context, id int64) (domain.Article,
 	return art, err
 }
 
+func (r *RedisArticleCache) Del(ctx context.Context, id int64) error {
+	return r.client.Del(ctx, r.authorArtKey(id)).Err()
+}
+
 func (r *RedisArticleCache) SetPub(ctx context.Context, art domain.Article) error {
 	bs, err := json.Marshal(art)
 	if err != nil {
@@ -96,10 +126,28 @@ func (r *RedisArticleCache) GetPub(ctx context.Context, id int64) (domain.Articl
 	return res, err
 }
 
+func (r *RedisArticleCache) DelPub(ctx context.Context, id int64) error {
+	return r.client.Del(ctx, r.readerArtKey(id)).Err()
+}
+
 func (r *RedisArticleCache) firstPageKey(author int64) string {
 	return fmt.Sprintf("article:first_page:%d", author)
 }
 
+// abstracts 只缓存摘要，不缓存内容。复制一份，不修改调用者的数据
+func (r *RedisArticleCache) abstracts(arts []domain.Article) []domain.Article {
+	res := make([]domain.Article, len(arts))
+	for i, art := range arts {
+		art.Content = ""
+		res[i] = art
+	}
+	return res
+}
+
+func (r *RedisArticleCache) pubFirstPageKey(author int64) string {
+	return fmt.Sprintf("article:pub_first_page:%d", author)
+}
+
 // 创作端的缓存设置
 func (r *RedisArticleCache) authorArtKey(id int64) string {
 	return fmt.Sprintf("article:author:%d", id)
@@ -5,43 +5,46 @@ import (
 	"errors"
 	"time"
 
-	"github.com/ecodeclub/ekit/syncx/atomicx"
+	"github.com/ecodeclub/ekit/syncx"
 
 	"github.com/mrhelloboy/wehook/internal/domain"
 )
 
 type RankingLocalCache struct {
-	// 可以使用 uber 的 atomic，或者 go 本身的
-	topN       *atomicx.Value[[]domain.Article]
-	ddl        *atomicx.Value[time.Time]
+	// key 是榜单名
+	topN       *syncx.Map[string, localTopN]
 	expiration time.Duration
 }
 
+type localTopN struct {
+	arts []domain.Article
+	ddl  time.Time
+}
+
 func NewRankingLocalCache() *RankingLocalCache {
 	return &RankingLocalCache{
-		topN:       atomicx.NewValue[[]domain.Article](),
-		ddl:        atomicx.NewValue[time.Time](),
+		topN:       &syncx.Map[string, localTopN]{},
 		expiration: time.Minute * 10,
 	}
 }
 
-func (r *RankingLocalCache) Set(ctx context.Context, arts []domain.Article) error {
-	r.topN.Store(arts)
-	ddl := time.Now().Add(r.expiration)
-	r.ddl.Store(ddl)
+func (r *RankingLocalCache) Set(ctx context.Context, name string, arts []domain.Article) error {
+	r.topN.Store(name, localTopN{
+		arts: arts,
+		ddl:  time.Now().Add(r.expiration),
+	})
 	return nil
 }
 
-func (r *RankingLocalCache) Get(ctx context.Context) ([]domain.Article, error) {
-	ddl := r.ddl.Load()
-	arts := r.topN.Load()
-	if len(arts) == 0 || ddl.Before(time.Now()) {
+func (r *RankingLocalCache) Get(ctx context.Context, name string) ([]domain.Article, error) {
+	val, ok := r.topN.Load(name)
+	if !ok || len(val.arts) == 0 || val.ddl.Before(time.Now()) {
 		return nil, errors.New("本地缓存未命中")
 	}
-	return arts, nil
+	return val.arts, nil
 }
 
-func (r *RankingLocalCache) ForceGet(ctx context.Context) ([]domain.Article, error) {
-	arts := r.topN.Load()
-	return arts, nil
+func (r *RankingLocalCache) ForceGet(ctx context.Context, name string) ([]domain.Article, error) {
+	val, _ := r.topN.Load(name)
+	return val.arts, nil
 }
@@ -0,0 +1,20 @@
+-- KEYS[1] 合并结果的 key，KEYS[2...] 各个时间桶
+-- ARGV[1] 合并结果的过期时间（毫秒）
+-- ARGV[2] offset，ARGV[3] limit
+-- ARGV[4...] 各个时间桶的权重，和 KEYS[2...] 一一对应
+local dest = KEYS[1]
+if redis.call("exists", dest) == 0 then
+    -- 合并结果过期了，按照衰减后的权重重新合并
+    local args = {"zunionstore", dest, #KEYS - 1}
+    for i = 2, #KEYS do
+        table.insert(args, KEYS[i])
+    end
+    table.insert(args, "weights")
+    for i = 4, #ARGV do
+        table.insert(args, ARGV[i])
+    end
+    redis.call(unpack(args))
+    redis.call("pexpire", dest, ARGV[1])
+end
+-- 取消点赞、取消收藏可能会把分数减到 0 以下，这部分不上榜
+return redis.call("zrevrangebyscore", dest, "+inf", "(0", "limit", ARGV[2], ARGV[3])
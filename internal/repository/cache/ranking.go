@@ -11,16 +11,16 @@ import (
 )
 
 type RankingCache interface {
-	Set(ctx context.Context, arts []domain.Article) error
-	Get(ctx context.Context) ([]domain.Article, error)
+	Set(ctx context.Context, name string, arts []domain.Article) error
+	Get(ctx context.Context, name string) ([]domain.Article, error)
 }
 
 type RankingRedisCache struct {
 	client redis.Cmdable
-	key    string
+	prefix string
 }
 
-func (r *RankingRedisCache) Set(ctx context.Context, arts []domain.Article) error {
+func (r *RankingRedisCache) Set(ctx context.Context, name string, arts []domain.Article) error {
 	for i := 0; i < len(arts); i++ {
 		arts[i].Content = ""
 	}
@@ -28,11 +28,11 @@ func (r *RankingRedisCache) Set(ctx context.Context, arts []domain.Article) erro
 	if err != nil {
 		return err
 	}
-	return r.client.Set(ctx, r.key, val, time.Minute*10).Err()
+	return r.client.Set(ctx, r.key(name), val, time.Minute*10).Err()
 }
 
-func (r *RankingRedisCache) Get(ctx context.Context) ([]domain.Article, error) {
-	data, err := r.client.Get(ctx, r.key).Bytes()
+func (r *RankingRedisCache) Get(ctx context.Context, name string) ([]domain.Article, error) {
+	data, err := r.client.Get(ctx, r.key(name)).Bytes()
 	if err != nil {
 		return nil, err
 	}
@@ -41,9 +41,14 @@ func (r *RankingRedisCache) Get(ctx context.Context) ([]domain.Article, error) {
 	return res, err
 }
 
+// key 每个榜单一个 key，例如 ranking:hot
+func (r *RankingRedisCache) key(name string) string {
+	return r.prefix + ":" + name
+}
+
 func NewRankingRedisCache(client redis.Cmdable) *RankingRedisCache {
 	return &RankingRedisCache{
 		client: client,
-		key:    "ranking",
+		prefix: "ranking",
 	}
 }
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+//go:embed lua/ranking_realtime_top.lua
+var luaRealtimeTopN string
+
+// RankingRealtimeCache 实时榜单，分数由交互事件增量累加，并且随时间衰减
+type RankingRealtimeCache interface {
+	IncrScore(ctx context.Context, aid int64, delta float64) error
+	// TopN 按照衰减后的分数从高到低返回文章 ID
+	TopN(ctx context.Context, offset, limit int) ([]int64, error)
+}
+
+// RankingRealtimeRedisCache 按时间分桶累加分数，每个桶是一个 sorted set。
+// 查询的时候把最近的桶按照衰减后的权重合并起来，越老的桶权重越小，
+// 合并结果只缓存几秒，所以榜单能在秒级反映最新的交互。
+type RankingRealtimeRedisCache struct {
+	client redis.Cmdable
+	prefix string
+	// bucket 每个时间桶的跨度
+	bucket time.Duration
+	// buckets 参与计算的桶的个数，更老的桶直接过期
+	buckets int
+	// halfLife 分数衰减一半需要的时间
+	halfLife time.Duration
+	// snapshotTTL 合并结果的缓存时间
+	snapshotTTL time.Duration
+}
+
+func NewRankingRealtimeRedisCache(client redis.Cmdable) RankingRealtimeCache {
+	return &RankingRealtimeRedisCache{
+		client:      client,
+		prefix:      "ranking:realtime",
+		bucket:      time.Hour,
+		buckets:     24,
+		halfLife:    time.Hour * 6,
+		snapshotTTL: time.Second * 3,
+	}
+}
+
+func (r *RankingRealtimeRedisCache) IncrScore(ctx context.Context, aid int64, delta float64) error {
+	key := r.bucketKey(r.bucketIdx(time.Now()))
+	pipe := r.client.TxPipeline()
+	pipe.ZIncrBy(ctx, key, delta, strconv.FormatInt(aid, 10))
+	// 多留一个桶的时间，保证查询的时候最老的桶还在
+	pipe.Expire(ctx, key, r.bucket*time.Duration(r.buckets+1))
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RankingRealtimeRedisCache) TopN(ctx context.Context, offset, limit int) ([]int64, error) {
+	cur := r.bucketIdx(time.Now())
+	keys := make([]string, 0, r.buckets+1)
+	args := make([]any, 0, r.buckets+3)
+	keys = append(keys, r.prefix)
+	args = append(args, r.snapshotTTL.Milliseconds(), offset, limit)
+	for i := 0; i < r.buckets; i++ {
+		keys = append(keys, r.bucketKey(cur-int64(i)))
+		args = append(args, strconv.FormatFloat(r.weight(i), 'f', -1, 64))
+	}
+	vals, err := r.client.Eval(ctx, luaRealtimeTopN, keys, args...).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]int64, 0, len(vals))
+	for _, val := range vals {
+		aid, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, aid)
+	}
+	return res, nil
+}
+
+// weight 第 age 个桶（0 是当前桶）的权重
+func (r *RankingRealtimeRedisCache) weight(age int) float64 {
+	return math.Pow(0.5, float64(r.bucket*time.Duration(age))/float64(r.halfLife))
+}
+
+func (r *RankingRealtimeRedisCache) bucketIdx(t time.Time) int64 {
+	return t.UnixMilli() / r.bucket.Milliseconds()
+}
+
+func (r *RankingRealtimeRedisCache) bucketKey(idx int64) string {
+	return fmt.Sprintf("%s:%d", r.prefix, idx)
+}
@@ -14,6 +14,7 @@ var ErrKeyNotExist = redis.Nil
 type UserCache interface {
 	Set(ctx context.Context, user domain.User) error
 	Get(ctx context.Context, id int64) (domain.User, error)
+	Del(ctx context.Context, id int64) error
 }
 
 // RedisUserCache 用户缓存
@@ -55,6 +56,10 @@ func (cache *RedisUserCache) Set(ctx context.Context, user domain.User) error {
 	return cache.client.Set(ctx, cache.key(user.Id), val, cache.expiration).Err()
 }
 
+func (cache *RedisUserCache) Del(ctx context.Context, id int64) error {
+	return cache.client.Del(ctx, cache.key(id)).Err()
+}
+
 func (cache *RedisUserCache) key(id int64) string {
 	return fmt.Sprintf("user:info:%d", id)
 }
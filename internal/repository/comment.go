@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository/dao"
+)
+
+var ErrCommentNotFound = dao.ErrCommentNotFound
+
+type CommentRepository interface {
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	FindById(ctx context.Context, id int64) (domain.Comment, error)
+	// Delete 返回一共删掉了多少条评论，根评论会连同回复一起删掉，回复会连同回复它的一起删掉
+	Delete(ctx context.Context, c domain.Comment) (int64, error)
+	Pin(ctx context.Context, c domain.Comment) error
+	Unpin(ctx context.Context, id int64) error
+	// ListRoots 根评论按照 id 倒序，带上回复数。
+	// 第一页（maxId 为 0）会把置顶评论放在最前面，后面的页不会再出现置顶评论
+	ListRoots(ctx context.Context, biz string, bizId int64, maxId int64, limit int) ([]domain.Comment, error)
+	ListReplies(ctx context.Context, rootId int64, minId int64, limit int) ([]domain.Comment, error)
+}
+
+type commentRepo struct {
+	dao dao.CommentDAO
+}
+
+func NewCommentRepo(dao dao.CommentDAO) CommentRepository {
+	return &commentRepo{dao: dao}
+}
+
+func (c *commentRepo) Create(ctx context.Context, cmt domain.Comment) (int64, error) {
+	return c.dao.Insert(ctx, c.toEntity(cmt))
+}
+
+func (c *commentRepo) FindById(ctx context.Context, id int64) (domain.Comment, error) {
+	res, err := c.dao.FindById(ctx, id)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return c.toDomain(res), nil
+}
+
+func (c *commentRepo) Delete(ctx context.Context, cmt domain.Comment) (int64, error) {
+	return c.dao.Delete(ctx, c.toEntity(cmt))
+}
+
+func (c *commentRepo) Pin(ctx context.Context, cmt domain.Comment) error {
+	return c.dao.Pin(ctx, c.toEntity(cmt))
+}
+
+func (c *commentRepo) Unpin(ctx context.Context, id int64) error {
+	return c.dao.Unpin(ctx, id)
+}
+
+func (c *commentRepo) ListRoots(ctx context.Context, biz string, bizId int64, maxId int64, limit int) ([]domain.Comment, error) {
+	roots, err := c.dao.ListRoots(ctx, biz, bizId, maxId, limit)
+	if err != nil {
+		return nil, err
+	}
+	if maxId == 0 {
+		pinned, er := c.dao.FindPinned(ctx, biz, bizId)
+		switch {
+		case er == nil:
+			roots = append([]dao.Comment{pinned}, roots...)
+		case !errors.Is(er, dao.ErrCommentNotFound):
+			return nil, er
+		}
+	}
+	cnts, err := c.dao.CountReplies(ctx, slice.Map[dao.Comment, int64](roots, func(idx int, src dao.Comment) int64 {
+		return src.Id
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Comment, domain.Comment](roots, func(idx int, src dao.Comment) domain.Comment {
+		res := c.toDomain(src)
+		res.ReplyCnt = cnts[src.Id]
+		return res
+	}), nil
+}
+
+func (c *commentRepo) ListReplies(ctx context.Context, rootId int64, minId int64, limit int) ([]domain.Comment, error) {
+	res, err := c.dao.ListReplies(ctx, rootId, minId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Comment, domain.Comment](res, func(idx int, src dao.Comment) domain.Comment {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *commentRepo) toEntity(cmt domain.Comment) dao.Comment {
+	return dao.Comment{
+		Id:      cmt.Id,
+		Biz:     cmt.Biz,
+		BizId:   cmt.BizId,
+		Uid:     cmt.Uid,
+		RootId:  cmt.RootId,
+		Pid:     cmt.Pid,
+		Content: cmt.Content,
+		Pinned:  cmt.Pinned,
+	}
+}
+
+func (c *commentRepo) toDomain(cmt dao.Comment) domain.Comment {
+	return domain.Comment{
+		Id:      cmt.Id,
+		Biz:     cmt.Biz,
+		BizId:   cmt.BizId,
+		Uid:     cmt.Uid,
+		RootId:  cmt.RootId,
+		Pid:     cmt.Pid,
+		Content: cmt.Content,
+		Pinned:  cmt.Pinned,
+		Ctime:   time.UnixMilli(cmt.Ctime),
+		Utime:   time.UnixMilli(cmt.Utime),
+	}
+}
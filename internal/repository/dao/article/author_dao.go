@@ -3,6 +3,7 @@ package article
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -13,23 +14,36 @@ type gormAuthorDAO struct {
 	db *gorm.DB
 }
 
-func (g *gormAuthorDAO) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]Article, error) {
+func (g *gormAuthorDAO) ListPub(ctx context.Context, cursor Cursor, limit int) ([]Article, error) {
+	var res []Article
+	err := g.afterCursor(g.db.WithContext(ctx).Model(&PublishedArticle{}), cursor).
+		Where("status = ?", statusPublished).
+		Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *gormAuthorDAO) ListAfterId(ctx context.Context, id int64, limit int) ([]Article, error) {
 	var res []Article
 	err := g.db.WithContext(ctx).
-		Where("utime < ?", start.UnixMilli()).
-		Order("utime DESC").Offset(offset).Limit(limit).Find(&res).Error
+		Where("id > ?", id).
+		Order("id ASC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *gormAuthorDAO) ListPubAfterId(ctx context.Context, id int64, limit int) ([]PublishedArticle, error) {
+	var res []PublishedArticle
+	err := g.db.WithContext(ctx).
+		Where("id > ?", id).
+		Order("id ASC").Limit(limit).Find(&res).Error
 	return res, err
 }
 
-// GetByAuthor 获取作者的文章列表 - 分页功能
-func (g *gormAuthorDAO) GetByAuthor(ctx context.Context, author int64, offset, limit int) ([]Article, error) {
+// GetByAuthor 获取作者的文章列表 - 分页功能，走 author_utime 联合索引
+func (g *gormAuthorDAO) GetByAuthor(ctx context.Context, author int64, cursor Cursor, limit int) ([]Article, error) {
 	var arts []Article
-	// todo: 数据库查询优化：author_id, utime 作为联合索引
-	err := g.db.WithContext(ctx).Model(&Article{}).
-		Where("author_id = ?", author).
-		Offset(offset).
+	err := g.afterCursor(g.db.WithContext(ctx).Model(&Article{}), cursor).
+		Where("author_id = ? AND status <> ?", author, StatusDeleted).
 		Limit(limit).
-		Order("utime DESC").
 		Find(&arts).Error
 	return arts, err
 }
@@ -49,7 +63,8 @@ func (g *gormAuthorDAO) GetPubById(ctx context.Context, id int64) (PublishedArti
 func (g *gormAuthorDAO) SyncStatus(ctx context.Context, id int64, author int64, status uint8) error {
 	now := time.Now().UnixMilli()
 	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		res := tx.Model(&Article{}).Where("id= ? and author = ?", id, author).Updates(map[string]any{
+		// 回收站里面的文章不能修改状态
+		res := tx.Model(&Article{}).Where("id = ? AND author_id = ? AND status <> ?", id, author, StatusDeleted).Updates(map[string]any{
 			"status": status,
 			"utime":  now,
 		})
@@ -57,7 +72,7 @@ func (g *gormAuthorDAO) SyncStatus(ctx context.Context, id int64, author int64,
 			// 数据库有问题
 			return res.Error
 		}
-		if res.RowsAffected != 0 {
+		if res.RowsAffected == 0 {
 			// 要么 ID 是错的，要么作者不对
 			// 如果是作者不对，就需要留意是否有人在搞事情。
 			// todo: 用 prometheus 打点，只要频繁出现，就需要告警，然后人为介入排查
@@ -75,27 +90,58 @@ func (g *gormAuthorDAO) Insert(ctx context.Context, art Article) (int64, error)
 	now := time.Now().UnixMilli()
 	art.Ctime = now
 	art.Utime = now
-	err := g.db.WithContext(ctx).Create(&art).Error
+	// 文章和它的第一个版本一起写入
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&art).Error; err != nil {
+			return err
+		}
+		rev := newRevision(art, now)
+		return tx.Create(&rev).Error
+	})
 	return art.Id, err
 }
 
+func (g *gormAuthorDAO) ListRevisions(ctx context.Context, artId int64, author int64, offset int, limit int) ([]ArticleRevision, error) {
+	var res []ArticleRevision
+	err := g.db.WithContext(ctx).
+		Where("article_id = ? AND author_id = ?", artId, author).
+		Order("id DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *gormAuthorDAO) GetRevision(ctx context.Context, id int64) (ArticleRevision, error) {
+	var rev ArticleRevision
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&rev).Error
+	return rev, err
+}
+
 func (g *gormAuthorDAO) UpdateById(ctx context.Context, art Article) error {
 	now := time.Now().UnixMilli()
 	art.Utime = now
-	// 确保只有作者才可以修改
-	res := g.db.WithContext(ctx).Model(&art).Where("id = ? AND author_id = ?", art.Id, art.AuthorId).Updates(map[string]any{
-		"title":   art.Title,
-		"content": art.Content,
-		"status":  art.Status,
-		"utime":   art.Utime,
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 确保只有作者才可以修改，回收站里面的也不能改
+		res := tx.Model(&art).Where("id = ? AND author_id = ? AND status <> ?", art.Id, art.AuthorId, StatusDeleted).Updates(map[string]any{
+			"title":       art.Title,
+			"content":     art.Content,
+			"content_key": art.ContentKey,
+			"tags":        art.Tags,
+			"category":    art.Category,
+			"cover":       art.Cover,
+			"summary":     art.Summary,
+			"abstract":    art.Abstract,
+			"status":      art.Status,
+			"utime":       art.Utime,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("更新失败，可能是创作者非法 id %d, author_id %d", art.Id, art.AuthorId)
+		}
+		// 每次保存都记一个版本，版本里是保存之后的内容
+		rev := newRevision(art, now)
+		return tx.Create(&rev).Error
 	})
-	if res.Error != nil {
-		return res.Error
-	}
-	if res.RowsAffected == 0 {
-		return fmt.Errorf("更新失败，可能是创作者非法 id %d, author_id %d", art.Id, art.AuthorId)
-	}
-	return nil
 }
 
 func (g *gormAuthorDAO) Sync(ctx context.Context, art Article) (int64, error) {
@@ -120,8 +166,8 @@ func (g *gormAuthorDAO) Sync(ctx context.Context, art Article) (int64, error) {
 		if err != nil {
 			return err
 		}
-		// 更新线上库
-		// todo：
+		// 更新线上库，新建的文章要用制作库生成的 ID
+		art.Id = id
 		now := time.Now().UnixMilli()
 		publishArt := PublishedArticle{Article: art}
 		publishArt.Ctime = now
@@ -140,17 +186,222 @@ func (g *gormAuthorDAO) Sync(ctx context.Context, art Article) (int64, error) {
 			//}
 			// MySQL 只需使用 DoUpdates 字段
 			DoUpdates: clause.Assignments(map[string]any{
-				"title":   art.Title,
-				"content": art.Content,
-				"status":  art.Status,
-				"utime":   now,
+				"title":       art.Title,
+				"content":     art.Content,
+				"content_key": art.ContentKey,
+				"tags":        art.Tags,
+				"category":    art.Category,
+				"cover":       art.Cover,
+				"summary":     art.Summary,
+				"abstract":    art.Abstract,
+				"status":      art.Status,
+				"utime":       now,
 			}),
 		}).Create(&publishArt).Error
-		return err
+		if err != nil {
+			return err
+		}
+		return g.syncTags(tx, id, art.Tags, now)
 	})
 	return id, err
 }
 
+func (g *gormAuthorDAO) Delete(ctx context.Context, id int64, author int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id = ? AND author_id = ? AND status <> ?", id, author, StatusDeleted).
+			Updates(map[string]any{
+				"status": StatusDeleted,
+				"utime":  now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		// 没发表过的线上库没有数据，不影响
+		return tx.Model(&PublishedArticle{}).Where("id = ?", id).Updates(map[string]any{
+			"status": StatusDeleted,
+			"utime":  now,
+		}).Error
+	})
+}
+
+func (g *gormAuthorDAO) Restore(ctx context.Context, id int64, author int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var pubCnt int64
+		err := tx.Model(&PublishedArticle{}).Where("id = ?", id).Count(&pubCnt).Error
+		if err != nil {
+			return err
+		}
+		// 发表过的不直接恢复成发表，让作者确认之后再发表
+		status := statusUnpublished
+		if pubCnt > 0 {
+			status = statusPrivate
+		}
+		res := tx.Model(&Article{}).
+			Where("id = ? AND author_id = ? AND status = ?", id, author, StatusDeleted).
+			Updates(map[string]any{
+				"status": status,
+				"utime":  now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		if pubCnt == 0 {
+			return nil
+		}
+		return tx.Model(&PublishedArticle{}).Where("id = ?", id).Updates(map[string]any{
+			"status": statusPrivate,
+			"utime":  now,
+		}).Error
+	})
+}
+
+func (g *gormAuthorDAO) GetDeletedByAuthor(ctx context.Context, author int64, offset, limit int) ([]Article, error) {
+	var arts []Article
+	err := g.db.WithContext(ctx).
+		Where("author_id = ? AND status = ?", author, StatusDeleted).
+		Order("utime DESC").Offset(offset).Limit(limit).Find(&arts).Error
+	return arts, err
+}
+
+func (g *gormAuthorDAO) ListDeletedBefore(ctx context.Context, utime int64, offset, limit int) ([]Article, error) {
+	var arts []Article
+	err := g.db.WithContext(ctx).
+		Where("status = ? AND utime < ?", StatusDeleted, utime).
+		Order("utime ASC, id ASC").Offset(offset).Limit(limit).Find(&arts).Error
+	return arts, err
+}
+
+// syncTags 用这次发表的标签替换线上库原来的标签
+func (g *gormAuthorDAO) syncTags(tx *gorm.DB, id int64, tags []string, now int64) error {
+	err := tx.Where("article_id = ?", id).Delete(&PublishedArticleTag{}).Error
+	if err != nil || len(tags) == 0 {
+		return err
+	}
+	res := make([]PublishedArticleTag, 0, len(tags))
+	for _, tag := range tags {
+		res = append(res, PublishedArticleTag{ArticleId: id, Tag: tag, Ctime: now})
+	}
+	return tx.Create(&res).Error
+}
+
+func (g *gormAuthorDAO) ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]Article, error) {
+	var res []Article
+	err := g.db.WithContext(ctx).Model(&PublishedArticle{}).
+		Joins("JOIN published_article_tags t ON t.article_id = published_articles.id").
+		Where("t.tag = ? AND published_articles.status = ?", tag, statusPublished).
+		Order("published_articles.utime DESC").Offset(offset).Limit(limit).
+		Select("published_articles.*").Find(&res).Error
+	return res, err
+}
+
+func (g *gormAuthorDAO) ListPubByCategory(ctx context.Context, category string, offset int, limit int) ([]Article, error) {
+	var res []Article
+	err := g.db.WithContext(ctx).Model(&PublishedArticle{}).
+		Where("category = ? AND status = ?", category, statusPublished).
+		Order("utime DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *gormAuthorDAO) ListPubByCursor(ctx context.Context, cursor Cursor, limit int) ([]Article, error) {
+	var res []Article
+	err := g.afterCursor(g.db.WithContext(ctx).Model(&PublishedArticle{}), cursor).
+		Where("status = ?", statusPublished).
+		Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *gormAuthorDAO) ListPubByAuthor(ctx context.Context, author int64, cursor Cursor, limit int) ([]Article, error) {
+	var res []Article
+	err := g.afterCursor(g.db.WithContext(ctx).Model(&PublishedArticle{}), cursor).
+		Where("author_id = ? AND status = ?", author, statusPublished).
+		Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *gormAuthorDAO) ListPubByAuthors(ctx context.Context, since map[int64]int64, cursor Cursor, limit int) ([]Article, error) {
+	var res []Article
+	authors := g.db.Session(&gorm.Session{NewDB: true})
+	for author, utime := range since {
+		authors = authors.Or("author_id = ? AND utime >= ?", author, utime)
+	}
+	err := g.afterCursor(g.db.WithContext(ctx).Model(&PublishedArticle{}), cursor).
+		Where("status = ?", statusPublished).
+		Where(authors).
+		Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *gormAuthorDAO) ListPubByIds(ctx context.Context, ids []int64) ([]Article, error) {
+	var res []Article
+	err := g.db.WithContext(ctx).Model(&PublishedArticle{}).
+		Where("id IN ? AND status = ?", ids, statusPublished).
+		Find(&res).Error
+	return res, err
+}
+
+// afterCursor 只要 cursor 后面的，按照 (utime, id) 倒序
+func (g *gormAuthorDAO) afterCursor(db *gorm.DB, cursor Cursor) *gorm.DB {
+	if cursor != (Cursor{}) {
+		db = db.Where("(utime < ? OR (utime = ? AND id < ?))", cursor.Utime, cursor.Utime, cursor.Id)
+	}
+	return db.Order("utime DESC, id DESC")
+}
+
+func (g *gormAuthorDAO) CountPubTags(ctx context.Context, prefix string, limit int) ([]TagCount, error) {
+	var res []TagCount
+	db := g.db.WithContext(ctx).Model(&PublishedArticleTag{}).
+		Joins("JOIN published_articles a ON a.id = published_article_tags.article_id").
+		Where("a.status = ?", statusPublished)
+	if prefix != "" {
+		db = db.Where("published_article_tags.tag LIKE ?", escapeLike(prefix)+"%")
+	}
+	err := db.Select("published_article_tags.tag AS tag, COUNT(*) AS cnt").
+		Group("published_article_tags.tag").
+		Order("cnt DESC, tag ASC").Limit(limit).Scan(&res).Error
+	return res, err
+}
+
+// escapeLike 转义 LIKE 里面的通配符
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func (g *gormAuthorDAO) Purge(ctx context.Context, id int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 只能删回收站里面的
+		res := tx.Where("id = ? AND status = ?", id, StatusDeleted).Delete(&Article{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		if err := tx.Where("id = ?", id).Delete(&PublishedArticle{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("article_id = ?", id).Delete(&ArticleRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("article_id = ?", id).Delete(&PublishedArticleTag{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("article_id = ?", id).Delete(&PublishSchedule{}).Error; err != nil {
+			return err
+		}
+		// 评论在 dao 包里面，引用不了 dao.Comment，直接用表名
+		return tx.Exec("DELETE FROM comments WHERE biz = ? AND biz_id = ?", "article", id).Error
+	})
+}
+
 func NewGormArticleDAO(db *gorm.DB) AuthorDAO {
 	return &gormAuthorDAO{db: db}
 }
@@ -0,0 +1,97 @@
+package article
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGormAuthorDAO_SyncStatus(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(t *testing.T) (*sql.DB, sqlmock.Sqlmock)
+		// input
+		id     int64
+		author int64
+		status uint8
+		// output
+		wantErr bool
+	}{
+		{
+			name: "同步成功",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				// 制作库要按 author_id 校验创作者
+				mock.ExpectExec("UPDATE `articles` SET .* WHERE id = \\? AND author_id = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `published_articles` SET .* WHERE id = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return mockDB, mock
+			},
+			id:     1,
+			author: 123,
+			status: 3,
+		},
+		{
+			name: "id 或者作者不对",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET .* WHERE id = \\? AND author_id = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				// 不能再去更新线上库
+				mock.ExpectRollback()
+				return mockDB, mock
+			},
+			id:      1,
+			author:  234,
+			status:  3,
+			wantErr: true,
+		},
+		{
+			name: "数据库错误",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET .*").
+					WillReturnError(errors.New("数据库错误"))
+				mock.ExpectRollback()
+				return mockDB, mock
+			},
+			id:      1,
+			author:  123,
+			status:  3,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock := tc.mock(t)
+			db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+				Conn: mockDB,
+				// 跳过版本检查 即 SELECT VERSION
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			d := NewGormArticleDAO(db)
+			err = d.SyncStatus(context.Background(), tc.id, tc.author, tc.status)
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrContentNotFound 对象存储里面没有这个 key
+var ErrContentNotFound = errors.New("内容不存在")
+
+// ContentStore 存放文章内容的对象存储，key 由调用方生成
+type ContentStore interface {
+	// Put 写入内容，key 已经存在的时候覆盖
+	Put(ctx context.Context, key string, content io.Reader) error
+	// Get 返回的 io.ReadCloser 需要调用方关闭
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete key 不存在的时候不返回错误
+	Delete(ctx context.Context, key string) error
+}
+
+// LocalContentStore 本地文件系统实现，开发和测试的时候用
+// key 里面的 / 对应子目录
+type LocalContentStore struct {
+	root string
+}
+
+func NewLocalContentStore(root string) (*LocalContentStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalContentStore{root: root}, nil
+}
+
+func (s *LocalContentStore) Put(ctx context.Context, key string, content io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// 先写临时文件再改名，读的人不会读到写了一半的内容
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = io.Copy(f, content)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func (s *LocalContentStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrContentNotFound
+	}
+	return f, err
+}
+
+func (s *LocalContentStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path 不允许 key 跳出 root
+func (s *LocalContentStore) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if key == "" || strings.Contains(key, "..") || cleaned == "/" {
+		return "", errors.New("非法的 key")
+	}
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
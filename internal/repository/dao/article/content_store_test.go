@@ -0,0 +1,41 @@
+package article
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalContentStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalContentStore(t.TempDir())
+	require.NoError(t, err)
+
+	err = store.Put(ctx, "article/123/a", strings.NewReader("内容"))
+	require.NoError(t, err)
+	// 覆盖
+	err = store.Put(ctx, "article/123/a", strings.NewReader("新内容"))
+	require.NoError(t, err)
+
+	r, err := store.Get(ctx, "article/123/a")
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "新内容", string(data))
+
+	require.NoError(t, store.Delete(ctx, "article/123/a"))
+	_, err = store.Get(ctx, "article/123/a")
+	assert.Equal(t, ErrContentNotFound, err)
+	// 删除不存在的 key 不报错
+	assert.NoError(t, store.Delete(ctx, "article/123/a"))
+
+	// 不能跳出 root
+	assert.Error(t, store.Put(ctx, "../a", strings.NewReader("内容")))
+	_, err = store.Get(ctx, "")
+	assert.Error(t, err)
+}
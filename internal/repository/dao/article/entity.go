@@ -1,14 +1,78 @@
 package article
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// 和 domain.ArticleStatus 保持一致，DAO 里面要用到的几个状态
+const (
+	statusUnpublished uint8 = 1
+	statusPublished   uint8 = 2
+	statusPrivate     uint8 = 3
+	StatusDeleted     uint8 = 4
+)
+
 // Article 制作库
 type Article struct {
-	Id       int64  `gorm:"primary_key,autoIncrement" bson:"id,omitempty"`
-	Title    string `gorm:"type=varchar(1024)" bson:"title,omitempty"`
-	Content  string `gorm:"type=BLOB" bson:"content,omitempty"`
-	AuthorId int64  `gorm:"index" bson:"author_id,omitempty"`
+	Id      int64  `gorm:"primary_key,autoIncrement" bson:"id,omitempty"`
+	Title   string `gorm:"type=varchar(1024)" bson:"title,omitempty"`
+	Content string `gorm:"type=BLOB" bson:"content,omitempty"`
+	// ContentKey 内容放在对象存储里面的时候，Content 为空，这里是对象存储的 key
+	ContentKey string `gorm:"type=varchar(256)" bson:"content_key,omitempty"`
+	// 线上库按照作者和 (utime, id) 翻页用 author_utime，全站的用 utime
+	AuthorId int64 `gorm:"index;index:author_utime,priority:1" bson:"author_id,omitempty"`
+	// Tags 按照标签查询用 PublishedArticleTag，MongoDB 直接查这个字段
+	Tags     Tags   `gorm:"type:varchar(512)" bson:"tags"`
+	Category string `gorm:"type:varchar(64);index" bson:"category"`
+	Cover    string `gorm:"type:varchar(1024)" bson:"cover"`
+	// Summary 作者填写的摘要，没有填的时候从内容里面生成
+	Summary string `gorm:"type:varchar(512)" bson:"summary"`
+	// Abstract 列表展示用的摘要，保存的时候生成，列表就不用读内容了
+	Abstract string `gorm:"type:varchar(1024)" bson:"abstract"`
 	Status   uint8  `bson:"status,omitempty"`
 	Ctime    int64  `bson:"ctime,omitempty"`
-	Utime    int64  `bson:"utime,omitempty"`
+	Utime    int64  `gorm:"index;index:author_utime,priority:2" bson:"utime,omitempty"`
+}
+
+// Tags 在 MySQL 里面存成 JSON 数组，在 MongoDB 里面就是数组
+type Tags []string
+
+func (t Tags) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	val, err := json.Marshal(t)
+	return string(val), err
+}
+
+func (t *Tags) Scan(src any) error {
+	var data []byte
+	switch val := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		data = val
+	case string:
+		data = []byte(val)
+	default:
+		return errors.New("Tags 只能从 []byte 或者 string 转换")
+	}
+	if len(data) == 0 {
+		*t = nil
+		return nil
+	}
+	return json.Unmarshal(data, t)
+}
+
+// PublishedArticleTag 线上库的文章和标签，用来按照标签查询和统计
+type PublishedArticleTag struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64  `gorm:"uniqueIndex:article_tag"`
+	Tag       string `gorm:"type:varchar(64);uniqueIndex:article_tag;index"`
+	Ctime     int64
 }
 
 // PublishedArticle 线上库，表结构跟制作库一致，表示已发表的状态
@@ -16,6 +80,31 @@ type PublishedArticle struct {
 	Article `bson:"inline"`
 }
 
+// ArticleRevision 制作库的历史版本，每次保存或者发表都会记一条，只增不改
+type ArticleRevision struct {
+	Id        int64  `gorm:"primary_key,autoIncrement" bson:"id,omitempty"`
+	ArticleId int64  `gorm:"index" bson:"article_id,omitempty"`
+	AuthorId  int64  `bson:"author_id,omitempty"`
+	Title     string `gorm:"type=varchar(1024)" bson:"title,omitempty"`
+	Content   string `gorm:"type=BLOB" bson:"content,omitempty"`
+	// ContentKey 同 Article.ContentKey，每个版本的 key 都不一样
+	ContentKey string `gorm:"type=varchar(256)" bson:"content_key,omitempty"`
+	Status     uint8  `bson:"status,omitempty"`
+	Ctime      int64  `bson:"ctime,omitempty"`
+}
+
+func newRevision(art Article, now int64) ArticleRevision {
+	return ArticleRevision{
+		ArticleId:  art.Id,
+		AuthorId:   art.AuthorId,
+		Title:      art.Title,
+		Content:    art.Content,
+		ContentKey: art.ContentKey,
+		Status:     art.Status,
+		Ctime:      now,
+	}
+}
+
 //func (u *Article) BeforeCreate(tx *gorm.DB) (err error) {
 //	startTime := time.Now()
 //	tx.Set("start_time", startTime)
@@ -3,6 +3,8 @@ package article
 import (
 	"context"
 	"errors"
+	"fmt"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -16,28 +18,139 @@ import (
 type mongoDBAuthorDAO struct {
 	col           *mongo.Collection // 制作库
 	liveCol       *mongo.Collection // 线上库
+	revisionCol   *mongo.Collection // 历史版本
 	snowflakeNode *snowflake.Node
 	// idGen         IDGenerator
 }
 
-func (m *mongoDBAuthorDAO) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]Article, error) {
-	// TODO implement me
-	panic("implement me")
+func (m *mongoDBAuthorDAO) ListPub(ctx context.Context, cursor Cursor, limit int) ([]Article, error) {
+	filter := afterCursor(bson.M{"status": statusPublished}, cursor)
+	return m.find(ctx, m.liveCol, filter, cursorOpts(limit))
 }
 
-func (m *mongoDBAuthorDAO) GetByAuthor(ctx context.Context, author int64, offset, limit int) ([]Article, error) {
-	// TODO implement me
-	panic("implement me")
+func (m *mongoDBAuthorDAO) ListAfterId(ctx context.Context, id int64, limit int) ([]Article, error) {
+	opts := options.Find().SetSort(bson.D{bson.E{Key: "id", Value: 1}}).SetLimit(int64(limit))
+	return m.find(ctx, m.col, bson.M{"id": bson.M{"$gt": id}}, opts)
+}
+
+func (m *mongoDBAuthorDAO) ListPubAfterId(ctx context.Context, id int64, limit int) ([]PublishedArticle, error) {
+	opts := options.Find().SetSort(bson.D{bson.E{Key: "id", Value: 1}}).SetLimit(int64(limit))
+	cursor, err := m.liveCol.Find(ctx, bson.M{"id": bson.M{"$gt": id}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []PublishedArticle
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *mongoDBAuthorDAO) Delete(ctx context.Context, id int64, author int64) error {
+	now := time.Now().UnixMilli()
+	filter := bson.M{"id": id, "author_id": author, "status": bson.M{"$ne": StatusDeleted}}
+	update := bson.M{"$set": bson.M{"status": StatusDeleted, "utime": now}}
+	res, err := m.col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrRecordNotFound
+	}
+	// 没法引入事务，线上库更新失败的话，两边的状态会不一致
+	_, err = m.liveCol.UpdateOne(ctx, bson.M{"id": id}, update)
+	return err
+}
+
+func (m *mongoDBAuthorDAO) Restore(ctx context.Context, id int64, author int64) error {
+	now := time.Now().UnixMilli()
+	pubCnt, err := m.liveCol.CountDocuments(ctx, bson.M{"id": id})
+	if err != nil {
+		return err
+	}
+	// 发表过的不直接恢复成发表，让作者确认之后再发表
+	status := statusUnpublished
+	if pubCnt > 0 {
+		status = statusPrivate
+	}
+	filter := bson.M{"id": id, "author_id": author, "status": StatusDeleted}
+	update := bson.M{"$set": bson.M{"status": status, "utime": now}}
+	res, err := m.col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrRecordNotFound
+	}
+	if pubCnt == 0 {
+		return nil
+	}
+	_, err = m.liveCol.UpdateOne(ctx, bson.M{"id": id}, update)
+	return err
+}
+
+func (m *mongoDBAuthorDAO) GetDeletedByAuthor(ctx context.Context, author int64, offset, limit int) ([]Article, error) {
+	filter := bson.M{"author_id": author, "status": StatusDeleted}
+	opts := options.Find().SetSort(bson.D{bson.E{Key: "utime", Value: -1}}).
+		SetSkip(int64(offset)).SetLimit(int64(limit))
+	return m.find(ctx, m.col, filter, opts)
+}
+
+func (m *mongoDBAuthorDAO) ListDeletedBefore(ctx context.Context, utime int64, offset, limit int) ([]Article, error) {
+	filter := bson.M{"status": StatusDeleted, "utime": bson.M{"$lt": utime}}
+	opts := options.Find().SetSort(bson.D{bson.E{Key: "utime", Value: 1}, bson.E{Key: "id", Value: 1}}).
+		SetSkip(int64(offset)).SetLimit(int64(limit))
+	return m.find(ctx, m.col, filter, opts)
+}
+
+// Purge 定时发表和评论都在 MySQL 里面，这里不处理，到期的定时发表发现文章不存在就会失败
+func (m *mongoDBAuthorDAO) Purge(ctx context.Context, id int64) error {
+	// 只能删回收站里面的
+	res, err := m.col.DeleteOne(ctx, bson.M{"id": id, "status": StatusDeleted})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrRecordNotFound
+	}
+	_, err = m.liveCol.DeleteOne(ctx, bson.M{"id": id})
+	if err != nil {
+		return err
+	}
+	_, err = m.revisionCol.DeleteMany(ctx, bson.M{"article_id": id})
+	return err
+}
+
+// GetByAuthor 获取作者的文章列表，不包括回收站里面的
+func (m *mongoDBAuthorDAO) GetByAuthor(ctx context.Context, author int64, cursor Cursor, limit int) ([]Article, error) {
+	filter := afterCursor(bson.M{"author_id": author, "status": bson.M{"$ne": StatusDeleted}}, cursor)
+	return m.find(ctx, m.col, filter, cursorOpts(limit))
 }
 
 func (m *mongoDBAuthorDAO) GetById(ctx context.Context, id int64) (Article, error) {
-	// TODO implement me
-	panic("implement me")
+	var art Article
+	err := m.col.FindOne(ctx, bson.M{"id": id}).Decode(&art)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return art, ErrRecordNotFound
+	}
+	return art, err
 }
 
 func (m *mongoDBAuthorDAO) GetPubById(ctx context.Context, id int64) (PublishedArticle, error) {
-	// TODO implement me
-	panic("implement me")
+	var art PublishedArticle
+	err := m.liveCol.FindOne(ctx, bson.M{"id": id}).Decode(&art)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return art, ErrRecordNotFound
+	}
+	return art, err
+}
+
+func (m *mongoDBAuthorDAO) find(ctx context.Context, col *mongo.Collection, filter any, opts *options.FindOptions) ([]Article, error) {
+	cursor, err := col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Article
+	err = cursor.All(ctx, &res)
+	return res, err
 }
 
 func (m *mongoDBAuthorDAO) Insert(ctx context.Context, art Article) (int64, error) {
@@ -47,26 +160,68 @@ func (m *mongoDBAuthorDAO) Insert(ctx context.Context, art Article) (int64, erro
 	id := m.snowflakeNode.Generate().Int64()
 	art.Id = id
 	_, err := m.col.InsertOne(ctx, art)
-	return id, err
+	if err != nil {
+		return id, err
+	}
+	return id, m.insertRevision(ctx, art, now)
+}
+
+// insertRevision 没有事务，文章写成功但是版本写失败的时候，会少一个版本
+func (m *mongoDBAuthorDAO) insertRevision(ctx context.Context, art Article, now int64) error {
+	rev := newRevision(art, now)
+	rev.Id = m.snowflakeNode.Generate().Int64()
+	_, err := m.revisionCol.InsertOne(ctx, rev)
+	return err
+}
+
+func (m *mongoDBAuthorDAO) ListRevisions(ctx context.Context, artId int64, author int64, offset int, limit int) ([]ArticleRevision, error) {
+	filter := bson.M{"article_id": artId, "author_id": author}
+	// 雪花 ID 是递增的，按 id 倒序就是新的在前
+	opts := options.Find().SetSort(bson.D{bson.E{Key: "id", Value: -1}}).
+		SetSkip(int64(offset)).SetLimit(int64(limit))
+	cursor, err := m.revisionCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []ArticleRevision
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (m *mongoDBAuthorDAO) GetRevision(ctx context.Context, id int64) (ArticleRevision, error) {
+	var rev ArticleRevision
+	err := m.revisionCol.FindOne(ctx, bson.M{"id": id}).Decode(&rev)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return rev, ErrRecordNotFound
+	}
+	return rev, err
 }
 
 // UpdateById 更新制作库
 func (m *mongoDBAuthorDAO) UpdateById(ctx context.Context, art Article) error {
-	filter := bson.M{"id": art.Id, "author_id": art.AuthorId}
+	now := time.Now().UnixMilli()
+	// 确保只有作者才可以修改，回收站里面的也不能改
+	filter := bson.M{"id": art.Id, "author_id": art.AuthorId, "status": bson.M{"$ne": StatusDeleted}}
 	update := bson.D{bson.E{Key: "$set", Value: bson.M{
-		"title":   art.Title,
-		"content": art.Content,
-		"utime":   time.Now().UnixMilli(),
-		"status":  art.Status,
+		"title":       art.Title,
+		"content":     art.Content,
+		"content_key": art.ContentKey,
+		"tags":        art.Tags,
+		"category":    art.Category,
+		"cover":       art.Cover,
+		"summary":     art.Summary,
+		"abstract":    art.Abstract,
+		"utime":       now,
+		"status":      art.Status,
 	}}}
 	res, err := m.col.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
-	if res.ModifiedCount == 0 {
-		return errors.New("更新数据失败")
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("更新失败，可能是创作者非法 id %d, author_id %d", art.Id, art.AuthorId)
 	}
-	return nil
+	return m.insertRevision(ctx, art, now)
 }
 
 func (m *mongoDBAuthorDAO) Sync(ctx context.Context, art Article) (int64, error) {
@@ -97,9 +252,101 @@ func (m *mongoDBAuthorDAO) Sync(ctx context.Context, art Article) (int64, error)
 	return id, err
 }
 
+func (m *mongoDBAuthorDAO) ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]Article, error) {
+	filter := bson.M{"tags": tag, "status": statusPublished}
+	opts := options.Find().SetSort(bson.D{bson.E{Key: "utime", Value: -1}}).
+		SetSkip(int64(offset)).SetLimit(int64(limit))
+	return m.find(ctx, m.liveCol, filter, opts)
+}
+
+func (m *mongoDBAuthorDAO) ListPubByCategory(ctx context.Context, category string, offset int, limit int) ([]Article, error) {
+	filter := bson.M{"category": category, "status": statusPublished}
+	opts := options.Find().SetSort(bson.D{bson.E{Key: "utime", Value: -1}}).
+		SetSkip(int64(offset)).SetLimit(int64(limit))
+	return m.find(ctx, m.liveCol, filter, opts)
+}
+
+func (m *mongoDBAuthorDAO) ListPubByCursor(ctx context.Context, cursor Cursor, limit int) ([]Article, error) {
+	filter := afterCursor(bson.M{"status": statusPublished}, cursor)
+	return m.find(ctx, m.liveCol, filter, cursorOpts(limit))
+}
+
+func (m *mongoDBAuthorDAO) ListPubByAuthor(ctx context.Context, author int64, cursor Cursor, limit int) ([]Article, error) {
+	filter := afterCursor(bson.M{"author_id": author, "status": statusPublished}, cursor)
+	return m.find(ctx, m.liveCol, filter, cursorOpts(limit))
+}
+
+func (m *mongoDBAuthorDAO) ListPubByAuthors(ctx context.Context, since map[int64]int64, cursor Cursor, limit int) ([]Article, error) {
+	authors := make(bson.A, 0, len(since))
+	for author, utime := range since {
+		authors = append(authors, bson.M{"author_id": author, "utime": bson.M{"$gte": utime}})
+	}
+	// afterCursor 占用了 $or，作者的条件放在 $and 里面
+	filter := afterCursor(bson.M{"status": statusPublished, "$and": bson.A{bson.M{"$or": authors}}}, cursor)
+	return m.find(ctx, m.liveCol, filter, cursorOpts(limit))
+}
+
+func (m *mongoDBAuthorDAO) ListPubByIds(ctx context.Context, ids []int64) ([]Article, error) {
+	filter := bson.M{"id": bson.M{"$in": ids}, "status": statusPublished}
+	return m.find(ctx, m.liveCol, filter, options.Find())
+}
+
+// afterCursor 在 filter 上加上 cursor 后面的条件
+func afterCursor(filter bson.M, cursor Cursor) bson.M {
+	if cursor != (Cursor{}) {
+		filter["$or"] = bson.A{
+			bson.M{"utime": bson.M{"$lt": cursor.Utime}},
+			bson.M{"utime": cursor.Utime, "id": bson.M{"$lt": cursor.Id}},
+		}
+	}
+	return filter
+}
+
+// cursorOpts 按照 (utime, id) 倒序取 limit 条
+func cursorOpts(limit int) *options.FindOptions {
+	return options.Find().SetSort(bson.D{bson.E{Key: "utime", Value: -1}, bson.E{Key: "id", Value: -1}}).
+		SetLimit(int64(limit))
+}
+
+func (m *mongoDBAuthorDAO) CountPubTags(ctx context.Context, prefix string, limit int) ([]TagCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": statusPublished}}},
+		{{Key: "$unwind", Value: "$tags"}},
+	}
+	if prefix != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{
+			"tags": bson.M{"$regex": "^" + regexp.QuoteMeta(prefix)},
+		}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.M{"_id": "$tags", "cnt": bson.M{"$sum": 1}}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "cnt", Value: -1}, {Key: "_id", Value: 1}}}},
+		bson.D{{Key: "$limit", Value: limit}},
+	)
+	cursor, err := m.liveCol.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var res []TagCount
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
 func (m *mongoDBAuthorDAO) SyncStatus(ctx context.Context, id int64, author int64, status uint8) error {
-	// TODO implement me
-	panic("implement me")
+	now := time.Now().UnixMilli()
+	// 回收站里面的文章不能修改状态
+	filter := bson.M{"id": id, "author_id": author, "status": bson.M{"$ne": StatusDeleted}}
+	update := bson.M{"$set": bson.M{"status": status, "utime": now}}
+	res, err := m.col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("更新帖子状态失败，可能是创作者非法 id %d, author_id %d", id, author)
+	}
+	// 同步线上库的状态，没发表过的线上库没有数据
+	_, err = m.liveCol.UpdateOne(ctx, bson.M{"id": id}, update)
+	return err
 }
 
 // InitCollection 初始化集合及索引
@@ -116,11 +363,52 @@ func InitCollection(db *mongo.Database) error {
 			Options: options.Index(),
 		},
 	}
-	_, err := db.Collection("articles").Indexes().CreateMany(ctx, index)
+	// 作者的文章列表和回收站
+	_, err := db.Collection("articles").Indexes().CreateMany(ctx, append(index,
+		mongo.IndexModel{
+			Keys:    bson.D{bson.E{Key: "author_id", Value: 1}, bson.E{Key: "utime", Value: -1}},
+			Options: options.Index(),
+		},
+		mongo.IndexModel{
+			Keys:    bson.D{bson.E{Key: "status", Value: 1}, bson.E{Key: "utime", Value: 1}},
+			Options: options.Index(),
+		},
+	))
 	if err != nil {
 		return err
 	}
-	_, err = db.Collection("published_articles").Indexes().CreateMany(ctx, index)
+	// ListPub 按照 utime 倒序翻页，按照标签、分类和作者查询
+	_, err = db.Collection("published_articles").Indexes().CreateMany(ctx, append(index,
+		mongo.IndexModel{
+			Keys:    bson.D{bson.E{Key: "utime", Value: -1}},
+			Options: options.Index(),
+		},
+		mongo.IndexModel{
+			Keys:    bson.D{bson.E{Key: "author_id", Value: 1}, bson.E{Key: "utime", Value: -1}},
+			Options: options.Index(),
+		},
+		mongo.IndexModel{
+			Keys:    bson.D{bson.E{Key: "tags", Value: 1}, bson.E{Key: "utime", Value: -1}},
+			Options: options.Index(),
+		},
+		mongo.IndexModel{
+			Keys:    bson.D{bson.E{Key: "category", Value: 1}, bson.E{Key: "utime", Value: -1}},
+			Options: options.Index(),
+		},
+	))
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection("article_revisions").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{bson.E{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{bson.E{Key: "article_id", Value: 1}, bson.E{Key: "id", Value: -1}},
+			Options: options.Index(),
+		},
+	})
 	return err
 }
 
@@ -128,6 +416,7 @@ func NewMongoDBAuthorDAO(db *mongo.Database, node *snowflake.Node) AuthorDAO {
 	return &mongoDBAuthorDAO{
 		col:           db.Collection("articles"),
 		liveCol:       db.Collection("published_articles"),
+		revisionCol:   db.Collection("article_revisions"),
 		snowflakeNode: node,
 	}
 }
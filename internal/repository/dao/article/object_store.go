@@ -0,0 +1,215 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+)
+
+// ObjectStoreAuthorDAO 装饰一个 AuthorDAO，把文章内容放到对象存储里面，
+// 数据库里面只保留元数据和内容的 key。
+// 没有 key 的老数据还是从数据库里面读内容，所以可以直接在已有数据上启用。
+// 只有详情、历史版本和全量遍历这几个要用到内容的方法会读对象存储，
+// 其余的列表直接用装饰的 AuthorDAO，展示用数据库里面的摘要
+type ObjectStoreAuthorDAO struct {
+	AuthorDAO
+	store ContentStore
+	// 列表一次并发读多少个内容
+	concurrency int
+}
+
+// maxContentSize 一篇文章的内容最多读多少字节
+const maxContentSize = 16 << 20
+
+var errContentTooLarge = errors.New("文章内容过大")
+
+func NewObjectStoreAuthorDAO(dao AuthorDAO, store ContentStore) AuthorDAO {
+	return &ObjectStoreAuthorDAO{
+		AuthorDAO:   dao,
+		store:       store,
+		concurrency: 10,
+	}
+}
+
+func (o *ObjectStoreAuthorDAO) Insert(ctx context.Context, art Article) (int64, error) {
+	art, err := o.putContent(ctx, art)
+	if err != nil {
+		return 0, err
+	}
+	return o.AuthorDAO.Insert(ctx, art)
+}
+
+// UpdateById 每次保存都写一个新的 key，旧的 key 还被历史版本和线上库引用，不能覆盖也不能删除
+func (o *ObjectStoreAuthorDAO) UpdateById(ctx context.Context, art Article) error {
+	art, err := o.putContent(ctx, art)
+	if err != nil {
+		return err
+	}
+	return o.AuthorDAO.UpdateById(ctx, art)
+}
+
+func (o *ObjectStoreAuthorDAO) Sync(ctx context.Context, art Article) (int64, error) {
+	art, err := o.putContent(ctx, art)
+	if err != nil {
+		return 0, err
+	}
+	return o.AuthorDAO.Sync(ctx, art)
+}
+
+func (o *ObjectStoreAuthorDAO) GetById(ctx context.Context, id int64) (Article, error) {
+	art, err := o.AuthorDAO.GetById(ctx, id)
+	if err != nil {
+		return art, err
+	}
+	art.Content, err = o.getContent(ctx, art.ContentKey, art.Content)
+	return art, err
+}
+
+func (o *ObjectStoreAuthorDAO) GetPubById(ctx context.Context, id int64) (PublishedArticle, error) {
+	art, err := o.AuthorDAO.GetPubById(ctx, id)
+	if err != nil {
+		return art, err
+	}
+	art.Content, err = o.getContent(ctx, art.ContentKey, art.Content)
+	return art, err
+}
+
+// ListAfterId 重建索引要用到内容
+func (o *ObjectStoreAuthorDAO) ListAfterId(ctx context.Context, id int64, limit int) ([]Article, error) {
+	arts, err := o.AuthorDAO.ListAfterId(ctx, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	err = o.eachContent(ctx, len(arts), func(i int) (string, string, *string) {
+		return arts[i].ContentKey, arts[i].Content, &arts[i].Content
+	})
+	return arts, err
+}
+
+func (o *ObjectStoreAuthorDAO) ListPubAfterId(ctx context.Context, id int64, limit int) ([]PublishedArticle, error) {
+	arts, err := o.AuthorDAO.ListPubAfterId(ctx, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	err = o.eachContent(ctx, len(arts), func(i int) (string, string, *string) {
+		return arts[i].ContentKey, arts[i].Content, &arts[i].Content
+	})
+	return arts, err
+}
+
+func (o *ObjectStoreAuthorDAO) ListRevisions(ctx context.Context, artId int64, author int64, offset int, limit int) ([]ArticleRevision, error) {
+	revs, err := o.AuthorDAO.ListRevisions(ctx, artId, author, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	err = o.eachContent(ctx, len(revs), func(i int) (string, string, *string) {
+		return revs[i].ContentKey, revs[i].Content, &revs[i].Content
+	})
+	return revs, err
+}
+
+func (o *ObjectStoreAuthorDAO) GetRevision(ctx context.Context, id int64) (ArticleRevision, error) {
+	rev, err := o.AuthorDAO.GetRevision(ctx, id)
+	if err != nil {
+		return rev, err
+	}
+	rev.Content, err = o.getContent(ctx, rev.ContentKey, rev.Content)
+	return rev, err
+}
+
+// Purge 先删对象存储里面的内容再删数据库，中途失败了文章还在回收站里面，下一轮会重新删一遍
+func (o *ObjectStoreAuthorDAO) Purge(ctx context.Context, id int64) error {
+	art, err := o.AuthorDAO.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if art.Status != StatusDeleted {
+		return ErrRecordNotFound
+	}
+	keys := []string{art.ContentKey}
+	pub, err := o.AuthorDAO.GetPubById(ctx, id)
+	switch {
+	case err == nil:
+		keys = append(keys, pub.ContentKey)
+	case !errors.Is(err, ErrRecordNotFound):
+		return err
+	}
+	const batchSize = 100
+	for offset := 0; ; offset += batchSize {
+		revs, err := o.AuthorDAO.ListRevisions(ctx, id, art.AuthorId, offset, batchSize)
+		if err != nil {
+			return err
+		}
+		for _, rev := range revs {
+			keys = append(keys, rev.ContentKey)
+		}
+		if len(revs) < batchSize {
+			break
+		}
+	}
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err = o.store.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return o.AuthorDAO.Purge(ctx, id)
+}
+
+// putContent 写入对象存储，返回的 art 里面只有 key 没有内容。
+// 数据库写失败的时候，对象存储里面会留下没人引用的内容
+func (o *ObjectStoreAuthorDAO) putContent(ctx context.Context, art Article) (Article, error) {
+	key := fmt.Sprintf("article/%d/%s", art.AuthorId, uuid.NewString())
+	err := o.store.Put(ctx, key, strings.NewReader(art.Content))
+	if err != nil {
+		return art, err
+	}
+	art.Content = ""
+	art.ContentKey = key
+	return art, nil
+}
+
+// getContent key 为空的是启用对象存储之前的数据，内容还在数据库里面。
+// 超过 maxContentSize 的内容返回 errContentTooLarge，防止一篇异常的内容把内存打满
+func (o *ObjectStoreAuthorDAO) getContent(ctx context.Context, key string, content string) (string, error) {
+	if key == "" {
+		return content, nil
+	}
+	r, err := o.store.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	var sb strings.Builder
+	n, err := io.Copy(&sb, io.LimitReader(r, maxContentSize+1))
+	if err != nil {
+		return "", err
+	}
+	if n > maxContentSize {
+		return "", fmt.Errorf("%w: %s", errContentTooLarge, key)
+	}
+	return sb.String(), nil
+}
+
+// eachContent 并发读取 n 条数据的内容，field 返回第 i 条的 key、数据库里的内容和要写回的位置
+func (o *ObjectStoreAuthorDAO) eachContent(ctx context.Context, n int,
+	field func(i int) (key string, content string, dst *string)) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(o.concurrency)
+	for i := 0; i < n; i++ {
+		key, content, dst := field(i)
+		eg.Go(func() error {
+			res, err := o.getContent(ctx, key, content)
+			*dst = res
+			return err
+		})
+	}
+	return eg.Wait()
+}
@@ -0,0 +1,141 @@
+package article
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	PublishScheduleStatusPending uint8 = iota + 1
+	PublishScheduleStatusDone
+	PublishScheduleStatusCancelled
+	// PublishScheduleStatusFailed 重试了好几次都没有发表成功
+	PublishScheduleStatusFailed
+)
+
+// PublishSchedule 定时发表，一篇文章同时只有一条
+type PublishSchedule struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"uniqueIndex"`
+	AuthorId  int64 `gorm:"index"`
+	// PublishTime 和 Status 联合索引，用于查找到期的任务
+	PublishTime int64 `gorm:"index:idx_status_publish_time,priority:2"`
+	Status      uint8 `gorm:"index:idx_status_publish_time,priority:1"`
+	// Retries 发表失败了几次
+	Retries int
+	Ctime   int64
+	Utime   int64
+}
+
+type PublishScheduleDAO interface {
+	// Upsert 设置文章的定时发表时间，之前的定时不管是什么状态都会被覆盖成待发表，失败次数清零
+	Upsert(ctx context.Context, s PublishSchedule) error
+	ListPending(ctx context.Context, author int64, offset int, limit int) ([]PublishSchedule, error)
+	// UpdatePublishTime 只能修改待发表的
+	UpdatePublishTime(ctx context.Context, artId int64, author int64, publishTime int64) error
+	// Cancel 只能取消待发表的
+	Cancel(ctx context.Context, artId int64, author int64) error
+	// FindDue 找出 now 之前就该发表的
+	FindDue(ctx context.Context, now int64, limit int) ([]PublishSchedule, error)
+	// MarkDone 标记为已发表，已经不是待发表状态的话返回 ErrRecordNotFound
+	MarkDone(ctx context.Context, id int64) error
+	// IncrRetries 发表失败，下一轮再试，已经不是待发表状态的话返回 ErrRecordNotFound
+	IncrRetries(ctx context.Context, id int64) error
+	// MarkFailed 标记为发表失败，不再重试，已经不是待发表状态的话返回 ErrRecordNotFound
+	MarkFailed(ctx context.Context, id int64) error
+}
+
+type GORMPublishScheduleDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMPublishScheduleDAO(db *gorm.DB) PublishScheduleDAO {
+	return &GORMPublishScheduleDAO{db: db}
+}
+
+func (g *GORMPublishScheduleDAO) Upsert(ctx context.Context, s PublishSchedule) error {
+	now := time.Now().UnixMilli()
+	s.Status = PublishScheduleStatusPending
+	s.Ctime = now
+	s.Utime = now
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"author_id":    s.AuthorId,
+			"publish_time": s.PublishTime,
+			"status":       s.Status,
+			"retries":      0,
+			"utime":        now,
+		}),
+	}).Create(&s).Error
+}
+
+func (g *GORMPublishScheduleDAO) ListPending(ctx context.Context, author int64, offset int, limit int) ([]PublishSchedule, error) {
+	var res []PublishSchedule
+	err := g.db.WithContext(ctx).
+		Where("author_id = ? AND status = ?", author, PublishScheduleStatusPending).
+		Order("publish_time ASC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GORMPublishScheduleDAO) UpdatePublishTime(ctx context.Context, artId int64, author int64, publishTime int64) error {
+	return g.updatePending(g.db.WithContext(ctx).
+		Where("article_id = ? AND author_id = ?", artId, author), map[string]any{
+		"publish_time": publishTime,
+		"utime":        time.Now().UnixMilli(),
+	})
+}
+
+func (g *GORMPublishScheduleDAO) Cancel(ctx context.Context, artId int64, author int64) error {
+	return g.updatePending(g.db.WithContext(ctx).
+		Where("article_id = ? AND author_id = ?", artId, author), map[string]any{
+		"status": PublishScheduleStatusCancelled,
+		"utime":  time.Now().UnixMilli(),
+	})
+}
+
+func (g *GORMPublishScheduleDAO) FindDue(ctx context.Context, now int64, limit int) ([]PublishSchedule, error) {
+	var res []PublishSchedule
+	err := g.db.WithContext(ctx).
+		Where("status = ? AND publish_time <= ?", PublishScheduleStatusPending, now).
+		Order("publish_time ASC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GORMPublishScheduleDAO) MarkDone(ctx context.Context, id int64) error {
+	return g.updatePending(g.db.WithContext(ctx).Where("id = ?", id), map[string]any{
+		"status": PublishScheduleStatusDone,
+		"utime":  time.Now().UnixMilli(),
+	})
+}
+
+func (g *GORMPublishScheduleDAO) IncrRetries(ctx context.Context, id int64) error {
+	return g.updatePending(g.db.WithContext(ctx).Where("id = ?", id), map[string]any{
+		"retries": gorm.Expr("retries + 1"),
+		"utime":   time.Now().UnixMilli(),
+	})
+}
+
+func (g *GORMPublishScheduleDAO) MarkFailed(ctx context.Context, id int64) error {
+	return g.updatePending(g.db.WithContext(ctx).Where("id = ?", id), map[string]any{
+		"retries": gorm.Expr("retries + 1"),
+		"status":  PublishScheduleStatusFailed,
+		"utime":   time.Now().UnixMilli(),
+	})
+}
+
+// updatePending 带上 status 条件，避免覆盖已经发表或者取消的
+func (g *GORMPublishScheduleDAO) updatePending(db *gorm.DB, updates map[string]any) error {
+	res := db.Model(&PublishSchedule{}).
+		Where("status = ?", PublishScheduleStatusPending).
+		Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
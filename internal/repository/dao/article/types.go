@@ -2,11 +2,15 @@ package article
 
 import (
 	"context"
-	"time"
+
+	"gorm.io/gorm"
 )
 
+var ErrRecordNotFound = gorm.ErrRecordNotFound
+
 type AuthorDAO interface {
-	GetByAuthor(ctx context.Context, author int64, offset, limit int) ([]Article, error)
+	// GetByAuthor 作者的文章，不包括回收站里面的，按照 (utime, id) 倒序，从 cursor 的下一条开始
+	GetByAuthor(ctx context.Context, author int64, cursor Cursor, limit int) ([]Article, error)
 	GetById(ctx context.Context, id int64) (Article, error)
 	GetPubById(ctx context.Context, id int64) (PublishedArticle, error)
 	Insert(ctx context.Context, art Article) (int64, error)
@@ -14,7 +18,57 @@ type AuthorDAO interface {
 	Sync(ctx context.Context, art Article) (int64, error)
 	// upsert(ctx context.Context, art PublishedArticle) error
 	SyncStatus(ctx context.Context, id int64, author int64, status uint8) error
-	ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]Article, error)
+	// ListPub 线上库里公开发表的文章，按照 (utime, id) 倒序。
+	// 只要 utime 早于某个时间的，用 Cursor{Utime: start} 就可以
+	ListPub(ctx context.Context, cursor Cursor, limit int) ([]Article, error)
+	// ListAfterId 按照 id 升序遍历制作库，用于重建索引这类全量的任务
+	ListAfterId(ctx context.Context, id int64, limit int) ([]Article, error)
+	// ListPubAfterId 按照 id 升序遍历线上库
+	ListPubAfterId(ctx context.Context, id int64, limit int) ([]PublishedArticle, error)
+	// ListRevisions 作者某篇文章的历史版本，新的在前
+	ListRevisions(ctx context.Context, artId int64, author int64, offset int, limit int) ([]ArticleRevision, error)
+	GetRevision(ctx context.Context, id int64) (ArticleRevision, error)
+
+	// Delete 放进回收站，线上库也一起标记为删除，已经删除过的返回 ErrRecordNotFound
+	Delete(ctx context.Context, id int64, author int64) error
+	// Restore 从回收站恢复，发表过的恢复成仅自己可见，没发表过的恢复成草稿
+	Restore(ctx context.Context, id int64, author int64) error
+	// GetDeletedByAuthor 回收站，最近删除的在前
+	GetDeletedByAuthor(ctx context.Context, author int64, offset, limit int) ([]Article, error)
+	// ListDeletedBefore 删除时间（utime）早于 utime 的，最早删除的在前。
+	// offset 用来跳过前面删不掉的文章
+	ListDeletedBefore(ctx context.Context, utime int64, offset, limit int) ([]Article, error)
+	// Purge 彻底删除回收站里面的文章，连同线上库、历史版本、定时发表和评论
+	Purge(ctx context.Context, id int64) error
+
+	// ListPubByTag 线上库里公开发表的、带这个标签的文章，新的在前
+	ListPubByTag(ctx context.Context, tag string, offset int, limit int) ([]Article, error)
+	// ListPubByCategory 线上库里公开发表的、这个分类的文章，新的在前
+	ListPubByCategory(ctx context.Context, category string, offset int, limit int) ([]Article, error)
+	// CountPubTags 以 prefix 开头的标签和公开发表的文章数，文章多的在前。prefix 为空就是所有标签
+	CountPubTags(ctx context.Context, prefix string, limit int) ([]TagCount, error)
+
+	// ListPubByCursor 线上库里公开发表的文章，按照 (utime, id) 倒序，从 cursor 的下一条开始
+	ListPubByCursor(ctx context.Context, cursor Cursor, limit int) ([]Article, error)
+	// ListPubByAuthor 同 ListPubByCursor，只要某个作者的
+	ListPubByAuthor(ctx context.Context, author int64, cursor Cursor, limit int) ([]Article, error)
+	// ListPubByAuthors 同 ListPubByCursor，只要 since 里面这些作者的，
+	// 并且 utime 不早于 since 里面这个作者对应的时间（毫秒）
+	ListPubByAuthors(ctx context.Context, since map[int64]int64, cursor Cursor, limit int) ([]Article, error)
+	// ListPubByIds 线上库里公开发表的文章，不保证顺序，不存在或者不是公开发表的不在结果里面
+	ListPubByIds(ctx context.Context, ids []int64) ([]Article, error)
+}
+
+// Cursor 按照 (utime, id) 倒序翻页的位置，就是上一页最后一条的 utime 和 id。
+// 零值表示从最新的开始
+type Cursor struct {
+	Utime int64
+	Id    int64
+}
+
+type TagCount struct {
+	Tag string `bson:"_id"`
+	Cnt int64  `bson:"cnt"`
 }
 
 type ReaderDAO interface {
@@ -0,0 +1,100 @@
+package dao
+
+import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ArticleSearchDAO interface {
+	// Upsert 同一个索引里面同一篇文章只有一条记录
+	Upsert(ctx context.Context, doc ArticleSearchDoc) error
+	Delete(ctx context.Context, idx string, articleId int64) error
+	// Search 按照文章的更新时间倒序
+	Search(ctx context.Context, idx string, cond ArticleSearchCond, offset, limit int) ([]ArticleSearchDoc, error)
+	Count(ctx context.Context, idx string) (int64, error)
+}
+
+// ArticleSearchCond 零值的字段表示不过滤
+type ArticleSearchCond struct {
+	// Terms 小写的关键字，Text 要包含全部关键字
+	Terms    []string
+	AuthorId int64
+	Status   uint8
+	// Start 和 End 是对 Utime 的过滤，左闭右开
+	Start int64
+	End   int64
+}
+
+type GORMArticleSearchDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMArticleSearchDAO(db *gorm.DB) ArticleSearchDAO {
+	return &GORMArticleSearchDAO{db: db}
+}
+
+func (g *GORMArticleSearchDAO) Upsert(ctx context.Context, doc ArticleSearchDoc) error {
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"author_id", "status", "title", "abstract", "text", "ctime", "utime"}),
+	}).Create(&doc).Error
+}
+
+func (g *GORMArticleSearchDAO) Delete(ctx context.Context, idx string, articleId int64) error {
+	return g.db.WithContext(ctx).
+		Where("idx = ? AND article_id = ?", idx, articleId).
+		Delete(&ArticleSearchDoc{}).Error
+}
+
+func (g *GORMArticleSearchDAO) Search(ctx context.Context, idx string, cond ArticleSearchCond, offset, limit int) ([]ArticleSearchDoc, error) {
+	db := g.db.WithContext(ctx).Where("idx = ?", idx)
+	if cond.AuthorId > 0 {
+		db = db.Where("author_id = ?", cond.AuthorId)
+	}
+	if cond.Status > 0 {
+		db = db.Where("status = ?", cond.Status)
+	}
+	if cond.Start > 0 {
+		db = db.Where("utime >= ?", cond.Start)
+	}
+	if cond.End > 0 {
+		db = db.Where("utime < ?", cond.End)
+	}
+	for _, term := range cond.Terms {
+		db = db.Where("text LIKE ?", "%"+escapeLike(term)+"%")
+	}
+	var res []ArticleSearchDoc
+	err := db.Order("utime DESC, article_id DESC").
+		Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GORMArticleSearchDAO) Count(ctx context.Context, idx string) (int64, error) {
+	var cnt int64
+	err := g.db.WithContext(ctx).Model(&ArticleSearchDoc{}).Where("idx = ?", idx).Count(&cnt).Error
+	return cnt, err
+}
+
+// escapeLike 关键字里面的 % 和 _ 按照普通字符匹配
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// ArticleSearchDoc 文章搜索索引里面的一篇文章，所有实例共用这张表
+type ArticleSearchDoc struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// Idx 索引的名字，制作库和线上库各一个
+	Idx       string `gorm:"type:varchar(32);uniqueIndex:idx_article;index:idx_utime,priority:1"`
+	ArticleId int64  `gorm:"uniqueIndex:idx_article"`
+	AuthorId  int64
+	Status    uint8
+	Title     string `gorm:"type:varchar(4096)"`
+	Abstract  string `gorm:"type:varchar(1024)"`
+	// Text 小写的标题和内容，用于忽略大小写匹配
+	Text  string `gorm:"type:longtext"`
+	Ctime int64
+	// Utime 文章的更新时间
+	Utime int64 `gorm:"index:idx_utime,priority:2"`
+}
@@ -0,0 +1,40 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGORMArticleSearchDAO_Search(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	// 每个关键字都要命中，关键字里面的 % 和 _ 按照普通字符匹配
+	mock.ExpectQuery("SELECT \\* FROM `article_search_docs` WHERE idx = \\? AND author_id = \\? AND utime >= \\? "+
+		"AND text LIKE \\? AND text LIKE \\? ORDER BY utime DESC, article_id DESC LIMIT 10 OFFSET 20").
+		WithArgs("article_published", int64(123), int64(1000), "%go%", `%100\%%`).
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "title"}).AddRow(1, "Go"))
+
+	db, err := gorm.Open(gormMysql.New(gormMysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	d := NewGORMArticleSearchDAO(db)
+	docs, err := d.Search(context.Background(), "article_published", ArticleSearchCond{
+		Terms:    []string{"go", "100%"},
+		AuthorId: 123,
+		Start:    1000,
+	}, 20, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []ArticleSearchDoc{{ArticleId: 1, Title: "Go"}}, docs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,206 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrCommentNotFound 单独定义，和帖子不存在区分开
+var ErrCommentNotFound = errors.New("评论不存在")
+
+type CommentDAO interface {
+	Insert(ctx context.Context, c Comment) (int64, error)
+	FindById(ctx context.Context, id int64) (Comment, error)
+	// Delete 删除根评论的时候连同下面的回复一起删掉，
+	// 删除回复的时候连同直接或者间接回复它的一起删掉，返回一共删掉了多少条
+	Delete(ctx context.Context, c Comment) (int64, error)
+	// Pin 置顶一条根评论，同一个资源原来置顶的会被取消
+	Pin(ctx context.Context, c Comment) error
+	// Unpin 不是置顶的根评论返回 ErrCommentNotFound
+	Unpin(ctx context.Context, id int64) error
+	// FindPinned 资源的置顶评论，没有的返回 ErrCommentNotFound
+	FindPinned(ctx context.Context, biz string, bizId int64) (Comment, error)
+	// ListRoots 没有置顶的根评论，按照 id 倒序，maxId 为 0 表示从最新的开始
+	ListRoots(ctx context.Context, biz string, bizId int64, maxId int64, limit int) ([]Comment, error)
+	// ListReplies 根评论下面的回复，按照 id 正序，也就是先回复的在前面
+	ListReplies(ctx context.Context, rootId int64, minId int64, limit int) ([]Comment, error)
+	// CountReplies 每一条根评论下面有多少回复，没有回复的不在结果里面
+	CountReplies(ctx context.Context, rootIds []int64) (map[int64]int64, error)
+}
+
+type GORMCommentDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMCommentDAO(db *gorm.DB) CommentDAO {
+	return &GORMCommentDAO{db: db}
+}
+
+func (g *GORMCommentDAO) Insert(ctx context.Context, c Comment) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := g.db.WithContext(ctx).Create(&c).Error
+	return c.Id, err
+}
+
+func (g *GORMCommentDAO) FindById(ctx context.Context, id int64) (Comment, error) {
+	var res Comment
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&res).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Comment{}, ErrCommentNotFound
+	}
+	return res, err
+}
+
+func (g *GORMCommentDAO) Delete(ctx context.Context, c Comment) (int64, error) {
+	if c.RootId == 0 {
+		res := g.db.WithContext(ctx).Where("id = ? OR root_id = ?", c.Id, c.Id).Delete(&Comment{})
+		return res.RowsAffected, res.Error
+	}
+	var cnt int64
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 同一条根评论下面的回复不会太多，全部捞出来在内存里面找子树
+		var replies []Comment
+		err := tx.Select("id", "pid").
+			Where("root_id = ?", c.RootId).
+			Find(&replies).Error
+		if err != nil {
+			return err
+		}
+		ids := subtreeIds(c.Id, replies)
+		res := tx.Where("id IN ?", ids).Delete(&Comment{})
+		cnt = res.RowsAffected
+		return res.Error
+	})
+	return cnt, err
+}
+
+// subtreeIds id 和直接或者间接回复它的评论
+func subtreeIds(id int64, replies []Comment) []int64 {
+	children := make(map[int64][]int64, len(replies))
+	for _, r := range replies {
+		children[r.Pid] = append(children[r.Pid], r.Id)
+	}
+	res := []int64{id}
+	for i := 0; i < len(res); i++ {
+		res = append(res, children[res[i]]...)
+	}
+	return res
+}
+
+func (g *GORMCommentDAO) Pin(ctx context.Context, c Comment) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Comment{}).
+			Where("biz = ? AND biz_id = ? AND root_id = 0 AND pinned = ?", c.Biz, c.BizId, true).
+			Updates(map[string]any{
+				"pinned": false,
+				"utime":  now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		res := tx.Model(&Comment{}).
+			Where("id = ? AND root_id = 0", c.Id).
+			Updates(map[string]any{
+				"pinned": true,
+				"utime":  now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrCommentNotFound
+		}
+		return nil
+	})
+}
+
+func (g *GORMCommentDAO) Unpin(ctx context.Context, id int64) error {
+	res := g.db.WithContext(ctx).Model(&Comment{}).
+		Where("id = ? AND root_id = 0 AND pinned = ?", id, true).
+		Updates(map[string]any{
+			"pinned": false,
+			"utime":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrCommentNotFound
+	}
+	return nil
+}
+
+func (g *GORMCommentDAO) FindPinned(ctx context.Context, biz string, bizId int64) (Comment, error) {
+	var res Comment
+	err := g.db.WithContext(ctx).
+		Where("biz = ? AND biz_id = ? AND root_id = 0 AND pinned = ?", biz, bizId, true).
+		First(&res).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Comment{}, ErrCommentNotFound
+	}
+	return res, err
+}
+
+func (g *GORMCommentDAO) ListRoots(ctx context.Context, biz string, bizId int64, maxId int64, limit int) ([]Comment, error) {
+	var res []Comment
+	query := g.db.WithContext(ctx).
+		Where("biz = ? AND biz_id = ? AND root_id = 0 AND pinned = ?", biz, bizId, false)
+	if maxId > 0 {
+		query = query.Where("id < ?", maxId)
+	}
+	err := query.Order("id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCommentDAO) ListReplies(ctx context.Context, rootId int64, minId int64, limit int) ([]Comment, error) {
+	var res []Comment
+	err := g.db.WithContext(ctx).
+		Where("root_id = ? AND id > ?", rootId, minId).
+		Order("id ASC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GORMCommentDAO) CountReplies(ctx context.Context, rootIds []int64) (map[int64]int64, error) {
+	res := make(map[int64]int64, len(rootIds))
+	if len(rootIds) == 0 {
+		return res, nil
+	}
+	var rows []struct {
+		RootId int64
+		Cnt    int64
+	}
+	err := g.db.WithContext(ctx).Model(&Comment{}).
+		Select("root_id, COUNT(*) AS cnt").
+		Where("root_id IN ?", rootIds).
+		Group("root_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		res[row.RootId] = row.Cnt
+	}
+	return res, nil
+}
+
+// Comment 评论表
+type Comment struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// 根评论按照资源查询，按照 id 倒序
+	Biz   string `gorm:"type:varchar(128);index:biz_root_id,priority:1"`
+	BizId int64  `gorm:"index:biz_root_id,priority:2"`
+	Uid   int64  `gorm:"index"`
+	// RootId 为 0 的是根评论，回复按照 root_id 查询，按照 id 正序
+	RootId  int64 `gorm:"index:biz_root_id,priority:3;index:root_id"`
+	Pid     int64
+	Content string `gorm:"type:varchar(4096)"`
+	Pinned  bool
+	Ctime   int64
+	Utime   int64
+}
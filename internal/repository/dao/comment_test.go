@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubtreeIds(t *testing.T) {
+	// 根评论 1 下面：2 回复 1，3 回复 2，4 回复 3，5 回复 1
+	replies := []Comment{
+		{Id: 2, Pid: 1},
+		{Id: 3, Pid: 2},
+		{Id: 4, Pid: 3},
+		{Id: 5, Pid: 1},
+	}
+	assert.ElementsMatch(t, []int64{2, 3, 4}, subtreeIds(2, replies))
+	assert.ElementsMatch(t, []int64{4}, subtreeIds(4, replies))
+	assert.ElementsMatch(t, []int64{5}, subtreeIds(5, replies))
+}
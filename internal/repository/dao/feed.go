@@ -0,0 +1,70 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FeedDAO 关注的人发表的文章推到粉丝的收件箱里面
+type FeedDAO interface {
+	// BatchUpsert 同一个人同一篇文章只有一条，重新发表只会把 utime 往后更新，所以重复消费是幂等的
+	BatchUpsert(ctx context.Context, items []FeedInbox) error
+	// List 收件箱，按照 (utime, aid) 倒序，从 (utime, aid) 的下一条开始，utime 为 0 表示从最新的开始
+	List(ctx context.Context, uid int64, utime int64, aid int64, limit int) ([]FeedInbox, error)
+	// DeleteByAuthor 取消关注之后，这个作者推过来的都删掉
+	DeleteByAuthor(ctx context.Context, uid int64, author int64) error
+}
+
+type GORMFeedDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMFeedDAO(db *gorm.DB) FeedDAO {
+	return &GORMFeedDAO{db: db}
+}
+
+func (g *GORMFeedDAO) BatchUpsert(ctx context.Context, items []FeedInbox) error {
+	if len(items) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	for i := range items {
+		items[i].Ctime = now
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"utime": gorm.Expr("GREATEST(utime, VALUES(utime))"),
+		}),
+	}).Create(&items).Error
+}
+
+func (g *GORMFeedDAO) List(ctx context.Context, uid int64, utime int64, aid int64, limit int) ([]FeedInbox, error) {
+	var res []FeedInbox
+	query := g.db.WithContext(ctx).Where("uid = ?", uid)
+	if utime > 0 {
+		query = query.Where("(utime < ? OR (utime = ? AND aid < ?))", utime, utime, aid)
+	}
+	err := query.Order("utime DESC, aid DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GORMFeedDAO) DeleteByAuthor(ctx context.Context, uid int64, author int64) error {
+	return g.db.WithContext(ctx).
+		Where("uid = ? AND author_id = ?", uid, author).
+		Delete(&FeedInbox{}).Error
+}
+
+// FeedInbox 收件箱，粉丝不多的作者发表文章的时候推给每一个粉丝
+type FeedInbox struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// 一个人的收件箱里面一篇文章只有一条
+	Uid      int64 `gorm:"uniqueIndex:uid_aid;index:uid_utime,priority:1;index:uid_author,priority:1"`
+	Aid      int64 `gorm:"uniqueIndex:uid_aid"`
+	AuthorId int64 `gorm:"index:uid_author,priority:2"`
+	// Utime 文章在线上库的更新时间，收件箱按照它排序
+	Utime int64 `gorm:"index:uid_utime,priority:2"`
+	Ctime int64
+}
@@ -0,0 +1,153 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrFollowRelationNotFound = errors.New("没有关注")
+
+type FollowRelationDAO interface {
+	// Follow 已经关注过的什么都不做，第一次关注才会更新两边的计数
+	Follow(ctx context.Context, r FollowRelation) error
+	// Unfollow 没有关注过的什么都不做
+	Unfollow(ctx context.Context, follower, followee int64) error
+	// FindRelation 没有关注的返回 ErrFollowRelationNotFound
+	FindRelation(ctx context.Context, follower, followee int64) (FollowRelation, error)
+	// ListFollowers 粉丝，最近关注的在前，maxId 为 0 表示从最新的开始
+	ListFollowers(ctx context.Context, followee int64, maxId int64, limit int) ([]FollowRelation, error)
+	// ListFollowees 关注的人，最近关注的在前，maxId 为 0 表示从最新的开始
+	ListFollowees(ctx context.Context, follower int64, maxId int64, limit int) ([]FollowRelation, error)
+	// ListBigFollowees follower 关注的人里面，粉丝数不少于 minFollowers 的关注关系
+	ListBigFollowees(ctx context.Context, follower int64, minFollowers int64) ([]FollowRelation, error)
+	// GetStatics 没有关注过别人也没有被关注过的返回零值
+	GetStatics(ctx context.Context, uid int64) (FollowStatics, error)
+}
+
+type GORMFollowRelationDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMFollowRelationDAO(db *gorm.DB) FollowRelationDAO {
+	return &GORMFollowRelationDAO{db: db}
+}
+
+func (g *GORMFollowRelationDAO) Follow(ctx context.Context, r FollowRelation) error {
+	now := time.Now().UnixMilli()
+	r.Ctime = now
+	r.Utime = now
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&r)
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		if err := g.incrStatics(tx, r.Followee, "followers", 1, now); err != nil {
+			return err
+		}
+		return g.incrStatics(tx, r.Follower, "followees", 1, now)
+	})
+}
+
+func (g *GORMFollowRelationDAO) Unfollow(ctx context.Context, follower, followee int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("follower = ? AND followee = ?", follower, followee).Delete(&FollowRelation{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		if err := g.incrStatics(tx, followee, "followers", -1, now); err != nil {
+			return err
+		}
+		return g.incrStatics(tx, follower, "followees", -1, now)
+	})
+}
+
+// incrStatics 计数加 delta，还没有计数的就插入一条
+func (g *GORMFollowRelationDAO) incrStatics(tx *gorm.DB, uid int64, col string, delta int64, now int64) error {
+	s := FollowStatics{Uid: uid, Ctime: now, Utime: now}
+	if delta > 0 {
+		switch col {
+		case "followers":
+			s.Followers = delta
+		case "followees":
+			s.Followees = delta
+		}
+	}
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			col:     gorm.Expr("GREATEST("+col+" + ?, 0)", delta),
+			"utime": now,
+		}),
+	}).Create(&s).Error
+}
+
+func (g *GORMFollowRelationDAO) FindRelation(ctx context.Context, follower, followee int64) (FollowRelation, error) {
+	var res FollowRelation
+	err := g.db.WithContext(ctx).
+		Where("follower = ? AND followee = ?", follower, followee).
+		First(&res).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return FollowRelation{}, ErrFollowRelationNotFound
+	}
+	return res, err
+}
+
+func (g *GORMFollowRelationDAO) ListFollowers(ctx context.Context, followee int64, maxId int64, limit int) ([]FollowRelation, error) {
+	return g.list(ctx, "followee", followee, maxId, limit)
+}
+
+func (g *GORMFollowRelationDAO) ListFollowees(ctx context.Context, follower int64, maxId int64, limit int) ([]FollowRelation, error) {
+	return g.list(ctx, "follower", follower, maxId, limit)
+}
+
+func (g *GORMFollowRelationDAO) list(ctx context.Context, col string, uid int64, maxId int64, limit int) ([]FollowRelation, error) {
+	var res []FollowRelation
+	query := g.db.WithContext(ctx).Where(col+" = ?", uid)
+	if maxId > 0 {
+		query = query.Where("id < ?", maxId)
+	}
+	err := query.Order("id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GORMFollowRelationDAO) ListBigFollowees(ctx context.Context, follower int64, minFollowers int64) ([]FollowRelation, error) {
+	var res []FollowRelation
+	err := g.db.WithContext(ctx).Model(&FollowRelation{}).
+		Joins("JOIN follow_statics ON follow_statics.uid = follow_relations.followee").
+		Where("follow_relations.follower = ? AND follow_statics.followers >= ?", follower, minFollowers).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMFollowRelationDAO) GetStatics(ctx context.Context, uid int64) (FollowStatics, error) {
+	var res FollowStatics
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).First(&res).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return FollowStatics{Uid: uid}, nil
+	}
+	return res, err
+}
+
+// FollowRelation 关注关系，取消关注直接删掉
+type FollowRelation struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// 关注列表按照 follower 查询，粉丝列表按照 followee 查询，都按照 id 倒序
+	Follower int64 `gorm:"uniqueIndex:follower_followee"`
+	Followee int64 `gorm:"uniqueIndex:follower_followee;index"`
+	Ctime    int64
+	Utime    int64
+}
+
+// FollowStatics 关注和粉丝的计数，和关注关系在同一个事务里面更新
+type FollowStatics struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	Uid       int64 `gorm:"uniqueIndex"`
+	Followers int64
+	Followees int64
+	Ctime     int64
+	Utime     int64
+}
@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type HistoryRecordDAO interface {
+	// Upsert 同一个用户对同一个资源只保留一条记录，阅读时间只会往后更新
+	Upsert(ctx context.Context, r HistoryRecord) error
+	// ListByUid 按照阅读时间倒序
+	ListByUid(ctx context.Context, uid int64, offset, limit int) ([]HistoryRecord, error)
+	Delete(ctx context.Context, uid int64, biz string, bizId int64) error
+	DeleteByUid(ctx context.Context, uid int64) error
+}
+
+type GORMHistoryRecordDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMHistoryRecordDAO(db *gorm.DB) HistoryRecordDAO {
+	return &GORMHistoryRecordDAO{db: db}
+}
+
+func (g *GORMHistoryRecordDAO) Upsert(ctx context.Context, r HistoryRecord) error {
+	now := time.Now().UnixMilli()
+	r.Ctime = now
+	r.Utime = now
+	// 重复消费或者消息乱序的时候，阅读时间不会回退，所以是幂等的
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"read_time": gorm.Expr("GREATEST(read_time, ?)", r.ReadTime),
+			"utime":     now,
+		}),
+	}).Create(&r).Error
+}
+
+func (g *GORMHistoryRecordDAO) ListByUid(ctx context.Context, uid int64, offset, limit int) ([]HistoryRecord, error) {
+	var res []HistoryRecord
+	err := g.db.WithContext(ctx).
+		Where("uid = ?", uid).
+		Order("read_time DESC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMHistoryRecordDAO) Delete(ctx context.Context, uid int64, biz string, bizId int64) error {
+	return g.db.WithContext(ctx).
+		Where("uid = ? AND biz = ? AND biz_id = ?", uid, biz, bizId).
+		Delete(&HistoryRecord{}).Error
+}
+
+func (g *GORMHistoryRecordDAO) DeleteByUid(ctx context.Context, uid int64) error {
+	return g.db.WithContext(ctx).Where("uid = ?", uid).Delete(&HistoryRecord{}).Error
+}
+
+// HistoryRecord 阅读记录
+type HistoryRecord struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// 一个用户对一个资源只有一条记录
+	Uid   int64  `gorm:"uniqueIndex:uid_biz_type_id;index:uid_read_time"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:uid_biz_type_id"`
+	BizId int64  `gorm:"uniqueIndex:uid_biz_type_id"`
+	// 列表按照 uid 查询，按照阅读时间排序
+	ReadTime int64 `gorm:"index:uid_read_time"`
+	Ctime    int64
+	Utime    int64
+}
@@ -11,6 +11,17 @@ func InitTables(db *gorm.DB) error {
 		&User{},
 		&article.Article{},
 		&article.PublishedArticle{},
+		&article.PublishedArticleTag{},
+		&article.ArticleRevision{},
+		&article.PublishSchedule{},
 		&Job{},
+		&HistoryRecord{},
+		&Comment{},
+		&FollowRelation{},
+		&FollowStatics{},
+		&FeedInbox{},
+		&Notification{},
+		&NotificationActor{},
+		&ArticleSearchDoc{},
 	)
 }
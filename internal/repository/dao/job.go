@@ -5,20 +5,40 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrNoJob 没有可以抢占的任务
+var ErrNoJob = gorm.ErrRecordNotFound
+
 type JobDAO interface {
 	Preempt(ctx context.Context) (Job, error)
 	Release(ctx context.Context, id int64) error
 	UpdateUtime(ctx context.Context, id int64) error
 	UpdateNextTime(ctx context.Context, id int64, next time.Time) error
 	Stop(ctx context.Context, id int64) error
+	// Insert 注册任务，同名的任务已经存在就什么也不做
+	Insert(ctx context.Context, j Job) error
 }
 
 type GORMJobDAO struct {
 	db *gorm.DB
 }
 
+func NewGORMJobDAO(db *gorm.DB) JobDAO {
+	return &GORMJobDAO{db: db}
+}
+
+func (g *GORMJobDAO) Insert(ctx context.Context, j Job) error {
+	now := time.Now().UnixMilli()
+	j.Ctime = now
+	j.Utime = now
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoNothing: true,
+	}).Create(&j).Error
+}
+
 // Preempt 抢占任务
 func (g *GORMJobDAO) Preempt(ctx context.Context) (Job, error) {
 	// 高并发情况下，大部分都是陪太子读书
@@ -33,7 +53,7 @@ func (g *GORMJobDAO) Preempt(ctx context.Context) (Job, error) {
 		// 1. 一次拉一批，我一次性取出 100 条来，然后，我随机从某一条开始，向后开始抢占
 		// 2. 我搞一个随机偏移量，0-100 生成一个随机偏移量。兜底：第一轮没查到，偏移量回归到 0
 		// 3. 我搞一个 id 取余分配，status = ？AND next_time <= ? AND id%10 = ? 兜底：不加余数条件，取 next_time 最老的
-		err := db.WithContext(ctx).Where("status = ? AND next_time <= ?", jobStatusWaiting, now).First(&j).Error
+		err := db.WithContext(ctx).Where("status = ? AND next_time <= ?", jobStatusWaiting, now.UnixMilli()).First(&j).Error
 		// 抢占任务
 		if err != nil {
 			// 没有任务
@@ -46,11 +66,11 @@ func (g *GORMJobDAO) Preempt(ctx context.Context) (Job, error) {
 		res := db.Where("id = ? AND version = ?", j.Id, j.Version).Model(&Job{}).
 			Updates(map[string]any{
 				"status":  jobStatusRunning,
-				"utime":   now,
+				"utime":   now.UnixMilli(),
 				"version": j.Version + 1,
 			})
 		if res.Error != nil {
-			return Job{}, err
+			return Job{}, res.Error
 		}
 		if res.RowsAffected == 0 {
 			// 抢占失败，只能继续下一轮
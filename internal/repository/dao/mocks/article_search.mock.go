@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/dao/article_search.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/dao/article_search.go -package=daomocks -destination=internal/repository/dao/mocks/article_search.mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/mrhelloboy/wehook/internal/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleSearchDAO is a mock of ArticleSearchDAO interface.
+type MockArticleSearchDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleSearchDAOMockRecorder
+}
+
+// MockArticleSearchDAOMockRecorder is the mock recorder for MockArticleSearchDAO.
+type MockArticleSearchDAOMockRecorder struct {
+	mock *MockArticleSearchDAO
+}
+
+// NewMockArticleSearchDAO creates a new mock instance.
+func NewMockArticleSearchDAO(ctrl *gomock.Controller) *MockArticleSearchDAO {
+	mock := &MockArticleSearchDAO{ctrl: ctrl}
+	mock.recorder = &MockArticleSearchDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleSearchDAO) EXPECT() *MockArticleSearchDAOMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockArticleSearchDAO) Count(ctx context.Context, idx string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, idx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockArticleSearchDAOMockRecorder) Count(ctx, idx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockArticleSearchDAO)(nil).Count), ctx, idx)
+}
+
+// Delete mocks base method.
+func (m *MockArticleSearchDAO) Delete(ctx context.Context, idx string, articleId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, idx, articleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleSearchDAOMockRecorder) Delete(ctx, idx, articleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleSearchDAO)(nil).Delete), ctx, idx, articleId)
+}
+
+// Search mocks base method.
+func (m *MockArticleSearchDAO) Search(ctx context.Context, idx string, cond dao.ArticleSearchCond, offset, limit int) ([]dao.ArticleSearchDoc, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, idx, cond, offset, limit)
+	ret0, _ := ret[0].([]dao.ArticleSearchDoc)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockArticleSearchDAOMockRecorder) Search(ctx, idx, cond, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockArticleSearchDAO)(nil).Search), ctx, idx, cond, offset, limit)
+}
+
+// Upsert mocks base method.
+func (m *MockArticleSearchDAO) Upsert(ctx context.Context, doc dao.ArticleSearchDoc) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, doc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockArticleSearchDAOMockRecorder) Upsert(ctx, doc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockArticleSearchDAO)(nil).Upsert), ctx, doc)
+}
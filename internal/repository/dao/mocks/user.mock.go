@@ -113,3 +113,59 @@ func (mr *MockUserDAOMockRecorder) Insert(ctx, u any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUserDAO)(nil).Insert), ctx, u)
 }
+
+// UpdateEmail mocks base method.
+func (m *MockUserDAO) UpdateEmail(ctx context.Context, id int64, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmail", ctx, id, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmail indicates an expected call of UpdateEmail.
+func (mr *MockUserDAOMockRecorder) UpdateEmail(ctx, id, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmail", reflect.TypeOf((*MockUserDAO)(nil).UpdateEmail), ctx, id, email)
+}
+
+// UpdateNonSensitiveInfo mocks base method.
+func (m *MockUserDAO) UpdateNonSensitiveInfo(ctx context.Context, u dao.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNonSensitiveInfo", ctx, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNonSensitiveInfo indicates an expected call of UpdateNonSensitiveInfo.
+func (mr *MockUserDAOMockRecorder) UpdateNonSensitiveInfo(ctx, u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNonSensitiveInfo", reflect.TypeOf((*MockUserDAO)(nil).UpdateNonSensitiveInfo), ctx, u)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserDAO) UpdatePassword(ctx context.Context, id int64, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, id, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserDAOMockRecorder) UpdatePassword(ctx, id, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserDAO)(nil).UpdatePassword), ctx, id, password)
+}
+
+// UpdatePhone mocks base method.
+func (m *MockUserDAO) UpdatePhone(ctx context.Context, id int64, phone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePhone", ctx, id, phone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePhone indicates an expected call of UpdatePhone.
+func (mr *MockUserDAOMockRecorder) UpdatePhone(ctx, id, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePhone", reflect.TypeOf((*MockUserDAO)(nil).UpdatePhone), ctx, id, phone)
+}
@@ -0,0 +1,125 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationDAO interface {
+	// Upsert 合并到同一个资源上同一类未读的通知里面，没有的话新建一条
+	Upsert(ctx context.Context, n Notification) error
+	// List 最近有更新的在前
+	List(ctx context.Context, uid int64, offset, limit int) ([]Notification, error)
+	// MarkRead 只会标记 uid 自己的通知
+	MarkRead(ctx context.Context, uid int64, ids []int64) error
+	MarkAllRead(ctx context.Context, uid int64) error
+	CountUnread(ctx context.Context, uid int64) (int64, error)
+}
+
+type GORMNotificationDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMNotificationDAO(db *gorm.DB) NotificationDAO {
+	return &GORMNotificationDAO{db: db}
+}
+
+func (g *GORMNotificationDAO) Upsert(ctx context.Context, n Notification) error {
+	now := time.Now().UnixMilli()
+	n.ActorCnt = 0
+	n.ReadAt = 0
+	n.Ctime = now
+	n.Utime = now
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]any{
+				"last_actor": n.LastActor,
+				"utime":      now,
+			}),
+		}).Create(&n).Error
+		if err != nil {
+			return err
+		}
+		// 冲突的时候拿不到 id，按照唯一索引再查一次
+		var cur Notification
+		err = tx.Select("id").
+			Where("uid = ? AND type = ? AND biz = ? AND biz_id = ? AND read_at = 0",
+				n.Uid, n.Type, n.Biz, n.BizId).
+			First(&cur).Error
+		if err != nil {
+			return err
+		}
+		// 同一个人触发多次（比如消息重复消费、取消之后又点赞）只计一次
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&NotificationActor{
+			NotificationId: cur.Id,
+			Actor:          n.LastActor,
+			Ctime:          now,
+		})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		return tx.Model(&Notification{}).Where("id = ?", cur.Id).
+			Update("actor_cnt", gorm.Expr("actor_cnt + 1")).Error
+	})
+}
+
+func (g *GORMNotificationDAO) List(ctx context.Context, uid int64, offset, limit int) ([]Notification, error) {
+	var res []Notification
+	err := g.db.WithContext(ctx).
+		Where("uid = ?", uid).
+		Order("utime DESC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GORMNotificationDAO) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return g.markRead(g.db.WithContext(ctx).Where("uid = ? AND id IN ? AND read_at = 0", uid, ids))
+}
+
+func (g *GORMNotificationDAO) MarkAllRead(ctx context.Context, uid int64) error {
+	return g.markRead(g.db.WithContext(ctx).Where("uid = ? AND read_at = 0", uid))
+}
+
+// markRead 已读的 read_at 是读的时间，所以同一个资源上可以有多条已读的通知
+func (g *GORMNotificationDAO) markRead(query *gorm.DB) error {
+	return query.Model(&Notification{}).Update("read_at", time.Now().UnixMilli()).Error
+}
+
+func (g *GORMNotificationDAO) CountUnread(ctx context.Context, uid int64) (int64, error) {
+	var res int64
+	err := g.db.WithContext(ctx).Model(&Notification{}).
+		Where("uid = ? AND read_at = 0", uid).
+		Count(&res).Error
+	return res, err
+}
+
+// Notification 站内通知
+type Notification struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// 同一个人、同一个资源、同一类通知，未读的（read_at 为 0）只有一条
+	Uid   int64  `gorm:"uniqueIndex:uid_group,priority:1;index:uid_utime,priority:1"`
+	Type  uint8  `gorm:"uniqueIndex:uid_group,priority:2"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:uid_group,priority:3"`
+	BizId int64  `gorm:"uniqueIndex:uid_group,priority:4"`
+	// ReadAt 读的时间，0 表示未读
+	ReadAt    int64 `gorm:"uniqueIndex:uid_group,priority:5"`
+	LastActor int64
+	ActorCnt  int64
+	Ctime     int64
+	Utime     int64 `gorm:"index:uid_utime,priority:2"`
+}
+
+// NotificationActor 一条通知里面有哪些人，用来算去重之后的 ActorCnt
+type NotificationActor struct {
+	Id             int64 `gorm:"primaryKey,autoIncrement"`
+	NotificationId int64 `gorm:"uniqueIndex:notification_actor,priority:1"`
+	Actor          int64 `gorm:"uniqueIndex:notification_actor,priority:2"`
+	Ctime          int64
+}
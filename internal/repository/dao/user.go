@@ -20,6 +20,12 @@ type UserDAO interface {
 	FindById(ctx context.Context, id int64) (User, error)
 	FindByWechat(ctx context.Context, openID string) (User, error)
 	Insert(ctx context.Context, u User) error
+	// UpdateNonSensitiveInfo 更新昵称、生日、简介和头像，空值也会覆盖上去
+	UpdateNonSensitiveInfo(ctx context.Context, u User) error
+	UpdatePhone(ctx context.Context, id int64, phone string) error
+	UpdateEmail(ctx context.Context, id int64, email string) error
+	// UpdatePassword password 是加密之后的
+	UpdatePassword(ctx context.Context, id int64, password string) error
 }
 
 type GORMUserDAO struct {
@@ -59,7 +65,44 @@ func (dao *GORMUserDAO) Insert(ctx context.Context, u User) error {
 	u.Ctime = now
 	u.Utime = now
 	err := dao.db.WithContext(ctx).Create(&u).Error
+	return dao.translateErr(err)
+}
+
+func (dao *GORMUserDAO) UpdateNonSensitiveInfo(ctx context.Context, u User) error {
+	return dao.updateById(ctx, u.Id, map[string]any{
+		"nickname": u.Nickname,
+		"birthday": u.Birthday,
+		"about_me": u.AboutMe,
+		"avatar":   u.Avatar,
+	})
+}
+
+func (dao *GORMUserDAO) UpdatePhone(ctx context.Context, id int64, phone string) error {
+	return dao.updateById(ctx, id, map[string]any{
+		"phone": sql.NullString{String: phone, Valid: phone != ""},
+	})
+}
+
+func (dao *GORMUserDAO) UpdateEmail(ctx context.Context, id int64, email string) error {
+	return dao.updateById(ctx, id, map[string]any{
+		"email": sql.NullString{String: email, Valid: email != ""},
+	})
+}
+
+func (dao *GORMUserDAO) UpdatePassword(ctx context.Context, id int64, password string) error {
+	return dao.updateById(ctx, id, map[string]any{
+		"password": password,
+	})
+}
+
+func (dao *GORMUserDAO) updateById(ctx context.Context, id int64, fields map[string]any) error {
+	fields["utime"] = time.Now().UnixMilli()
+	err := dao.db.WithContext(ctx).Model(&User{}).Where("`id` = ?", id).Updates(fields).Error
+	return dao.translateErr(err)
+}
 
+// translateErr 邮箱、手机号码的唯一索引冲突转成 ErrUserDuplicate
+func (dao *GORMUserDAO) translateErr(err error) error {
 	// 下面代码存在强耦合问题，表明是与Mysql数据库相关的
 	// 如果切换成其他数据库，需要修改
 	//if mysqlError, ok := err.(*mysql.MySQLError); ok {
@@ -84,6 +127,10 @@ type User struct {
 	Password string
 	Phone    sql.NullString `gorm:"unique"`
 	Nickname string
+	// 生日，毫秒数，可能早于 1970 年，所以用 NULL 表示没有填
+	Birthday sql.NullInt64
+	AboutMe  string `gorm:"type:varchar(1024)"`
+	Avatar   string `gorm:"type:varchar(1024)"`
 
 	// 微信信息
 	WechatUnionId sql.NullString `gorm:"unique"`
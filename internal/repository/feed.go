@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository/dao"
+)
+
+type FeedRepository interface {
+	// Push 把文章推到这些用户的收件箱里面
+	Push(ctx context.Context, uids []int64, art domain.Article) error
+	// List 收件箱，按照 (utime, aid) 倒序，从 cursor 的下一条开始
+	List(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.FeedItem, error)
+	DeleteByAuthor(ctx context.Context, uid int64, author int64) error
+}
+
+type feedRepo struct {
+	dao dao.FeedDAO
+}
+
+func NewFeedRepo(dao dao.FeedDAO) FeedRepository {
+	return &feedRepo{dao: dao}
+}
+
+func (f *feedRepo) Push(ctx context.Context, uids []int64, art domain.Article) error {
+	return f.dao.BatchUpsert(ctx, slice.Map[int64, dao.FeedInbox](uids, func(idx int, uid int64) dao.FeedInbox {
+		return dao.FeedInbox{
+			Uid:      uid,
+			Aid:      art.Id,
+			AuthorId: art.Author.Id,
+			Utime:    art.Utime.UnixMilli(),
+		}
+	}))
+}
+
+func (f *feedRepo) List(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.FeedItem, error) {
+	var utime int64
+	if !cursor.IsZero() {
+		utime = cursor.Utime.UnixMilli()
+	}
+	res, err := f.dao.List(ctx, uid, utime, cursor.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.FeedInbox, domain.FeedItem](res, func(idx int, src dao.FeedInbox) domain.FeedItem {
+		return domain.FeedItem{
+			Uid:    src.Uid,
+			Aid:    src.Aid,
+			Author: src.AuthorId,
+			Utime:  time.UnixMilli(src.Utime),
+		}
+	}), nil
+}
+
+func (f *feedRepo) DeleteByAuthor(ctx context.Context, uid int64, author int64) error {
+	return f.dao.DeleteByAuthor(ctx, uid, author)
+}
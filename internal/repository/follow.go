@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository/dao"
+)
+
+type FollowRepository interface {
+	Follow(ctx context.Context, follower, followee int64) error
+	Unfollow(ctx context.Context, follower, followee int64) error
+	Followed(ctx context.Context, follower, followee int64) (bool, error)
+	ListFollowers(ctx context.Context, followee int64, maxId int64, limit int) ([]domain.FollowRelation, error)
+	ListFollowees(ctx context.Context, follower int64, maxId int64, limit int) ([]domain.FollowRelation, error)
+	// ListBigFollowees follower 关注的人里面，粉丝数不少于 minFollowers 的关注关系
+	ListBigFollowees(ctx context.Context, follower int64, minFollowers int64) ([]domain.FollowRelation, error)
+	GetStatics(ctx context.Context, uid int64) (domain.FollowStatics, error)
+}
+
+type followRepo struct {
+	dao dao.FollowRelationDAO
+}
+
+func NewFollowRepo(dao dao.FollowRelationDAO) FollowRepository {
+	return &followRepo{dao: dao}
+}
+
+func (f *followRepo) Follow(ctx context.Context, follower, followee int64) error {
+	return f.dao.Follow(ctx, dao.FollowRelation{
+		Follower: follower,
+		Followee: followee,
+	})
+}
+
+func (f *followRepo) Unfollow(ctx context.Context, follower, followee int64) error {
+	return f.dao.Unfollow(ctx, follower, followee)
+}
+
+func (f *followRepo) Followed(ctx context.Context, follower, followee int64) (bool, error) {
+	_, err := f.dao.FindRelation(ctx, follower, followee)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, dao.ErrFollowRelationNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (f *followRepo) ListFollowers(ctx context.Context, followee int64, maxId int64, limit int) ([]domain.FollowRelation, error) {
+	return f.toDomains(f.dao.ListFollowers(ctx, followee, maxId, limit))
+}
+
+func (f *followRepo) ListFollowees(ctx context.Context, follower int64, maxId int64, limit int) ([]domain.FollowRelation, error) {
+	return f.toDomains(f.dao.ListFollowees(ctx, follower, maxId, limit))
+}
+
+func (f *followRepo) ListBigFollowees(ctx context.Context, follower int64, minFollowers int64) ([]domain.FollowRelation, error) {
+	return f.toDomains(f.dao.ListBigFollowees(ctx, follower, minFollowers))
+}
+
+func (f *followRepo) GetStatics(ctx context.Context, uid int64) (domain.FollowStatics, error) {
+	s, err := f.dao.GetStatics(ctx, uid)
+	if err != nil {
+		return domain.FollowStatics{}, err
+	}
+	return domain.FollowStatics{
+		Uid:       uid,
+		Followers: s.Followers,
+		Followees: s.Followees,
+	}, nil
+}
+
+func (f *followRepo) toDomains(rs []dao.FollowRelation, err error) ([]domain.FollowRelation, error) {
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.FollowRelation, domain.FollowRelation](rs, func(idx int, src dao.FollowRelation) domain.FollowRelation {
+		return domain.FollowRelation{
+			Id:       src.Id,
+			Follower: src.Follower,
+			Followee: src.Followee,
+			Ctime:    time.UnixMilli(src.Ctime),
+		}
+	}), nil
+}
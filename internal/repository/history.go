@@ -1,7 +1,58 @@
 package repository
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository/dao"
+)
 
 type HistoryRecordRepository interface {
-	AddRecord(ctx context.Context)
+	AddRecord(ctx context.Context, r domain.HistoryRecord) error
+	List(ctx context.Context, uid int64, offset, limit int) ([]domain.HistoryRecord, error)
+	Delete(ctx context.Context, uid int64, biz string, bizId int64) error
+	Clear(ctx context.Context, uid int64) error
+}
+
+type historyRecordRepo struct {
+	dao dao.HistoryRecordDAO
+}
+
+func NewHistoryRecordRepo(dao dao.HistoryRecordDAO) HistoryRecordRepository {
+	return &historyRecordRepo{dao: dao}
+}
+
+func (h *historyRecordRepo) AddRecord(ctx context.Context, r domain.HistoryRecord) error {
+	return h.dao.Upsert(ctx, dao.HistoryRecord{
+		Uid:      r.Uid,
+		Biz:      r.Biz,
+		BizId:    r.BizId,
+		ReadTime: r.ReadTime.UnixMilli(),
+	})
+}
+
+func (h *historyRecordRepo) List(ctx context.Context, uid int64, offset, limit int) ([]domain.HistoryRecord, error) {
+	res, err := h.dao.ListByUid(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.HistoryRecord, domain.HistoryRecord](res, func(idx int, src dao.HistoryRecord) domain.HistoryRecord {
+		return domain.HistoryRecord{
+			Uid:      src.Uid,
+			Biz:      src.Biz,
+			BizId:    src.BizId,
+			ReadTime: time.UnixMilli(src.ReadTime),
+		}
+	}), nil
+}
+
+func (h *historyRecordRepo) Delete(ctx context.Context, uid int64, biz string, bizId int64) error {
+	return h.dao.Delete(ctx, uid, biz, bizId)
+}
+
+func (h *historyRecordRepo) Clear(ctx context.Context, uid int64) error {
+	return h.dao.DeleteByUid(ctx, uid)
 }
@@ -9,18 +9,35 @@ import (
 	"github.com/mrhelloboy/wehook/internal/domain"
 )
 
+var ErrNoJob = dao.ErrNoJob
+
 type JobRepository interface {
 	Preempt(ctx context.Context) (domain.Job, error)
 	Release(ctx context.Context, id int64) error
 	UpdateUtime(ctx context.Context, id int64) error
 	UpdateNextTime(ctx context.Context, id int64, next time.Time) error
 	Stop(ctx context.Context, id int64) error
+	Add(ctx context.Context, j domain.Job, next time.Time) error
 }
 
 type PreemptCronJobRepo struct {
 	dao dao.JobDAO
 }
 
+func NewPreemptCronJobRepo(dao dao.JobDAO) JobRepository {
+	return &PreemptCronJobRepo{dao: dao}
+}
+
+func (p *PreemptCronJobRepo) Add(ctx context.Context, j domain.Job, next time.Time) error {
+	return p.dao.Insert(ctx, dao.Job{
+		Name:     j.Name,
+		Executor: j.Executor,
+		Cfg:      j.Cfg,
+		Cron:     j.Cron,
+		NextTime: next.UnixMilli(),
+	})
+}
+
 func (p *PreemptCronJobRepo) Preempt(ctx context.Context) (domain.Job, error) {
 	j, err := p.dao.Preempt(ctx)
 	if err != nil {
@@ -31,6 +48,7 @@ func (p *PreemptCronJobRepo) Preempt(ctx context.Context) (domain.Job, error) {
 		Name:     j.Name,
 		Executor: j.Executor,
 		Cfg:      j.Cfg,
+		Cron:     j.Cron,
 	}, nil
 }
 
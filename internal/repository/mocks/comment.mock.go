@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/comment.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/comment.go -package=repomocks -destination=internal/repository/mocks/comment.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mrhelloboy/wehook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCommentRepository is a mock of CommentRepository interface.
+type MockCommentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentRepositoryMockRecorder
+}
+
+// MockCommentRepositoryMockRecorder is the mock recorder for MockCommentRepository.
+type MockCommentRepositoryMockRecorder struct {
+	mock *MockCommentRepository
+}
+
+// NewMockCommentRepository creates a new mock instance.
+func NewMockCommentRepository(ctrl *gomock.Controller) *MockCommentRepository {
+	mock := &MockCommentRepository{ctrl: ctrl}
+	mock.recorder = &MockCommentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentRepository) EXPECT() *MockCommentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCommentRepository) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, c)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentRepositoryMockRecorder) Create(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentRepository)(nil).Create), ctx, c)
+}
+
+// Delete mocks base method.
+func (m *MockCommentRepository) Delete(ctx context.Context, c domain.Comment) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, c)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentRepositoryMockRecorder) Delete(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentRepository)(nil).Delete), ctx, c)
+}
+
+// FindById mocks base method.
+func (m *MockCommentRepository) FindById(ctx context.Context, id int64) (domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockCommentRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCommentRepository)(nil).FindById), ctx, id)
+}
+
+// ListReplies mocks base method.
+func (m *MockCommentRepository) ListReplies(ctx context.Context, rootId, minId int64, limit int) ([]domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReplies", ctx, rootId, minId, limit)
+	ret0, _ := ret[0].([]domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReplies indicates an expected call of ListReplies.
+func (mr *MockCommentRepositoryMockRecorder) ListReplies(ctx, rootId, minId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReplies", reflect.TypeOf((*MockCommentRepository)(nil).ListReplies), ctx, rootId, minId, limit)
+}
+
+// ListRoots mocks base method.
+func (m *MockCommentRepository) ListRoots(ctx context.Context, biz string, bizId, maxId int64, limit int) ([]domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoots", ctx, biz, bizId, maxId, limit)
+	ret0, _ := ret[0].([]domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoots indicates an expected call of ListRoots.
+func (mr *MockCommentRepositoryMockRecorder) ListRoots(ctx, biz, bizId, maxId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoots", reflect.TypeOf((*MockCommentRepository)(nil).ListRoots), ctx, biz, bizId, maxId, limit)
+}
+
+// Pin mocks base method.
+func (m *MockCommentRepository) Pin(ctx context.Context, c domain.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pin", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pin indicates an expected call of Pin.
+func (mr *MockCommentRepositoryMockRecorder) Pin(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pin", reflect.TypeOf((*MockCommentRepository)(nil).Pin), ctx, c)
+}
+
+// Unpin mocks base method.
+func (m *MockCommentRepository) Unpin(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unpin", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unpin indicates an expected call of Unpin.
+func (mr *MockCommentRepositoryMockRecorder) Unpin(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unpin", reflect.TypeOf((*MockCommentRepository)(nil).Unpin), ctx, id)
+}
@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/feed.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/feed.go -package=repomocks -destination=internal/repository/mocks/feed.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mrhelloboy/wehook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeedRepository is a mock of FeedRepository interface.
+type MockFeedRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedRepositoryMockRecorder
+}
+
+// MockFeedRepositoryMockRecorder is the mock recorder for MockFeedRepository.
+type MockFeedRepositoryMockRecorder struct {
+	mock *MockFeedRepository
+}
+
+// NewMockFeedRepository creates a new mock instance.
+func NewMockFeedRepository(ctrl *gomock.Controller) *MockFeedRepository {
+	mock := &MockFeedRepository{ctrl: ctrl}
+	mock.recorder = &MockFeedRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedRepository) EXPECT() *MockFeedRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByAuthor mocks base method.
+func (m *MockFeedRepository) DeleteByAuthor(ctx context.Context, uid, author int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByAuthor", ctx, uid, author)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByAuthor indicates an expected call of DeleteByAuthor.
+func (mr *MockFeedRepositoryMockRecorder) DeleteByAuthor(ctx, uid, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByAuthor", reflect.TypeOf((*MockFeedRepository)(nil).DeleteByAuthor), ctx, uid, author)
+}
+
+// List mocks base method.
+func (m *MockFeedRepository) List(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.FeedItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.FeedItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockFeedRepositoryMockRecorder) List(ctx, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockFeedRepository)(nil).List), ctx, uid, cursor, limit)
+}
+
+// Push mocks base method.
+func (m *MockFeedRepository) Push(ctx context.Context, uids []int64, art domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Push", ctx, uids, art)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockFeedRepositoryMockRecorder) Push(ctx, uids, art any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockFeedRepository)(nil).Push), ctx, uids, art)
+}
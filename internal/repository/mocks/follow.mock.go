@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/follow.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/follow.go -package=repomocks -destination=internal/repository/mocks/follow.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mrhelloboy/wehook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFollowRepository is a mock of FollowRepository interface.
+type MockFollowRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFollowRepositoryMockRecorder
+}
+
+// MockFollowRepositoryMockRecorder is the mock recorder for MockFollowRepository.
+type MockFollowRepositoryMockRecorder struct {
+	mock *MockFollowRepository
+}
+
+// NewMockFollowRepository creates a new mock instance.
+func NewMockFollowRepository(ctrl *gomock.Controller) *MockFollowRepository {
+	mock := &MockFollowRepository{ctrl: ctrl}
+	mock.recorder = &MockFollowRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFollowRepository) EXPECT() *MockFollowRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Follow mocks base method.
+func (m *MockFollowRepository) Follow(ctx context.Context, follower, followee int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Follow", ctx, follower, followee)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Follow indicates an expected call of Follow.
+func (mr *MockFollowRepositoryMockRecorder) Follow(ctx, follower, followee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Follow", reflect.TypeOf((*MockFollowRepository)(nil).Follow), ctx, follower, followee)
+}
+
+// Followed mocks base method.
+func (m *MockFollowRepository) Followed(ctx context.Context, follower, followee int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Followed", ctx, follower, followee)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Followed indicates an expected call of Followed.
+func (mr *MockFollowRepositoryMockRecorder) Followed(ctx, follower, followee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Followed", reflect.TypeOf((*MockFollowRepository)(nil).Followed), ctx, follower, followee)
+}
+
+// GetStatics mocks base method.
+func (m *MockFollowRepository) GetStatics(ctx context.Context, uid int64) (domain.FollowStatics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatics", ctx, uid)
+	ret0, _ := ret[0].(domain.FollowStatics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatics indicates an expected call of GetStatics.
+func (mr *MockFollowRepositoryMockRecorder) GetStatics(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatics", reflect.TypeOf((*MockFollowRepository)(nil).GetStatics), ctx, uid)
+}
+
+// ListBigFollowees mocks base method.
+func (m *MockFollowRepository) ListBigFollowees(ctx context.Context, follower, minFollowers int64) ([]domain.FollowRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBigFollowees", ctx, follower, minFollowers)
+	ret0, _ := ret[0].([]domain.FollowRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBigFollowees indicates an expected call of ListBigFollowees.
+func (mr *MockFollowRepositoryMockRecorder) ListBigFollowees(ctx, follower, minFollowers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBigFollowees", reflect.TypeOf((*MockFollowRepository)(nil).ListBigFollowees), ctx, follower, minFollowers)
+}
+
+// ListFollowees mocks base method.
+func (m *MockFollowRepository) ListFollowees(ctx context.Context, follower, maxId int64, limit int) ([]domain.FollowRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowees", ctx, follower, maxId, limit)
+	ret0, _ := ret[0].([]domain.FollowRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowees indicates an expected call of ListFollowees.
+func (mr *MockFollowRepositoryMockRecorder) ListFollowees(ctx, follower, maxId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowees", reflect.TypeOf((*MockFollowRepository)(nil).ListFollowees), ctx, follower, maxId, limit)
+}
+
+// ListFollowers mocks base method.
+func (m *MockFollowRepository) ListFollowers(ctx context.Context, followee, maxId int64, limit int) ([]domain.FollowRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowers", ctx, followee, maxId, limit)
+	ret0, _ := ret[0].([]domain.FollowRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowers indicates an expected call of ListFollowers.
+func (mr *MockFollowRepositoryMockRecorder) ListFollowers(ctx, followee, maxId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowers", reflect.TypeOf((*MockFollowRepository)(nil).ListFollowers), ctx, followee, maxId, limit)
+}
+
+// Unfollow mocks base method.
+func (m *MockFollowRepository) Unfollow(ctx context.Context, follower, followee int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfollow", ctx, follower, followee)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unfollow indicates an expected call of Unfollow.
+func (mr *MockFollowRepositoryMockRecorder) Unfollow(ctx, follower, followee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfollow", reflect.TypeOf((*MockFollowRepository)(nil).Unfollow), ctx, follower, followee)
+}
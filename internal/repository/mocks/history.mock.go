@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/history.go -package=repomocks -destination=internal/repository/mocks/history.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mrhelloboy/wehook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHistoryRecordRepository is a mock of HistoryRecordRepository interface.
+type MockHistoryRecordRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryRecordRepositoryMockRecorder
+}
+
+// MockHistoryRecordRepositoryMockRecorder is the mock recorder for MockHistoryRecordRepository.
+type MockHistoryRecordRepositoryMockRecorder struct {
+	mock *MockHistoryRecordRepository
+}
+
+// NewMockHistoryRecordRepository creates a new mock instance.
+func NewMockHistoryRecordRepository(ctrl *gomock.Controller) *MockHistoryRecordRepository {
+	mock := &MockHistoryRecordRepository{ctrl: ctrl}
+	mock.recorder = &MockHistoryRecordRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryRecordRepository) EXPECT() *MockHistoryRecordRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddRecord mocks base method.
+func (m *MockHistoryRecordRepository) AddRecord(ctx context.Context, r domain.HistoryRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRecord", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRecord indicates an expected call of AddRecord.
+func (mr *MockHistoryRecordRepositoryMockRecorder) AddRecord(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRecord", reflect.TypeOf((*MockHistoryRecordRepository)(nil).AddRecord), ctx, r)
+}
+
+// Clear mocks base method.
+func (m *MockHistoryRecordRepository) Clear(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockHistoryRecordRepositoryMockRecorder) Clear(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockHistoryRecordRepository)(nil).Clear), ctx, uid)
+}
+
+// Delete mocks base method.
+func (m *MockHistoryRecordRepository) Delete(ctx context.Context, uid int64, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, uid, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockHistoryRecordRepositoryMockRecorder) Delete(ctx, uid, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHistoryRecordRepository)(nil).Delete), ctx, uid, biz, bizId)
+}
+
+// List mocks base method.
+func (m *MockHistoryRecordRepository) List(ctx context.Context, uid int64, offset, limit int) ([]domain.HistoryRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.HistoryRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockHistoryRecordRepositoryMockRecorder) List(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHistoryRecordRepository)(nil).List), ctx, uid, offset, limit)
+}
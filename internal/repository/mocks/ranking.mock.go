@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/ranking.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/ranking.go -package=repomocks -destination=internal/repository/mocks/ranking.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mrhelloboy/wehook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRankingRepository is a mock of RankingRepository interface.
+type MockRankingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRankingRepositoryMockRecorder
+}
+
+// MockRankingRepositoryMockRecorder is the mock recorder for MockRankingRepository.
+type MockRankingRepositoryMockRecorder struct {
+	mock *MockRankingRepository
+}
+
+// NewMockRankingRepository creates a new mock instance.
+func NewMockRankingRepository(ctrl *gomock.Controller) *MockRankingRepository {
+	mock := &MockRankingRepository{ctrl: ctrl}
+	mock.recorder = &MockRankingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRankingRepository) EXPECT() *MockRankingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetRealtimeTopN mocks base method.
+func (m *MockRankingRepository) GetRealtimeTopN(ctx context.Context, offset, limit int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRealtimeTopN", ctx, offset, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRealtimeTopN indicates an expected call of GetRealtimeTopN.
+func (mr *MockRankingRepositoryMockRecorder) GetRealtimeTopN(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRealtimeTopN", reflect.TypeOf((*MockRankingRepository)(nil).GetRealtimeTopN), ctx, offset, limit)
+}
+
+// GetTopN mocks base method.
+func (m *MockRankingRepository) GetTopN(ctx context.Context, name string) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopN", ctx, name)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopN indicates an expected call of GetTopN.
+func (mr *MockRankingRepositoryMockRecorder) GetTopN(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopN", reflect.TypeOf((*MockRankingRepository)(nil).GetTopN), ctx, name)
+}
+
+// IncrRealtimeScore mocks base method.
+func (m *MockRankingRepository) IncrRealtimeScore(ctx context.Context, aid int64, delta float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrRealtimeScore", ctx, aid, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrRealtimeScore indicates an expected call of IncrRealtimeScore.
+func (mr *MockRankingRepositoryMockRecorder) IncrRealtimeScore(ctx, aid, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrRealtimeScore", reflect.TypeOf((*MockRankingRepository)(nil).IncrRealtimeScore), ctx, aid, delta)
+}
+
+// ReplaceTopN mocks base method.
+func (m *MockRankingRepository) ReplaceTopN(ctx context.Context, name string, arts []domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTopN", ctx, name, arts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTopN indicates an expected call of ReplaceTopN.
+func (mr *MockRankingRepositoryMockRecorder) ReplaceTopN(ctx, name, arts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTopN", reflect.TypeOf((*MockRankingRepository)(nil).ReplaceTopN), ctx, name, arts)
+}
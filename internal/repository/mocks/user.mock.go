@@ -113,3 +113,59 @@ func (mr *MockUserRepositoryMockRecorder) FindByWechat(ctx, openID any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWechat", reflect.TypeOf((*MockUserRepository)(nil).FindByWechat), ctx, openID)
 }
+
+// UpdateEmail mocks base method.
+func (m *MockUserRepository) UpdateEmail(ctx context.Context, id int64, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmail", ctx, id, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmail indicates an expected call of UpdateEmail.
+func (mr *MockUserRepositoryMockRecorder) UpdateEmail(ctx, id, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmail", reflect.TypeOf((*MockUserRepository)(nil).UpdateEmail), ctx, id, email)
+}
+
+// UpdateNonSensitiveInfo mocks base method.
+func (m *MockUserRepository) UpdateNonSensitiveInfo(ctx context.Context, u domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNonSensitiveInfo", ctx, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNonSensitiveInfo indicates an expected call of UpdateNonSensitiveInfo.
+func (mr *MockUserRepositoryMockRecorder) UpdateNonSensitiveInfo(ctx, u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNonSensitiveInfo", reflect.TypeOf((*MockUserRepository)(nil).UpdateNonSensitiveInfo), ctx, u)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, id int64, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, id, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRepositoryMockRecorder) UpdatePassword(ctx, id, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepository)(nil).UpdatePassword), ctx, id, password)
+}
+
+// UpdatePhone mocks base method.
+func (m *MockUserRepository) UpdatePhone(ctx context.Context, id int64, phone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePhone", ctx, id, phone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePhone indicates an expected call of UpdatePhone.
+func (mr *MockUserRepositoryMockRecorder) UpdatePhone(ctx, id, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePhone", reflect.TypeOf((*MockUserRepository)(nil).UpdatePhone), ctx, id, phone)
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository/dao"
+)
+
+type NotificationRepository interface {
+	Add(ctx context.Context, n domain.Notification) error
+	List(ctx context.Context, uid int64, offset, limit int) ([]domain.Notification, error)
+	MarkRead(ctx context.Context, uid int64, ids []int64) error
+	MarkAllRead(ctx context.Context, uid int64) error
+	CountUnread(ctx context.Context, uid int64) (int64, error)
+}
+
+type notificationRepo struct {
+	dao dao.NotificationDAO
+}
+
+func NewNotificationRepo(dao dao.NotificationDAO) NotificationRepository {
+	return &notificationRepo{dao: dao}
+}
+
+func (n *notificationRepo) Add(ctx context.Context, ntf domain.Notification) error {
+	return n.dao.Upsert(ctx, dao.Notification{
+		Uid:       ntf.Uid,
+		Type:      ntf.Type.ToUint8(),
+		Biz:       ntf.Biz,
+		BizId:     ntf.BizId,
+		LastActor: ntf.LastActor,
+	})
+}
+
+func (n *notificationRepo) List(ctx context.Context, uid int64, offset, limit int) ([]domain.Notification, error) {
+	res, err := n.dao.List(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Notification, domain.Notification](res, func(idx int, src dao.Notification) domain.Notification {
+		return domain.Notification{
+			Id:        src.Id,
+			Uid:       src.Uid,
+			Type:      domain.NotificationType(src.Type),
+			Biz:       src.Biz,
+			BizId:     src.BizId,
+			LastActor: src.LastActor,
+			ActorCnt:  src.ActorCnt,
+			Read:      src.ReadAt > 0,
+			Utime:     time.UnixMilli(src.Utime),
+		}
+	}), nil
+}
+
+func (n *notificationRepo) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	return n.dao.MarkRead(ctx, uid, ids)
+}
+
+func (n *notificationRepo) MarkAllRead(ctx context.Context, uid int64) error {
+	return n.dao.MarkAllRead(ctx, uid)
+}
+
+func (n *notificationRepo) CountUnread(ctx context.Context, uid int64) (int64, error) {
+	return n.dao.CountUnread(ctx, uid)
+}
@@ -9,40 +9,55 @@ import (
 )
 
 type RankingRepository interface {
-	ReplaceTopN(ctx context.Context, arts []domain.Article) error
-	GetTopN(ctx context.Context) ([]domain.Article, error)
+	ReplaceTopN(ctx context.Context, name string, arts []domain.Article) error
+	GetTopN(ctx context.Context, name string) ([]domain.Article, error)
+	// IncrRealtimeScore 增加文章在实时榜单上的分数
+	IncrRealtimeScore(ctx context.Context, aid int64, delta float64) error
+	// GetRealtimeTopN 实时榜单上的文章 ID
+	GetRealtimeTopN(ctx context.Context, offset, limit int) ([]int64, error)
 }
 
 type CachedRankingRepo struct {
-	redis *cache.RankingRedisCache
-	local *cache.RankingLocalCache
+	redis    *cache.RankingRedisCache
+	local    *cache.RankingLocalCache
+	realtime cache.RankingRealtimeCache
 }
 
-func (c *CachedRankingRepo) ReplaceTopN(ctx context.Context, arts []domain.Article) error {
+func (c *CachedRankingRepo) IncrRealtimeScore(ctx context.Context, aid int64, delta float64) error {
+	return c.realtime.IncrScore(ctx, aid, delta)
+}
+
+func (c *CachedRankingRepo) GetRealtimeTopN(ctx context.Context, offset, limit int) ([]int64, error) {
+	return c.realtime.TopN(ctx, offset, limit)
+}
+
+func (c *CachedRankingRepo) ReplaceTopN(ctx context.Context, name string, arts []domain.Article) error {
 	// 先放入本地缓存，再放入redis缓存
-	_ = c.local.Set(ctx, arts)
-	return c.redis.Set(ctx, arts)
+	_ = c.local.Set(ctx, name, arts)
+	return c.redis.Set(ctx, name, arts)
 }
 
-func (c *CachedRankingRepo) GetTopN(ctx context.Context) ([]domain.Article, error) {
-	data, err := c.local.Get(ctx)
+func (c *CachedRankingRepo) GetTopN(ctx context.Context, name string) ([]domain.Article, error) {
+	data, err := c.local.Get(ctx, name)
 	if err == nil {
 		return data, nil
 	}
-	data, err = c.redis.Get(ctx)
+	data, err = c.redis.Get(ctx, name)
 	if err == nil {
-		_ = c.local.Set(ctx, data)
+		_ = c.local.Set(ctx, name, data)
 	} else {
 		// redis缓存出错，从强制从本地缓存获取（不保证数据准确）
 		// 为了应对redis异常时的保护措施
-		return c.local.ForceGet(ctx)
+		return c.local.ForceGet(ctx, name)
 	}
 	return data, err
 }
 
-func NewCachedRankingRepo(redis *cache.RankingRedisCache, local *cache.RankingLocalCache) RankingRepository {
+func NewCachedRankingRepo(redis *cache.RankingRedisCache, local *cache.RankingLocalCache,
+	realtime cache.RankingRealtimeCache) RankingRepository {
 	return &CachedRankingRepo{
-		redis: redis,
-		local: local,
+		redis:    redis,
+		local:    local,
+		realtime: realtime,
 	}
 }
@@ -0,0 +1,78 @@
+package search
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository/dao"
+)
+
+var _ ArticleIndex = (*DBArticleIndex)(nil)
+
+// DBArticleIndex 索引放在数据库的一张表里面，所有实例共用，多实例部署的时候用它。
+// 关键字用 LIKE 匹配，数据量大了之后要换成 Elasticsearch 之类的搜索引擎。
+// 搜索结果里面只有列表需要的字段，没有 Content
+type DBArticleIndex struct {
+	dao dao.ArticleSearchDAO
+}
+
+func NewDBArticleIndex(dao dao.ArticleSearchDAO) *DBArticleIndex {
+	return &DBArticleIndex{dao: dao}
+}
+
+func (d *DBArticleIndex) Upsert(ctx context.Context, index string, art domain.Article) error {
+	return d.dao.Upsert(ctx, dao.ArticleSearchDoc{
+		Idx:       index,
+		ArticleId: art.Id,
+		AuthorId:  art.Author.Id,
+		Status:    art.Status.ToUint8(),
+		Title:     art.Title,
+		Abstract:  art.Abstract,
+		Text:      strings.ToLower(art.Title + "\n" + art.Content),
+		Ctime:     art.Ctime.UnixMilli(),
+		Utime:     art.Utime.UnixMilli(),
+	})
+}
+
+func (d *DBArticleIndex) Delete(ctx context.Context, index string, id int64) error {
+	return d.dao.Delete(ctx, index, id)
+}
+
+func (d *DBArticleIndex) Search(ctx context.Context, index string, q domain.ArticleSearchQuery) ([]domain.Article, error) {
+	cond := dao.ArticleSearchCond{
+		Terms:    strings.Fields(strings.ToLower(q.Keyword)),
+		AuthorId: q.AuthorId,
+		Status:   q.Status.ToUint8(),
+	}
+	if !q.Start.IsZero() {
+		cond.Start = q.Start.UnixMilli()
+	}
+	if !q.End.IsZero() {
+		cond.End = q.End.UnixMilli()
+	}
+	docs, err := d.dao.Search(ctx, index, cond, q.Offset, q.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(docs, func(idx int, src dao.ArticleSearchDoc) domain.Article {
+		return domain.Article{
+			Id:       src.ArticleId,
+			Title:    src.Title,
+			Abstract: src.Abstract,
+			Author:   domain.Author{Id: src.AuthorId},
+			Status:   domain.ArticleStatus(src.Status),
+			Ctime:    time.UnixMilli(src.Ctime),
+			Utime:    time.UnixMilli(src.Utime),
+		}
+	}), nil
+}
+
+// Empty 索引里面还没有文章，第一次部署的时候要从数据库全量加载一遍
+func (d *DBArticleIndex) Empty(ctx context.Context) (bool, error) {
+	cnt, err := d.dao.Count(ctx, IndexAuthor)
+	return cnt == 0, err
+}
@@ -0,0 +1,74 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository/dao"
+	daomocks "github.com/mrhelloboy/wehook/internal/repository/dao/mocks"
+)
+
+func TestDBArticleIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	base := time.UnixMilli(1700000000000)
+	d := daomocks.NewMockArticleSearchDAO(ctrl)
+	// 存小写的标题和内容，用来忽略大小写匹配
+	d.EXPECT().Upsert(gomock.Any(), dao.ArticleSearchDoc{
+		Idx:       IndexPublished,
+		ArticleId: 1,
+		AuthorId:  2,
+		Status:    domain.ArticleStatusPublished.ToUint8(),
+		Title:     "Go 并发编程",
+		Abstract:  "摘要",
+		Text:      "go 并发编程\ngoroutine 和 channel",
+		Ctime:     base.UnixMilli(),
+		Utime:     base.UnixMilli(),
+	}).Return(nil)
+	d.EXPECT().Search(gomock.Any(), IndexPublished, dao.ArticleSearchCond{
+		Terms:    []string{"go", "channel"},
+		AuthorId: 2,
+		Status:   domain.ArticleStatusPublished.ToUint8(),
+		End:      base.Add(time.Hour).UnixMilli(),
+	}, 0, 10).Return([]dao.ArticleSearchDoc{
+		{ArticleId: 1, AuthorId: 2, Status: domain.ArticleStatusPublished.ToUint8(),
+			Title: "Go 并发编程", Abstract: "摘要", Ctime: base.UnixMilli(), Utime: base.UnixMilli()},
+	}, nil)
+
+	idx := NewDBArticleIndex(d)
+	err := idx.Upsert(context.Background(), IndexPublished, domain.Article{
+		Id:       1,
+		Title:    "Go 并发编程",
+		Content:  "goroutine 和 Channel",
+		Abstract: "摘要",
+		Author:   domain.Author{Id: 2},
+		Status:   domain.ArticleStatusPublished,
+		Ctime:    base,
+		Utime:    base,
+	})
+	require.NoError(t, err)
+	arts, err := idx.Search(context.Background(), IndexPublished, domain.ArticleSearchQuery{
+		Keyword:  " GO  channel ",
+		AuthorId: 2,
+		Status:   domain.ArticleStatusPublished,
+		End:      base.Add(time.Hour),
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Article{{
+		Id:       1,
+		Title:    "Go 并发编程",
+		Abstract: "摘要",
+		Author:   domain.Author{Id: 2},
+		Status:   domain.ArticleStatusPublished,
+		Ctime:    base,
+		Utime:    base,
+	}}, arts)
+}
@@ -0,0 +1,105 @@
+package search
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+)
+
+var _ ArticleIndex = (*MemoryArticleIndex)(nil)
+
+// MemoryArticleIndex 进程内的索引，不依赖外部的搜索集群。
+// 搜索的时候逐篇匹配，适合数据量不大或者本地开发的场景。
+// 注意：只能单实例部署。索引只在启动的时候全量加载，之后只有本实例处理的写请求会更新它，
+// 多实例部署的时候其它实例上的修改、发表、删除都不会同步过来，搜索结果会不一致。
+// 要多实例部署就用 DBArticleIndex 这种所有实例共用的索引。
+type MemoryArticleIndex struct {
+	mu      sync.RWMutex
+	indexes map[string]map[int64]memoryDoc
+}
+
+type memoryDoc struct {
+	art domain.Article
+	// 小写的标题和内容，用于忽略大小写匹配
+	text string
+}
+
+func NewMemoryArticleIndex() *MemoryArticleIndex {
+	return &MemoryArticleIndex{
+		indexes: make(map[string]map[int64]memoryDoc),
+	}
+}
+
+func (m *MemoryArticleIndex) Upsert(ctx context.Context, index string, art domain.Article) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	docs, ok := m.indexes[index]
+	if !ok {
+		docs = make(map[int64]memoryDoc)
+		m.indexes[index] = docs
+	}
+	docs[art.Id] = memoryDoc{
+		art:  art,
+		text: strings.ToLower(art.Title + "\n" + art.Content),
+	}
+	return nil
+}
+
+func (m *MemoryArticleIndex) Delete(ctx context.Context, index string, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.indexes[index], id)
+	return nil
+}
+
+func (m *MemoryArticleIndex) Search(ctx context.Context, index string, q domain.ArticleSearchQuery) ([]domain.Article, error) {
+	terms := strings.Fields(strings.ToLower(q.Keyword))
+	m.mu.RLock()
+	res := make([]domain.Article, 0, 16)
+	for _, doc := range m.indexes[index] {
+		if m.match(doc, terms, q) {
+			res = append(res, doc.art)
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Utime.Equal(res[j].Utime) {
+			return res[i].Id > res[j].Id
+		}
+		return res[i].Utime.After(res[j].Utime)
+	})
+	if q.Offset >= len(res) {
+		return []domain.Article{}, nil
+	}
+	end := len(res)
+	if q.Limit > 0 && q.Offset+q.Limit < end {
+		end = q.Offset + q.Limit
+	}
+	return res[q.Offset:end], nil
+}
+
+func (m *MemoryArticleIndex) match(doc memoryDoc, terms []string, q domain.ArticleSearchQuery) bool {
+	art := doc.art
+	if q.AuthorId > 0 && art.Author.Id != q.AuthorId {
+		return false
+	}
+	if q.Status != domain.ArticleStatusUnknown && art.Status != q.Status {
+		return false
+	}
+	if !q.Start.IsZero() && art.Utime.Before(q.Start) {
+		return false
+	}
+	if !q.End.IsZero() && !art.Utime.Before(q.End) {
+		return false
+	}
+	for _, term := range terms {
+		if !strings.Contains(doc.text, term) {
+			return false
+		}
+	}
+	return true
+}
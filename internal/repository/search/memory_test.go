@@ -0,0 +1,102 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+)
+
+func TestMemoryArticleIndex_Search(t *testing.T) {
+	base := time.UnixMilli(1700000000000)
+	arts := []domain.Article{
+		{Id: 1, Title: "Go 并发编程", Content: "goroutine 和 channel", Author: domain.Author{Id: 1},
+			Status: domain.ArticleStatusPublished, Utime: base},
+		{Id: 2, Title: "Redis 缓存", Content: "缓存穿透 GO 客户端", Author: domain.Author{Id: 1},
+			Status: domain.ArticleStatusUnpublished, Utime: base.Add(time.Minute)},
+		{Id: 3, Title: "MySQL 索引", Content: "B+ 树", Author: domain.Author{Id: 2},
+			Status: domain.ArticleStatusPublished, Utime: base.Add(2 * time.Minute)},
+	}
+	idx := NewMemoryArticleIndex()
+	for _, art := range arts {
+		require.NoError(t, idx.Upsert(context.Background(), IndexAuthor, art))
+	}
+
+	testCases := []struct {
+		name    string
+		q       domain.ArticleSearchQuery
+		wantIds []int64
+	}{
+		{
+			name:    "不过滤，按更新时间倒序",
+			q:       domain.ArticleSearchQuery{Limit: 10},
+			wantIds: []int64{3, 2, 1},
+		},
+		{
+			name:    "关键字忽略大小写，标题或内容命中",
+			q:       domain.ArticleSearchQuery{Keyword: "go", Limit: 10},
+			wantIds: []int64{2, 1},
+		},
+		{
+			name:    "多个关键字都要命中",
+			q:       domain.ArticleSearchQuery{Keyword: "go channel", Limit: 10},
+			wantIds: []int64{1},
+		},
+		{
+			name:    "按作者和状态过滤",
+			q:       domain.ArticleSearchQuery{AuthorId: 1, Status: domain.ArticleStatusPublished, Limit: 10},
+			wantIds: []int64{1},
+		},
+		{
+			name: "时间范围左闭右开",
+			q: domain.ArticleSearchQuery{Start: base.Add(time.Minute),
+				End: base.Add(2 * time.Minute), Limit: 10},
+			wantIds: []int64{2},
+		},
+		{
+			name:    "分页",
+			q:       domain.ArticleSearchQuery{Offset: 1, Limit: 1},
+			wantIds: []int64{2},
+		},
+		{
+			name:    "超出范围",
+			q:       domain.ArticleSearchQuery{Offset: 3, Limit: 1},
+			wantIds: []int64{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := idx.Search(context.Background(), IndexAuthor, tc.q)
+			require.NoError(t, err)
+			ids := make([]int64, 0, len(res))
+			for _, art := range res {
+				ids = append(ids, art.Id)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+		})
+	}
+}
+
+func TestMemoryArticleIndex_UpsertDelete(t *testing.T) {
+	idx := NewMemoryArticleIndex()
+	ctx := context.Background()
+	require.NoError(t, idx.Upsert(ctx, IndexPublished, domain.Article{Id: 1, Title: "旧标题"}))
+	require.NoError(t, idx.Upsert(ctx, IndexPublished, domain.Article{Id: 1, Title: "新标题"}))
+
+	res, err := idx.Search(ctx, IndexPublished, domain.ArticleSearchQuery{Keyword: "旧", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, res)
+	// 不同的索引互不影响
+	res, err = idx.Search(ctx, IndexAuthor, domain.ArticleSearchQuery{Keyword: "新", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	require.NoError(t, idx.Delete(ctx, IndexPublished, 1))
+	res, err = idx.Search(ctx, IndexPublished, domain.ArticleSearchQuery{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
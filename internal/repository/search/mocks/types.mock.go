@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/search/types.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/search/types.go -package=searchmocks -destination=internal/repository/search/mocks/types.mock.go
+//
+
+// Package searchmocks is a generated GoMock package.
+package searchmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mrhelloboy/wehook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleIndex is a mock of ArticleIndex interface.
+type MockArticleIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleIndexMockRecorder
+}
+
+// MockArticleIndexMockRecorder is the mock recorder for MockArticleIndex.
+type MockArticleIndexMockRecorder struct {
+	mock *MockArticleIndex
+}
+
+// NewMockArticleIndex creates a new mock instance.
+func NewMockArticleIndex(ctrl *gomock.Controller) *MockArticleIndex {
+	mock := &MockArticleIndex{ctrl: ctrl}
+	mock.recorder = &MockArticleIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleIndex) EXPECT() *MockArticleIndexMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockArticleIndex) Delete(ctx context.Context, index string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, index, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleIndexMockRecorder) Delete(ctx, index, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleIndex)(nil).Delete), ctx, index, id)
+}
+
+// Search mocks base method.
+func (m *MockArticleIndex) Search(ctx context.Context, index string, q domain.ArticleSearchQuery) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, index, q)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockArticleIndexMockRecorder) Search(ctx, index, q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockArticleIndex)(nil).Search), ctx, index, q)
+}
+
+// Upsert mocks base method.
+func (m *MockArticleIndex) Upsert(ctx context.Context, index string, art domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, index, art)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockArticleIndexMockRecorder) Upsert(ctx, index, art any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockArticleIndex)(nil).Upsert), ctx, index, art)
+}
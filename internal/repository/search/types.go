@@ -0,0 +1,28 @@
+package search
+
+import (
+	"context"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+)
+
+const (
+	// IndexAuthor 制作库的索引，作者搜索自己的文章
+	IndexAuthor = "article_author"
+	// IndexPublished 线上库的索引，读者搜索已发表的文章
+	IndexPublished = "article_published"
+)
+
+// ArticleIndex 文章搜索索引
+// 索引只是数据库的一个副本，以数据库为准，写索引失败不影响主流程。
+// 要接入 Elasticsearch 之类的搜索引擎，实现这个接口即可。
+//
+//go:generate mockgen -source=types.go -package=searchmocks -destination=mocks/types.mock.go ArticleIndex
+type ArticleIndex interface {
+	// Upsert 新建或者覆盖 index 中的文章
+	Upsert(ctx context.Context, index string, art domain.Article) error
+	Delete(ctx context.Context, index string, id int64) error
+	// Search 按 Keyword、AuthorId、Status、时间范围过滤，
+	// 按更新时间倒序分页返回，不处理 SortBy
+	Search(ctx context.Context, index string, q domain.ArticleSearchQuery) ([]domain.Article, error)
+}
@@ -22,6 +22,12 @@ type UserRepository interface {
 	Create(ctx context.Context, u domain.User) error
 	FindById(ctx context.Context, id int64) (domain.User, error)
 	FindByWechat(ctx context.Context, openID string) (domain.User, error)
+	// UpdateNonSensitiveInfo 更新昵称、生日、简介和头像
+	UpdateNonSensitiveInfo(ctx context.Context, u domain.User) error
+	UpdatePhone(ctx context.Context, id int64, phone string) error
+	UpdateEmail(ctx context.Context, id int64, email string) error
+	// UpdatePassword password 是加密之后的
+	UpdatePassword(ctx context.Context, id int64, password string) error
 }
 
 type CachedUserRepository struct {
@@ -113,12 +119,48 @@ func (r *CachedUserRepository) FindByWechat(ctx context.Context, openID string)
 	return r.entityToDomain(user), nil
 }
 
+// UpdateNonSensitiveInfo 更新之后删除缓存，下一次 FindById 再从数据库加载，下面几个也一样
+func (r *CachedUserRepository) UpdateNonSensitiveInfo(ctx context.Context, u domain.User) error {
+	if err := r.dao.UpdateNonSensitiveInfo(ctx, r.domainToEntity(u)); err != nil {
+		return err
+	}
+	return r.cache.Del(ctx, u.Id)
+}
+
+func (r *CachedUserRepository) UpdatePhone(ctx context.Context, id int64, phone string) error {
+	if err := r.dao.UpdatePhone(ctx, id, phone); err != nil {
+		return err
+	}
+	return r.cache.Del(ctx, id)
+}
+
+func (r *CachedUserRepository) UpdateEmail(ctx context.Context, id int64, email string) error {
+	if err := r.dao.UpdateEmail(ctx, id, email); err != nil {
+		return err
+	}
+	return r.cache.Del(ctx, id)
+}
+
+func (r *CachedUserRepository) UpdatePassword(ctx context.Context, id int64, password string) error {
+	if err := r.dao.UpdatePassword(ctx, id, password); err != nil {
+		return err
+	}
+	return r.cache.Del(ctx, id)
+}
+
 func (r *CachedUserRepository) domainToEntity(u domain.User) dao.User {
+	var birthday sql.NullInt64
+	if !u.Birthday.IsZero() {
+		birthday = sql.NullInt64{Int64: u.Birthday.UnixMilli(), Valid: true}
+	}
 	return dao.User{
 		Id:            u.Id,
 		Email:         sql.NullString{String: u.Email, Valid: u.Email != ""},
 		Password:      u.Password,
 		Nickname:      u.Nickname,
+		Birthday:      birthday,
+		AboutMe:       u.AboutMe,
+		Avatar:        u.Avatar,
 		Phone:         sql.NullString{String: u.Phone, Valid: u.Phone != ""},
 		WechatOpenId:  sql.NullString{String: u.WechatInfo.OpenID, Valid: u.WechatInfo.OpenID != ""},
 		WechatUnionId: sql.NullString{String: u.WechatInfo.UnionID, Valid: u.WechatInfo.UnionID != ""},
@@ -127,12 +169,19 @@ func (r *CachedUserRepository) domainToEntity(u domain.User) dao.User {
 }
 
 func (r *CachedUserRepository) entityToDomain(u dao.User) domain.User {
+	var birthday time.Time
+	if u.Birthday.Valid {
+		birthday = time.UnixMilli(u.Birthday.Int64)
+	}
 	return domain.User{
 		Id:       u.Id,
 		Email:    u.Email.String,
 		Password: u.Password,
 		Phone:    u.Phone.String,
 		Nickname: u.Nickname,
+		Birthday: birthday,
+		AboutMe:  u.AboutMe,
+		Avatar:   u.Avatar,
 		WechatInfo: domain.WechatInfo{
 			OpenID:  u.WechatOpenId.String,
 			UnionID: u.WechatUnionId.String,
@@ -140,3 +140,63 @@ func TestCachedUserRepository_FindById(t *testing.T) {
 		})
 	}
 }
+
+func TestCachedUserRepository_UpdateNonSensitiveInfo(t *testing.T) {
+	birthday := time.Date(1990, 1, 1, 0, 0, 0, 0, time.Local)
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (dao.UserDAO, cache.UserCache)
+		user    domain.User
+		wantErr error
+	}{
+		{
+			name: "更新成功，删除缓存",
+			mock: func(ctrl *gomock.Controller) (dao.UserDAO, cache.UserCache) {
+				mud := daomocks.NewMockUserDAO(ctrl)
+				mud.EXPECT().UpdateNonSensitiveInfo(gomock.Any(), dao.User{
+					Id:       123,
+					Nickname: "test",
+					Birthday: sql.NullInt64{Int64: birthday.UnixMilli(), Valid: true},
+					AboutMe:  "hello",
+					Avatar:   "avatar/123.png",
+					Ctime:    time.Time{}.UnixMilli(),
+				}).Return(nil)
+				muc := cachemocks.NewMockUserCache(ctrl)
+				muc.EXPECT().Del(gomock.Any(), int64(123)).Return(nil)
+				return mud, muc
+			},
+			user: domain.User{
+				Id:       123,
+				Nickname: "test",
+				Birthday: birthday,
+				AboutMe:  "hello",
+				Avatar:   "avatar/123.png",
+			},
+		},
+		{
+			name: "没有填生日，更新失败不删除缓存",
+			mock: func(ctrl *gomock.Controller) (dao.UserDAO, cache.UserCache) {
+				mud := daomocks.NewMockUserDAO(ctrl)
+				mud.EXPECT().UpdateNonSensitiveInfo(gomock.Any(), dao.User{
+					Id:       123,
+					Nickname: "test",
+					Ctime:    time.Time{}.UnixMilli(),
+				}).Return(errors.New("数据库返回错误"))
+				return mud, cachemocks.NewMockUserCache(ctrl)
+			},
+			user:    domain.User{Id: 123, Nickname: "test"},
+			wantErr: errors.New("数据库返回错误"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ud, uc := tc.mock(ctrl)
+			cuRepo := NewUserRepository(ud, uc)
+			err := cuRepo.UpdateNonSensitiveInfo(context.Background(), tc.user)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
+	"github.com/pmezard/go-difflib/difflib"
+
 	events "github.com/mrhelloboy/wehook/internal/events/article"
 
 	"github.com/mrhelloboy/wehook/internal/domain"
@@ -16,17 +20,41 @@ type ArticleService interface {
 	Save(ctx context.Context, art domain.Article) (int64, error)
 	Publish(ctx context.Context, art domain.Article) (int64, error)
 	Withdraw(ctx context.Context, art domain.Article) error
-	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
-	ListPub(ctx context.Context, start time.Time, offset, limit int) ([]domain.Article, error)
+	// List 作者自己的文章，新的在前，下一页从 cursor 开始
+	List(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	// ListPub 线上库里公开发表的文章，新的在前，下一页从 cursor 开始
+	ListPub(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
 	GetById(ctx context.Context, id int64) (domain.Article, error)
 	GetPublishedById(ctx context.Context, id int64, uid int64) (domain.Article, error)
+	// ListRevisions 作者查看自己文章的历史版本
+	ListRevisions(ctx context.Context, artId int64, uid int64, offset, limit int) ([]domain.ArticleRevision, error)
+	// DiffRevisions 比较同一篇文章的两个版本
+	DiffRevisions(ctx context.Context, uid int64, from, to int64) (domain.ArticleRevisionDiff, error)
+	// RestoreRevision 把某个版本恢复成当前的草稿，返回文章 ID
+	RestoreRevision(ctx context.Context, uid int64, id int64) (int64, error)
+	// ListPubByTag 按照标签分页获取公开发表的文章，新的在前
+	ListPubByTag(ctx context.Context, tag string, offset, limit int) ([]domain.Article, error)
+	// ListPubByCategory 按照分类分页获取公开发表的文章，新的在前
+	ListPubByCategory(ctx context.Context, category string, offset, limit int) ([]domain.Article, error)
+	// CountPubTags 标签和对应的文章数，prefix 不为空的时候用于输入标签时的自动补全
+	CountPubTags(ctx context.Context, prefix string, limit int) ([]domain.TagCount, error)
+	// ListPubByAuthor 某个作者公开发表的文章，新的在前，下一页从 cursor 开始
+	ListPubByAuthor(ctx context.Context, author int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	// Timeline 全站公开发表的文章，新的在前，下一页从 cursor 开始
+	Timeline(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
 }
 
+// ErrRevisionNotFound 版本不存在，或者不是这个作者的
+var ErrRevisionNotFound = article.ErrRevisionNotFound
+
 type articleSvc struct {
 	authorRepo article.AuthorRepository
 	l          logger.Logger
 	producer   events.Producer
 	ch         chan readInfo // 批量方式
+	// stop 关闭后不再接收新的阅读事件，finished 关闭表示剩下的事件都已经发出去了
+	stop     chan struct{}
+	finished chan struct{}
 }
 
 type readInfo struct {
@@ -43,57 +71,117 @@ func NewArticleSvc(authorRepo article.AuthorRepository, l logger.Logger, produce
 }
 
 // NewArticleSvcV1 通过批量方式发送阅读事件
-func NewArticleSvcV1(authorRepo article.AuthorRepository, l logger.Logger, producer events.Producer) ArticleService {
-	ch := make(chan readInfo, 10)
-	go func() {
-		for {
-			uids := make([]int64, 0, 10)
-			aids := make([]int64, 0, 10)
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-			for i := 0; i < 10; i++ {
+// 攒够 batchSize 个或者等了 interval 就发送一批。
+// 返回的函数用于关闭服务，会把还没发出去的阅读事件发送完再返回
+func NewArticleSvcV1(authorRepo article.AuthorRepository, l logger.Logger, producer events.Producer) (ArticleService, func()) {
+	svc := &articleSvc{
+		authorRepo: authorRepo,
+		producer:   producer,
+		l:          l,
+		ch:         make(chan readInfo, 100),
+		stop:       make(chan struct{}),
+		finished:   make(chan struct{}),
+	}
+	go svc.batchProduceReadEvents(10, time.Second)
+	var once sync.Once
+	return svc, func() {
+		once.Do(func() {
+			close(svc.stop)
+			<-svc.finished
+		})
+	}
+}
+
+func (a *articleSvc) batchProduceReadEvents(batchSize int, interval time.Duration) {
+	defer close(a.finished)
+	for {
+		uids := make([]int64, 0, batchSize)
+		aids := make([]int64, 0, batchSize)
+		stopped := false
+		timer := time.NewTimer(interval)
+	batch:
+		for len(aids) < batchSize {
+			select {
+			case info := <-a.ch:
+				uids = append(uids, info.uid)
+				aids = append(aids, info.aid)
+			case <-timer.C:
+				break batch
+			case <-a.stop:
+				stopped = true
+				break batch
+			}
+		}
+		timer.Stop()
+		if stopped {
+			// 把 ch 里面剩下的也一起发出去
+		drain:
+			for {
 				select {
-				case info, ok := <-ch:
-					// !ok 表示 ch 已经关闭了
-					if !ok {
-						cancel()
-						return
-					}
+				case info := <-a.ch:
 					uids = append(uids, info.uid)
 					aids = append(aids, info.aid)
-				case <-ctx.Done():
-					break
+				default:
+					break drain
 				}
 			}
-			cancel()
-			ctx, cancel = context.WithTimeout(context.Background(), time.Second)
-			producer.ProduceReadEventV1(ctx, events.ReadEventV1{
+		}
+		if len(aids) > 0 {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			err := a.producer.ProduceReadEventV1(ctx, events.ReadEventV1{
 				Uids: uids,
 				Aids: aids,
 			})
 			cancel()
+			if err != nil {
+				a.l.Error("批量发送读者阅读事件失败", logger.Int64("cnt", int64(len(aids))), logger.Error(err))
+			}
+		}
+		if stopped {
+			return
 		}
-	}()
-	return &articleSvc{
-		authorRepo: authorRepo,
-		producer:   producer,
-		l:          l,
-		ch:         ch,
 	}
 }
 
 // ListPub 获取作者发布的文章列表
-func (a *articleSvc) ListPub(ctx context.Context, start time.Time, offset, limit int) ([]domain.Article, error) {
-	// 根据 start 时间来查询
-	return a.authorRepo.ListPub(ctx, start, offset, limit)
+func (a *articleSvc) ListPub(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	return a.authorRepo.ListPub(ctx, cursor, limit)
+}
+
+func (a *articleSvc) ListPubByTag(ctx context.Context, tag string, offset, limit int) ([]domain.Article, error) {
+	return a.authorRepo.ListPubByTag(ctx, tag, offset, limit)
+}
+
+func (a *articleSvc) ListPubByCategory(ctx context.Context, category string, offset, limit int) ([]domain.Article, error) {
+	return a.authorRepo.ListPubByCategory(ctx, category, offset, limit)
+}
+
+func (a *articleSvc) CountPubTags(ctx context.Context, prefix string, limit int) ([]domain.TagCount, error) {
+	return a.authorRepo.CountPubTags(ctx, prefix, limit)
+}
+
+func (a *articleSvc) ListPubByAuthor(ctx context.Context, author int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	return a.authorRepo.ListPubByAuthor(ctx, author, cursor, limit)
+}
+
+func (a *articleSvc) Timeline(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	return a.authorRepo.Timeline(ctx, cursor, limit)
 }
 
 func (a *articleSvc) GetPublishedById(ctx context.Context, id int64, uid int64) (domain.Article, error) {
 	art, err := a.authorRepo.GetPublishedById(ctx, id)
+	if err == nil && art.Status == domain.ArticleStatusDeleted {
+		return domain.Article{}, ErrArticleNotFound
+	}
 
 	if err == nil {
+		if a.ch != nil {
+			// 批量的做法
+			a.sendReadInfo(readInfo{uid: uid, aid: id})
+			return art, err
+		}
 		go func() {
 			// 使用消息队列，发送阅读事件，增加阅读数计数
-			// 改进：通过批量来提高性能
 			er := a.producer.ProduceReadEvent(ctx, events.ReadEvent{
 				// 即便消费者要用 art 里面的数据，
 				// 应该让它去查询，不要在 event 里面带
@@ -104,24 +192,101 @@ func (a *articleSvc) GetPublishedById(ctx context.Context, id int64, uid int64)
 				a.l.Error("发送读者阅读事件失败")
 			}
 		}()
-
-		//go func() {
-		//	// 改批量的做法
-		//	a.ch <- readInfo{
-		//		aid: id,
-		//		uid: uid,
-		//	}
-		//}()
 	}
 	return art, err
 }
 
+// sendReadInfo 不阻塞读者，ch 满了或者服务已经关闭就丢弃这次阅读事件
+func (a *articleSvc) sendReadInfo(info readInfo) {
+	select {
+	case <-a.stop:
+		a.l.Warn("服务已关闭，丢弃阅读事件", logger.Int64("aid", info.aid))
+		return
+	default:
+	}
+	select {
+	case a.ch <- info:
+	default:
+		a.l.Warn("阅读事件太多，丢弃阅读事件", logger.Int64("aid", info.aid))
+	}
+}
+
 func (a *articleSvc) GetById(ctx context.Context, id int64) (domain.Article, error) {
 	return a.authorRepo.GetById(ctx, id)
 }
 
-func (a *articleSvc) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
-	return a.authorRepo.List(ctx, uid, offset, limit)
+func (a *articleSvc) List(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	return a.authorRepo.List(ctx, uid, cursor, limit)
+}
+
+func (a *articleSvc) ListRevisions(ctx context.Context, artId int64, uid int64, offset, limit int) ([]domain.ArticleRevision, error) {
+	return a.authorRepo.ListRevisions(ctx, artId, uid, offset, limit)
+}
+
+func (a *articleSvc) DiffRevisions(ctx context.Context, uid int64, from, to int64) (domain.ArticleRevisionDiff, error) {
+	fromRev, err := a.getRevision(ctx, uid, from)
+	if err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+	toRev, err := a.getRevision(ctx, uid, to)
+	if err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+	if fromRev.ArticleId != toRev.ArticleId {
+		return domain.ArticleRevisionDiff{}, ErrRevisionNotFound
+	}
+	res := domain.ArticleRevisionDiff{From: fromRev, To: toRev}
+	res.TitleDiff, err = a.diff(fromRev, toRev, fromRev.Title, toRev.Title)
+	if err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+	res.ContentDiff, err = a.diff(fromRev, toRev, fromRev.Content, toRev.Content)
+	return res, err
+}
+
+func (a *articleSvc) diff(from, to domain.ArticleRevision, a1, b1 string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a1),
+		B:        difflib.SplitLines(b1),
+		FromFile: fmt.Sprintf("revision-%d", from.Id),
+		ToFile:   fmt.Sprintf("revision-%d", to.Id),
+		Context:  3,
+	})
+}
+
+// RestoreRevision 恢复也是一次保存，所以会产生一个新的版本，不会丢掉中间的版本
+func (a *articleSvc) RestoreRevision(ctx context.Context, uid int64, id int64) (int64, error) {
+	rev, err := a.getRevision(ctx, uid, id)
+	if err != nil {
+		return 0, err
+	}
+	// 版本里面只有标题和内容，标签、摘要这些保持现在的
+	art, err := a.authorRepo.GetById(ctx, rev.ArticleId)
+	if err != nil {
+		return 0, err
+	}
+	return a.Save(ctx, domain.Article{
+		Id:       rev.ArticleId,
+		Title:    rev.Title,
+		Content:  rev.Content,
+		Tags:     art.Tags,
+		Category: art.Category,
+		Cover:    art.Cover,
+		Summary:  art.Summary,
+		Author:   domain.Author{Id: uid},
+	})
+}
+
+// getRevision 别人的版本也当作不存在
+func (a *articleSvc) getRevision(ctx context.Context, uid int64, id int64) (domain.ArticleRevision, error) {
+	rev, err := a.authorRepo.GetRevision(ctx, id)
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	if rev.AuthorId != uid {
+		return domain.ArticleRevision{}, ErrRevisionNotFound
+	}
+	return rev, nil
 }
 
 // Withdraw 撤回了帖子公开可见状态，改为私有（仅自己可见）
@@ -134,7 +299,16 @@ func (a *articleSvc) Withdraw(ctx context.Context, art domain.Article) error {
 // 2. 用户之前发表过帖子，在制作库上有记录，编辑帖子再发布（更新帖子，再发布）
 func (a *articleSvc) Publish(ctx context.Context, art domain.Article) (int64, error) {
 	art.Status = domain.ArticleStatusPublished // 状态改为公开
-	return a.authorRepo.Sync(ctx, art)
+	id, err := a.authorRepo.Sync(ctx, art)
+	if err != nil {
+		return id, err
+	}
+	// 发表事件只用来推关注流，发送失败不影响发表
+	er := a.producer.ProducePublishEvent(ctx, events.PublishEvent{Aid: id, Uid: art.Author.Id})
+	if er != nil {
+		a.l.Error("发送发表事件失败", logger.Int64("aid", id), logger.Error(er))
+	}
+	return id, nil
 }
 
 // Save 保存到制作库
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+var (
+	ErrInvalidPublishTime      = errors.New("定时发表的时间必须晚于当前时间")
+	ErrPublishScheduleNotFound = article.ErrPublishScheduleNotFound
+)
+
+//go:generate mockgen -source=article_schedule.go -package=svcmocks -destination=mocks/article_schedule.mock.go ArticleScheduleService
+type ArticleScheduleService interface {
+	// SchedulePublish 保存成草稿，到了 publishTime 再发表，返回文章 ID
+	SchedulePublish(ctx context.Context, art domain.Article, publishTime time.Time) (int64, error)
+	// ListPending 作者还没发表的定时，最早发表的在前
+	ListPending(ctx context.Context, uid int64, offset, limit int) ([]domain.PublishSchedule, error)
+	Reschedule(ctx context.Context, artId int64, uid int64, publishTime time.Time) error
+	Cancel(ctx context.Context, artId int64, uid int64) error
+	// PublishDue 发表所有到期的文章，由定时任务调用
+	PublishDue(ctx context.Context) error
+}
+
+type articleScheduleSvc struct {
+	artSvc    ArticleService
+	repo      article.PublishScheduleRepository
+	l         logger.Logger
+	batchSize int
+	// maxRetries 失败这么多次之后标记为发表失败，不再重试
+	maxRetries int
+}
+
+func NewArticleScheduleSvc(artSvc ArticleService, repo article.PublishScheduleRepository, l logger.Logger) ArticleScheduleService {
+	return &articleScheduleSvc{
+		artSvc:     artSvc,
+		repo:       repo,
+		l:          l,
+		batchSize:  100,
+		maxRetries: 3,
+	}
+}
+
+func (s *articleScheduleSvc) SchedulePublish(ctx context.Context, art domain.Article, publishTime time.Time) (int64, error) {
+	if !publishTime.After(time.Now()) {
+		return 0, ErrInvalidPublishTime
+	}
+	// 先存草稿，定时到了发表的是那个时候的草稿
+	id, err := s.artSvc.Save(ctx, art)
+	if err != nil {
+		return 0, err
+	}
+	return id, s.repo.Set(ctx, domain.PublishSchedule{
+		ArticleId:   id,
+		AuthorId:    art.Author.Id,
+		PublishTime: publishTime,
+	})
+}
+
+func (s *articleScheduleSvc) ListPending(ctx context.Context, uid int64, offset, limit int) ([]domain.PublishSchedule, error) {
+	return s.repo.ListPending(ctx, uid, offset, limit)
+}
+
+func (s *articleScheduleSvc) Reschedule(ctx context.Context, artId int64, uid int64, publishTime time.Time) error {
+	if !publishTime.After(time.Now()) {
+		return ErrInvalidPublishTime
+	}
+	return s.repo.UpdatePublishTime(ctx, artId, uid, publishTime)
+}
+
+func (s *articleScheduleSvc) Cancel(ctx context.Context, artId int64, uid int64) error {
+	return s.repo.Cancel(ctx, artId, uid)
+}
+
+func (s *articleScheduleSvc) PublishDue(ctx context.Context) error {
+	for {
+		due, err := s.repo.FindDue(ctx, time.Now(), s.batchSize)
+		if err != nil {
+			return err
+		}
+		failed := 0
+		for _, sch := range due {
+			// 一篇失败不影响同一批的其他文章
+			if err = s.publish(ctx, sch); err != nil {
+				failed++
+				s.onPublishFailed(ctx, sch, err)
+			}
+		}
+		// 有失败的就留到下一轮，否则这一轮会一直拿到失败的那几篇
+		if failed > 0 || len(due) < s.batchSize {
+			return nil
+		}
+	}
+}
+
+// onPublishFailed 记一次失败，失败了 maxRetries 次就标记为发表失败
+func (s *articleScheduleSvc) onPublishFailed(ctx context.Context, sch domain.PublishSchedule, err error) {
+	s.l.Error("定时发表失败",
+		logger.Int64("aid", sch.ArticleId),
+		logger.Int64("retries", int64(sch.Retries)),
+		logger.Error(err))
+	if sch.Retries+1 >= s.maxRetries {
+		err = s.repo.MarkFailed(ctx, sch.Id)
+	} else {
+		err = s.repo.IncrRetries(ctx, sch.Id)
+	}
+	// 已经不是待发表的，说明作者取消了或者改了，不用管
+	if err != nil && !errors.Is(err, ErrPublishScheduleNotFound) {
+		s.l.Error("记录定时发表失败次数失败", logger.Int64("aid", sch.ArticleId), logger.Error(err))
+	}
+}
+
+func (s *articleScheduleSvc) publish(ctx context.Context, sch domain.PublishSchedule) error {
+	art, err := s.artSvc.GetById(ctx, sch.ArticleId)
+	if err != nil {
+		return err
+	}
+	if art.Status == domain.ArticleStatusDeleted {
+		// 放进回收站的就不发表了
+		err = s.repo.Cancel(ctx, sch.ArticleId, sch.AuthorId)
+		if errors.Is(err, ErrPublishScheduleNotFound) {
+			return nil
+		}
+		return err
+	}
+	_, err = s.artSvc.Publish(ctx, domain.Article{
+		Id:       art.Id,
+		Title:    art.Title,
+		Content:  art.Content,
+		Tags:     art.Tags,
+		Category: art.Category,
+		Cover:    art.Cover,
+		Summary:  art.Summary,
+		Author:   domain.Author{Id: sch.AuthorId},
+	})
+	if err != nil {
+		return err
+	}
+	err = s.repo.MarkDone(ctx, sch.Id)
+	if errors.Is(err, ErrPublishScheduleNotFound) {
+		// 发表的过程中作者取消了，已经发出去了，只能记录一下
+		s.l.Warn("定时发表已被取消，但是文章已经发表", logger.Int64("aid", sch.ArticleId))
+		return nil
+	}
+	return err
+}
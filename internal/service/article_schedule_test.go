@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+	repomocks "github.com/mrhelloboy/wehook/internal/repository/article/mocks"
+	svcmocks "github.com/mrhelloboy/wehook/internal/service/mocks"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+func TestArticleScheduleSvc_SchedulePublish(t *testing.T) {
+	publishTime := time.Now().Add(time.Hour)
+	testCases := []struct {
+		name        string
+		mock        func(ctrl *gomock.Controller) (ArticleService, article.PublishScheduleRepository)
+		publishTime time.Time
+		wantErr     error
+		wantId      int64
+	}{
+		{
+			name: "设置成功",
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.PublishScheduleRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().Save(gomock.Any(), domain.Article{Title: "标题", Author: domain.Author{Id: 123}}).
+					Return(int64(1), nil)
+				repo := repomocks.NewMockPublishScheduleRepository(ctrl)
+				repo.EXPECT().Set(gomock.Any(), domain.PublishSchedule{
+					ArticleId: 1, AuthorId: 123, PublishTime: publishTime,
+				}).Return(nil)
+				return artSvc, repo
+			},
+			publishTime: publishTime,
+			wantId:      1,
+		},
+		{
+			name: "时间已经过去",
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.PublishScheduleRepository) {
+				return svcmocks.NewMockArticleService(ctrl), repomocks.NewMockPublishScheduleRepository(ctrl)
+			},
+			publishTime: time.Now().Add(-time.Minute),
+			wantErr:     ErrInvalidPublishTime,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			artSvc, repo := tc.mock(ctrl)
+			svc := NewArticleScheduleSvc(artSvc, repo, &logger.NopLogger{})
+			id, err := svc.SchedulePublish(context.Background(),
+				domain.Article{Title: "标题", Author: domain.Author{Id: 123}}, tc.publishTime)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func TestArticleScheduleSvc_PublishDue(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (ArticleService, article.PublishScheduleRepository)
+		wantErr error
+	}{
+		{
+			name: "发表到期的草稿",
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.PublishScheduleRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				repo := repomocks.NewMockPublishScheduleRepository(ctrl)
+				repo.EXPECT().FindDue(gomock.Any(), gomock.Any(), 100).Return([]domain.PublishSchedule{
+					{Id: 11, ArticleId: 1, AuthorId: 123},
+					{Id: 12, ArticleId: 2, AuthorId: 123},
+				}, nil)
+				for _, aid := range []int64{1, 2} {
+					artSvc.EXPECT().GetById(gomock.Any(), aid).Return(domain.Article{
+						Id: aid, Title: "标题", Content: "内容", Author: domain.Author{Id: 123},
+						Status: domain.ArticleStatusUnpublished,
+					}, nil)
+					artSvc.EXPECT().Publish(gomock.Any(), domain.Article{
+						Id: aid, Title: "标题", Content: "内容", Author: domain.Author{Id: 123},
+					}).Return(aid, nil)
+				}
+				repo.EXPECT().MarkDone(gomock.Any(), int64(11)).Return(nil)
+				// 发表的时候被取消了，不算失败
+				repo.EXPECT().MarkDone(gomock.Any(), int64(12)).Return(ErrPublishScheduleNotFound)
+				return artSvc, repo
+			},
+		},
+		{
+			name: "帖子已经删除，取消定时",
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.PublishScheduleRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				repo := repomocks.NewMockPublishScheduleRepository(ctrl)
+				repo.EXPECT().FindDue(gomock.Any(), gomock.Any(), 100).Return([]domain.PublishSchedule{
+					{Id: 11, ArticleId: 1, AuthorId: 123},
+				}, nil)
+				artSvc.EXPECT().GetById(gomock.Any(), int64(1)).Return(domain.Article{
+					Id: 1, Author: domain.Author{Id: 123}, Status: domain.ArticleStatusDeleted,
+				}, nil)
+				repo.EXPECT().Cancel(gomock.Any(), int64(1), int64(123)).Return(nil)
+				return artSvc, repo
+			},
+		},
+		{
+			name: "一篇发表失败，同一批的其他文章继续发表",
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.PublishScheduleRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				repo := repomocks.NewMockPublishScheduleRepository(ctrl)
+				repo.EXPECT().FindDue(gomock.Any(), gomock.Any(), 100).Return([]domain.PublishSchedule{
+					{Id: 11, ArticleId: 1, AuthorId: 123},
+					{Id: 12, ArticleId: 2, AuthorId: 123},
+				}, nil)
+				artSvc.EXPECT().GetById(gomock.Any(), int64(1)).Return(domain.Article{Id: 1}, nil)
+				artSvc.EXPECT().Publish(gomock.Any(), domain.Article{Id: 1, Author: domain.Author{Id: 123}}).
+					Return(int64(0), errors.New("db error"))
+				repo.EXPECT().IncrRetries(gomock.Any(), int64(11)).Return(nil)
+				artSvc.EXPECT().GetById(gomock.Any(), int64(2)).Return(domain.Article{Id: 2}, nil)
+				artSvc.EXPECT().Publish(gomock.Any(), domain.Article{Id: 2, Author: domain.Author{Id: 123}}).
+					Return(int64(2), nil)
+				repo.EXPECT().MarkDone(gomock.Any(), int64(12)).Return(nil)
+				return artSvc, repo
+			},
+		},
+		{
+			name: "失败次数到了上限，标记为发表失败",
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.PublishScheduleRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				repo := repomocks.NewMockPublishScheduleRepository(ctrl)
+				repo.EXPECT().FindDue(gomock.Any(), gomock.Any(), 100).Return([]domain.PublishSchedule{
+					{Id: 11, ArticleId: 1, AuthorId: 123, Retries: 2},
+				}, nil)
+				artSvc.EXPECT().GetById(gomock.Any(), int64(1)).Return(domain.Article{}, errors.New("db error"))
+				repo.EXPECT().MarkFailed(gomock.Any(), int64(11)).Return(nil)
+				return artSvc, repo
+			},
+		},
+		{
+			name: "查询到期的定时失败",
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.PublishScheduleRepository) {
+				repo := repomocks.NewMockPublishScheduleRepository(ctrl)
+				repo.EXPECT().FindDue(gomock.Any(), gomock.Any(), 100).Return(nil, errors.New("db error"))
+				return svcmocks.NewMockArticleService(ctrl), repo
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			artSvc, repo := tc.mock(ctrl)
+			svc := NewArticleScheduleSvc(artSvc, repo, &logger.NopLogger{})
+			err := svc.PublishDue(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	events "github.com/mrhelloboy/wehook/internal/events/article"
 	evtArtMock "github.com/mrhelloboy/wehook/internal/events/article/mocks"
 
 	repomocks "github.com/mrhelloboy/wehook/internal/repository/article/mocks"
@@ -20,32 +22,26 @@ import (
 func Test_articleSvc_Publish(t *testing.T) {
 	testCases := []struct {
 		name    string
-		mock    func(ctrl *gomock.Controller) (article.AuthorRepository, article.ReaderRepository)
+		mock    func(ctrl *gomock.Controller) (article.AuthorRepository, events.Producer)
 		art     domain.Article
 		wantErr error
 		wantId  int64
 	}{
 		{
 			name: "新建发表成功",
-			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, article.ReaderRepository) {
+			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, events.Producer) {
 				author := repomocks.NewMockAuthorRepository(ctrl)
-				reader := repomocks.NewMockReaderRepository(ctrl)
-				author.EXPECT().Create(gomock.Any(), domain.Article{
+				producer := evtArtMock.NewMockProducer(ctrl)
+				author.EXPECT().Sync(gomock.Any(), domain.Article{
 					Title:   "test",
 					Content: "test",
 					Author: domain.Author{
 						Id: 123,
 					},
+					Status: domain.ArticleStatusPublished,
 				}).Return(int64(1), nil)
-				reader.EXPECT().Save(gomock.Any(), domain.Article{
-					Id:      1, // 使用制作库的 ID
-					Title:   "test",
-					Content: "test",
-					Author: domain.Author{
-						Id: 123,
-					},
-				}).Return(int64(1), nil)
-				return author, reader
+				producer.EXPECT().ProducePublishEvent(gomock.Any(), events.PublishEvent{Aid: 1, Uid: 123}).Return(nil)
+				return author, producer
 			},
 			art: domain.Article{
 				Title:   "test",
@@ -59,26 +55,20 @@ func Test_articleSvc_Publish(t *testing.T) {
 		},
 		{
 			name: "修改并发表成功",
-			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, article.ReaderRepository) {
+			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, events.Producer) {
 				author := repomocks.NewMockAuthorRepository(ctrl)
-				reader := repomocks.NewMockReaderRepository(ctrl)
-				author.EXPECT().Update(gomock.Any(), domain.Article{
+				producer := evtArtMock.NewMockProducer(ctrl)
+				author.EXPECT().Sync(gomock.Any(), domain.Article{
 					Id:      2,
 					Title:   "test",
 					Content: "test",
 					Author: domain.Author{
 						Id: 123,
 					},
-				}).Return(nil)
-				reader.EXPECT().Save(gomock.Any(), domain.Article{
-					Id:      2, // 使用制作库的 ID
-					Title:   "test",
-					Content: "test",
-					Author: domain.Author{
-						Id: 123,
-					},
+					Status: domain.ArticleStatusPublished,
 				}).Return(int64(2), nil)
-				return author, reader
+				producer.EXPECT().ProducePublishEvent(gomock.Any(), events.PublishEvent{Aid: 2, Uid: 123}).Return(nil)
+				return author, producer
 			},
 			art: domain.Article{
 				Id:      2,
@@ -92,18 +82,12 @@ func Test_articleSvc_Publish(t *testing.T) {
 			wantId:  2,
 		},
 		{
-			name: "保存到制作库失败",
-			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, article.ReaderRepository) {
+			name: "同步到线上库失败",
+			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, events.Producer) {
 				author := repomocks.NewMockAuthorRepository(ctrl)
-				reader := repomocks.NewMockReaderRepository(ctrl)
-				author.EXPECT().Create(gomock.Any(), domain.Article{
-					Title:   "test",
-					Content: "test",
-					Author: domain.Author{
-						Id: 123,
-					},
-				}).Return(int64(0), errors.New("mock db error"))
-				return author, reader
+				producer := evtArtMock.NewMockProducer(ctrl)
+				author.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("mock db error"))
+				return author, producer
 			},
 			art: domain.Article{
 				Title:   "test",
@@ -116,34 +100,14 @@ func Test_articleSvc_Publish(t *testing.T) {
 			wantId:  0,
 		},
 		{
-			name: "保存到制作库成功，重试到线上库成功",
-			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, article.ReaderRepository) {
+			name: "发表事件发送失败，不影响发表",
+			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, events.Producer) {
 				author := repomocks.NewMockAuthorRepository(ctrl)
-				reader := repomocks.NewMockReaderRepository(ctrl)
-				author.EXPECT().Create(gomock.Any(), domain.Article{
-					Title:   "test",
-					Content: "test",
-					Author: domain.Author{
-						Id: 123,
-					},
-				}).Return(int64(1), nil)
-				reader.EXPECT().Save(gomock.Any(), domain.Article{
-					Id:      1, // 使用制作库的 ID
-					Title:   "test",
-					Content: "test",
-					Author: domain.Author{
-						Id: 123,
-					},
-				}).Return(int64(0), errors.New("mock db error"))
-				reader.EXPECT().Save(gomock.Any(), domain.Article{
-					Id:      1, // 使用制作库的 ID
-					Title:   "test",
-					Content: "test",
-					Author: domain.Author{
-						Id: 123,
-					},
-				}).Return(int64(1), nil)
-				return author, reader
+				producer := evtArtMock.NewMockProducer(ctrl)
+				author.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+				producer.EXPECT().ProducePublishEvent(gomock.Any(), events.PublishEvent{Aid: 1, Uid: 123}).
+					Return(errors.New("mock kafka error"))
+				return author, producer
 			},
 			art: domain.Article{
 				Title:   "test",
@@ -155,38 +119,6 @@ func Test_articleSvc_Publish(t *testing.T) {
 			wantErr: nil,
 			wantId:  1,
 		},
-		{
-			name: "保存到制作库成功，重试全部失败",
-			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, article.ReaderRepository) {
-				author := repomocks.NewMockAuthorRepository(ctrl)
-				reader := repomocks.NewMockReaderRepository(ctrl)
-				author.EXPECT().Create(gomock.Any(), domain.Article{
-					Title:   "test",
-					Content: "test",
-					Author: domain.Author{
-						Id: 123,
-					},
-				}).Return(int64(1), nil)
-				reader.EXPECT().Save(gomock.Any(), domain.Article{
-					Id:      1, // 使用制作库的 ID
-					Title:   "test",
-					Content: "test",
-					Author: domain.Author{
-						Id: 123,
-					},
-				}).Times(3).Return(int64(0), errors.New("mock db error"))
-				return author, reader
-			},
-			art: domain.Article{
-				Title:   "test",
-				Content: "test",
-				Author: domain.Author{
-					Id: 123,
-				},
-			},
-			wantErr: errors.New("mock db error"),
-			wantId:  0,
-		},
 	}
 
 	for _, tc := range testCases {
@@ -195,12 +127,131 @@ func Test_articleSvc_Publish(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			author, _ := tc.mock(ctrl)
+			author, producer := tc.mock(ctrl)
 
-			svc := NewArticleSvc(author, &logger.NopLogger{}, evtArtMock.NewMockProducer(ctrl))
+			svc := NewArticleSvc(author, &logger.NopLogger{}, producer)
 			id, err := svc.Publish(context.Background(), tc.art)
 			assert.Equal(t, tc.wantErr, err)
 			assert.Equal(t, tc.wantId, id)
 		})
 	}
 }
+
+func Test_articleSvc_GetPublishedByIdV1(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	author := repomocks.NewMockAuthorRepository(ctrl)
+	author.EXPECT().GetPublishedById(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id int64) (domain.Article, error) {
+		return domain.Article{Id: id}, nil
+	}).Times(3)
+	producer := evtArtMock.NewMockProducer(ctrl)
+	// 还没攒够一批，也没到时间，关闭的时候要把剩下的发出去
+	producer.EXPECT().ProduceReadEventV1(gomock.Any(), events.ReadEventV1{
+		Uids: []int64{123, 123, 456},
+		Aids: []int64{1, 2, 1},
+	}).Return(nil)
+
+	svc, closeFunc := NewArticleSvcV1(author, &logger.NopLogger{}, producer)
+	for _, ra := range [][2]int64{{123, 1}, {123, 2}, {456, 1}} {
+		_, err := svc.GetPublishedById(context.Background(), ra[1], ra[0])
+		assert.NoError(t, err)
+	}
+	start := time.Now()
+	closeFunc()
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func Test_articleSvc_DiffRevisions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) article.AuthorRepository
+		from, to int64
+		wantErr  error
+		wantDiff string
+	}{
+		{
+			name: "比较成功",
+			mock: func(ctrl *gomock.Controller) article.AuthorRepository {
+				repo := repomocks.NewMockAuthorRepository(ctrl)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1)).
+					Return(domain.ArticleRevision{Id: 1, ArticleId: 10, AuthorId: 123, Title: "标题", Content: "a\nb"}, nil)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(2)).
+					Return(domain.ArticleRevision{Id: 2, ArticleId: 10, AuthorId: 123, Title: "标题", Content: "a\nc"}, nil)
+				return repo
+			},
+			from: 1,
+			to:   2,
+			wantDiff: "--- revision-1\n+++ revision-2\n@@ -1,2 +1,2 @@\n" +
+				" a\n-b\n+c\n",
+		},
+		{
+			name: "别人的版本",
+			mock: func(ctrl *gomock.Controller) article.AuthorRepository {
+				repo := repomocks.NewMockAuthorRepository(ctrl)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1)).
+					Return(domain.ArticleRevision{Id: 1, ArticleId: 10, AuthorId: 456}, nil)
+				return repo
+			},
+			from:    1,
+			to:      2,
+			wantErr: ErrRevisionNotFound,
+		},
+		{
+			name: "不是同一篇文章",
+			mock: func(ctrl *gomock.Controller) article.AuthorRepository {
+				repo := repomocks.NewMockAuthorRepository(ctrl)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(1)).
+					Return(domain.ArticleRevision{Id: 1, ArticleId: 10, AuthorId: 123}, nil)
+				repo.EXPECT().GetRevision(gomock.Any(), int64(2)).
+					Return(domain.ArticleRevision{Id: 2, ArticleId: 11, AuthorId: 123}, nil)
+				return repo
+			},
+			from:    1,
+			to:      2,
+			wantErr: ErrRevisionNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewArticleSvc(tc.mock(ctrl), &logger.NopLogger{}, evtArtMock.NewMockProducer(ctrl))
+			diff, err := svc.DiffRevisions(context.Background(), 123, tc.from, tc.to)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, "", diff.TitleDiff)
+			assert.Equal(t, tc.wantDiff, diff.ContentDiff)
+		})
+	}
+}
+
+func Test_articleSvc_RestoreRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repomocks.NewMockAuthorRepository(ctrl)
+	repo.EXPECT().GetRevision(gomock.Any(), int64(1)).
+		Return(domain.ArticleRevision{Id: 1, ArticleId: 10, AuthorId: 123, Title: "旧标题", Content: "旧内容",
+			Status: domain.ArticleStatusPublished}, nil)
+	repo.EXPECT().GetById(gomock.Any(), int64(10)).
+		Return(domain.Article{Id: 10, Title: "新标题", Content: "新内容", Tags: []string{"Go"}, Category: "后端",
+			Author: domain.Author{Id: 123}}, nil)
+	// 恢复成草稿，而不是恢复当时的状态，标签和分类保持现在的
+	repo.EXPECT().Update(gomock.Any(), domain.Article{
+		Id:       10,
+		Title:    "旧标题",
+		Content:  "旧内容",
+		Tags:     []string{"Go"},
+		Category: "后端",
+		Author:   domain.Author{Id: 123},
+		Status:   domain.ArticleStatusUnpublished,
+	}).Return(nil)
+
+	svc := NewArticleSvc(repo, &logger.NopLogger{}, evtArtMock.NewMockProducer(ctrl))
+	id, err := svc.RestoreRevision(context.Background(), 123, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), id)
+}
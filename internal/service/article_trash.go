@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	intrv1 "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1"
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+// ErrArticleNotFound 文章不存在，或者已经在回收站里面了
+var ErrArticleNotFound = article.ErrArticleNotFound
+
+//go:generate mockgen -source=article_trash.go -package=svcmocks -destination=mocks/article_trash.mock.go ArticleTrashService
+type ArticleTrashService interface {
+	// Delete 放进回收站，读者看不到，作者可以在保留期内恢复
+	Delete(ctx context.Context, id int64, uid int64) error
+	Restore(ctx context.Context, id int64, uid int64) error
+	ListTrash(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error)
+	// PurgeExpired 彻底删除超过保留期的文章，由定时任务调用
+	PurgeExpired(ctx context.Context) error
+}
+
+type articleTrashSvc struct {
+	repo      article.AuthorRepository
+	intrSvc   intrv1.InteractiveServiceClient
+	l         logger.Logger
+	retention time.Duration
+	batchSize int
+	biz       string
+}
+
+func NewArticleTrashSvc(repo article.AuthorRepository, intrSvc intrv1.InteractiveServiceClient, l logger.Logger) ArticleTrashService {
+	return &articleTrashSvc{
+		repo:      repo,
+		intrSvc:   intrSvc,
+		l:         l,
+		retention: time.Hour * 24 * 30,
+		batchSize: 100,
+		biz:       "article",
+	}
+}
+
+func (s *articleTrashSvc) Delete(ctx context.Context, id int64, uid int64) error {
+	return s.repo.Delete(ctx, id, uid)
+}
+
+func (s *articleTrashSvc) Restore(ctx context.Context, id int64, uid int64) error {
+	return s.repo.Restore(ctx, id, uid)
+}
+
+func (s *articleTrashSvc) ListTrash(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+	return s.repo.ListTrash(ctx, uid, offset, limit)
+}
+
+func (s *articleTrashSvc) PurgeExpired(ctx context.Context) error {
+	before := time.Now().Add(-s.retention)
+	// 删掉的文章不会再被查出来，删不掉的还留在前面，用 failed 跳过它们
+	failed := 0
+	for {
+		arts, err := s.repo.ListExpiredTrash(ctx, before, failed, s.batchSize)
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			// 一篇失败不能挡住后面的，跳过它，下一轮再来
+			if err = s.purge(ctx, art.Id); err != nil {
+				failed++
+				s.l.Error("彻底删除文章失败", logger.Int64("aid", art.Id), logger.Error(err))
+			}
+		}
+		if len(arts) < s.batchSize {
+			return nil
+		}
+	}
+}
+
+// purge 先删交互数据再删文章，中途失败了下一轮还能找到这篇文章，重新删一遍
+func (s *articleTrashSvc) purge(ctx context.Context, id int64) error {
+	_, err := s.intrSvc.DeleteBiz(ctx, &intrv1.DeleteBizRequest{Biz: s.biz, BizId: id})
+	if err != nil {
+		return err
+	}
+	err = s.repo.Purge(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.l.Info("彻底删除文章", logger.Int64("aid", id))
+	return nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	intrv1 "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1"
+	intrv1mocks "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1/mocks"
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+	repomocks "github.com/mrhelloboy/wehook/internal/repository/article/mocks"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+func TestArticleTrashSvc_PurgeExpired(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (article.AuthorRepository, intrv1.InteractiveServiceClient)
+		wantErr error
+	}{
+		{
+			name: "删除过期的帖子和交互数据",
+			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, intrv1.InteractiveServiceClient) {
+				repo := repomocks.NewMockAuthorRepository(ctrl)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				repo.EXPECT().ListExpiredTrash(gomock.Any(), gomock.Any(), 0, 2).
+					Return([]domain.Article{{Id: 1}, {Id: 2}}, nil)
+				repo.EXPECT().ListExpiredTrash(gomock.Any(), gomock.Any(), 0, 2).
+					Return([]domain.Article{{Id: 3}}, nil)
+				for _, id := range []int64{1, 2, 3} {
+					intrSvc.EXPECT().DeleteBiz(gomock.Any(), &intrv1.DeleteBizRequest{Biz: "article", BizId: id}).
+						Return(&intrv1.DeleteBizResponse{}, nil)
+					repo.EXPECT().Purge(gomock.Any(), id).Return(nil)
+				}
+				return repo, intrSvc
+			},
+		},
+		{
+			name: "删除失败的文章跳过，不影响后面的",
+			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, intrv1.InteractiveServiceClient) {
+				repo := repomocks.NewMockAuthorRepository(ctrl)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				repo.EXPECT().ListExpiredTrash(gomock.Any(), gomock.Any(), 0, 2).
+					Return([]domain.Article{{Id: 1}, {Id: 2}}, nil)
+				// 1 的交互数据删不掉，2 的文章删不掉，都还留在前面，要跳过
+				repo.EXPECT().ListExpiredTrash(gomock.Any(), gomock.Any(), 2, 2).
+					Return([]domain.Article{{Id: 3}}, nil)
+				intrSvc.EXPECT().DeleteBiz(gomock.Any(), &intrv1.DeleteBizRequest{Biz: "article", BizId: 1}).
+					Return(nil, errors.New("rpc error"))
+				intrSvc.EXPECT().DeleteBiz(gomock.Any(), &intrv1.DeleteBizRequest{Biz: "article", BizId: 2}).
+					Return(&intrv1.DeleteBizResponse{}, nil)
+				repo.EXPECT().Purge(gomock.Any(), int64(2)).Return(errors.New("db error"))
+				intrSvc.EXPECT().DeleteBiz(gomock.Any(), &intrv1.DeleteBizRequest{Biz: "article", BizId: 3}).
+					Return(&intrv1.DeleteBizResponse{}, nil)
+				repo.EXPECT().Purge(gomock.Any(), int64(3)).Return(nil)
+				return repo, intrSvc
+			},
+		},
+		{
+			name: "查询失败",
+			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, intrv1.InteractiveServiceClient) {
+				repo := repomocks.NewMockAuthorRepository(ctrl)
+				repo.EXPECT().ListExpiredTrash(gomock.Any(), gomock.Any(), 0, 2).
+					Return(nil, errors.New("db error"))
+				return repo, intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, intrSvc := tc.mock(ctrl)
+			svc := NewArticleTrashSvc(repo, intrSvc, &logger.NopLogger{}).(*articleTrashSvc)
+			svc.batchSize = 2
+			err := svc.PurgeExpired(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestArticleTrashSvc_PurgeExpiredRetention(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockAuthorRepository(ctrl)
+	repo.EXPECT().ListExpiredTrash(gomock.Any(), gomock.Any(), 0, 100).
+		DoAndReturn(func(ctx context.Context, before time.Time, offset int, limit int) ([]domain.Article, error) {
+			// 保留 30 天
+			assert.WithinDuration(t, time.Now().Add(-time.Hour*24*30), before, time.Minute)
+			return nil, nil
+		})
+	svc := NewArticleTrashSvc(repo, intrv1mocks.NewMockInteractiveServiceClient(ctrl), &logger.NopLogger{})
+	assert.NoError(t, svc.PurgeExpired(context.Background()))
+}
@@ -4,31 +4,43 @@ import (
 	"context"
 	"fmt"
 	"github.com/mrhelloboy/wehook/internal/repository"
+	"github.com/mrhelloboy/wehook/internal/service/email"
 	"github.com/mrhelloboy/wehook/internal/service/sms"
 	"math/rand"
 )
 
 const codeTplId = "1877556"
 
+const (
+	codeEmailSubject = "webook 验证码"
+	codeEmailContent = "你的验证码是 %s，10 分钟内有效，请不要告诉别人。"
+)
+
 var (
 	ErrCodeSendTooMany        = repository.ErrCodeSendTooMany
 	ErrCodeVerifyTooManyTimes = repository.ErrCodeVerifyTooManyTimes
 )
 
 type CodeService interface {
+	// Send 通过短信发验证码
 	Send(ctx context.Context, biz string, phone string) error
-	Verify(ctx context.Context, biz string, phone string, inputCode string) (bool, error)
+	// SendEmail 通过邮件发验证码
+	SendEmail(ctx context.Context, biz string, email string) error
+	// Verify target 是收验证码的手机号或者邮箱
+	Verify(ctx context.Context, biz string, target string, inputCode string) (bool, error)
 }
 
 type CodeSvc struct {
-	repo repository.CodeRepository
-	sms  sms.Service
+	repo  repository.CodeRepository
+	sms   sms.Service
+	email email.Service
 }
 
-func NewCodeSvc(repo repository.CodeRepository, sms sms.Service) CodeService {
+func NewCodeSvc(repo repository.CodeRepository, sms sms.Service, email email.Service) CodeService {
 	return &CodeSvc{
-		repo: repo,
-		sms:  sms,
+		repo:  repo,
+		sms:   sms,
+		email: email,
 	}
 }
 
@@ -53,9 +65,19 @@ func (svc *CodeSvc) Send(ctx context.Context, biz string, phone string) error {
 	return err
 }
 
+// SendEmail 发邮件验证码，和短信验证码存在一起，发送频率的限制也一样
+func (svc *CodeSvc) SendEmail(ctx context.Context, biz string, email string) error {
+	code := svc.generateCode()
+	err := svc.repo.Store(ctx, biz, email, code)
+	if err != nil {
+		return err
+	}
+	return svc.email.Send(ctx, codeEmailSubject, fmt.Sprintf(codeEmailContent, code), email)
+}
+
 // Verify 验证验证码
-func (svc *CodeSvc) Verify(ctx context.Context, biz string, phone string, inputCode string) (bool, error) {
-	return svc.repo.Verify(ctx, biz, phone, inputCode)
+func (svc *CodeSvc) Verify(ctx context.Context, biz string, target string, inputCode string) (bool, error) {
+	return svc.repo.Verify(ctx, biz, target, inputCode)
 }
 
 func (svc *CodeSvc) generateCode() string {
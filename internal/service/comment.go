@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	intrv1 "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1"
+	"github.com/mrhelloboy/wehook/internal/domain"
+	events "github.com/mrhelloboy/wehook/internal/events/article"
+	"github.com/mrhelloboy/wehook/internal/repository"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+var (
+	ErrCommentNotFound = repository.ErrCommentNotFound
+	// ErrCommentPermissionDenied 不是评论者本人，也不是帖子的作者
+	ErrCommentPermissionDenied = errors.New("没有权限操作这条评论")
+	// ErrCommentNotRoot 只有根评论可以置顶
+	ErrCommentNotRoot = errors.New("只能置顶根评论")
+)
+
+// CommentService 帖子的评论。评论者本人可以删除自己的评论，
+// 帖子的作者可以删除和置顶帖子下面的任何评论
+//
+//go:generate mockgen -source=comment.go -package=svcmocks -destination=mocks/comment.mock.go CommentService
+type CommentService interface {
+	// Create 发表评论，Pid 不为 0 的是回复，只能评论公开发表的帖子
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	// Delete 删除根评论的时候连同回复一起删掉，删除回复的时候连同回复它的一起删掉
+	Delete(ctx context.Context, id int64, uid int64) error
+	// Pin 置顶一条根评论，同一篇帖子只保留一条置顶
+	Pin(ctx context.Context, id int64, uid int64) error
+	Unpin(ctx context.Context, id int64, uid int64) error
+	// ListRoots 第一页置顶评论在最前面，其余的按照发表时间倒序，maxId 为 0 表示第一页
+	ListRoots(ctx context.Context, aid int64, maxId int64, limit int) ([]domain.Comment, error)
+	// ListReplies 按照发表时间正序，minId 为 0 表示第一页
+	ListReplies(ctx context.Context, rootId int64, minId int64, limit int) ([]domain.Comment, error)
+}
+
+type commentSvc struct {
+	repo     repository.CommentRepository
+	artRepo  article.AuthorRepository
+	intrSvc  intrv1.InteractiveServiceClient
+	producer events.Producer
+	l        logger.Logger
+	biz      string
+}
+
+func NewCommentSvc(repo repository.CommentRepository, artRepo article.AuthorRepository,
+	intrSvc intrv1.InteractiveServiceClient, producer events.Producer, l logger.Logger) CommentService {
+	return &commentSvc{
+		repo:     repo,
+		artRepo:  artRepo,
+		intrSvc:  intrSvc,
+		producer: producer,
+		l:        l,
+		biz:      "article",
+	}
+}
+
+func (s *commentSvc) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	art, err := s.artRepo.GetPublishedById(ctx, c.BizId)
+	if err != nil {
+		return 0, err
+	}
+	if art.Status != domain.ArticleStatusPublished {
+		return 0, ErrArticleNotFound
+	}
+	c.Biz = s.biz
+	c.RootId = 0
+	var replyTo int64
+	if c.Pid > 0 {
+		parent, er := s.repo.FindById(ctx, c.Pid)
+		if er != nil {
+			return 0, er
+		}
+		if parent.Biz != c.Biz || parent.BizId != c.BizId {
+			return 0, ErrCommentNotFound
+		}
+		// 回复的回复也挂在根评论下面
+		c.RootId = parent.RootId
+		if parent.IsRoot() {
+			c.RootId = parent.Id
+		}
+		replyTo = parent.Uid
+	}
+	id, err := s.repo.Create(ctx, c)
+	if err != nil {
+		return 0, err
+	}
+	s.incrCommentCnt(ctx, c.BizId, 1)
+	// 评论已经写进去了，通知发不出去只记录日志
+	err = s.producer.ProduceCommentEvent(ctx, events.CommentEvent{
+		Biz:     c.Biz,
+		BizId:   c.BizId,
+		Uid:     c.Uid,
+		Cid:     id,
+		ReplyTo: replyTo,
+	})
+	if err != nil {
+		s.l.Error("发送评论事件失败", logger.Int64("cid", id), logger.Error(err))
+	}
+	return id, nil
+}
+
+func (s *commentSvc) Delete(ctx context.Context, id int64, uid int64) error {
+	c, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	// 评论者本人删除自己的评论，不需要去查帖子
+	if c.Uid != uid {
+		if err = s.checkAuthor(ctx, c, uid); err != nil {
+			return err
+		}
+	}
+	cnt, err := s.repo.Delete(ctx, c)
+	if err != nil {
+		return err
+	}
+	if cnt > 0 {
+		s.incrCommentCnt(ctx, c.BizId, -cnt)
+	}
+	return nil
+}
+
+func (s *commentSvc) Pin(ctx context.Context, id int64, uid int64) error {
+	c, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !c.IsRoot() {
+		return ErrCommentNotRoot
+	}
+	if err = s.checkAuthor(ctx, c, uid); err != nil {
+		return err
+	}
+	return s.repo.Pin(ctx, c)
+}
+
+func (s *commentSvc) Unpin(ctx context.Context, id int64, uid int64) error {
+	c, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	// 只有置顶的根评论才能取消置顶
+	if !c.IsRoot() || !c.Pinned {
+		return ErrCommentNotFound
+	}
+	if err = s.checkAuthor(ctx, c, uid); err != nil {
+		return err
+	}
+	return s.repo.Unpin(ctx, id)
+}
+
+func (s *commentSvc) ListRoots(ctx context.Context, aid int64, maxId int64, limit int) ([]domain.Comment, error) {
+	return s.repo.ListRoots(ctx, s.biz, aid, maxId, limit)
+}
+
+func (s *commentSvc) ListReplies(ctx context.Context, rootId int64, minId int64, limit int) ([]domain.Comment, error) {
+	return s.repo.ListReplies(ctx, rootId, minId, limit)
+}
+
+// checkAuthor uid 是不是评论所在帖子的作者
+func (s *commentSvc) checkAuthor(ctx context.Context, c domain.Comment, uid int64) error {
+	art, err := s.artRepo.GetById(ctx, c.BizId)
+	switch {
+	case errors.Is(err, ErrArticleNotFound):
+		return ErrCommentPermissionDenied
+	case err != nil:
+		return err
+	case art.Author.Id != uid:
+		return ErrCommentPermissionDenied
+	default:
+		return nil
+	}
+}
+
+// incrCommentCnt 评论已经写进去了，评论数更新失败只记录日志
+func (s *commentSvc) incrCommentCnt(ctx context.Context, aid int64, delta int64) {
+	_, err := s.intrSvc.IncrCommentCnt(ctx, &intrv1.IncrCommentCntRequest{
+		Biz:   s.biz,
+		BizId: aid,
+		Delta: delta,
+	})
+	if err != nil {
+		s.l.Error("更新评论数失败", logger.Int64("aid", aid), logger.Int64("delta", delta), logger.Error(err))
+	}
+}
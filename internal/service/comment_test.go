@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	intrv1 "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1"
+	intrv1mocks "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1/mocks"
+	"github.com/mrhelloboy/wehook/internal/domain"
+	events "github.com/mrhelloboy/wehook/internal/events/article"
+	evtArtMock "github.com/mrhelloboy/wehook/internal/events/article/mocks"
+	"github.com/mrhelloboy/wehook/internal/repository"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+	artrepomocks "github.com/mrhelloboy/wehook/internal/repository/article/mocks"
+	repomocks "github.com/mrhelloboy/wehook/internal/repository/mocks"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+func TestCommentSvc_Create(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.CommentRepository, article.AuthorRepository, intrv1.InteractiveServiceClient, events.Producer)
+		cmt     domain.Comment
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "发表根评论",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, article.AuthorRepository, intrv1.InteractiveServiceClient, events.Producer) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := artrepomocks.NewMockAuthorRepository(ctrl)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				artRepo.EXPECT().GetPublishedById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusPublished}, nil)
+				repo.EXPECT().Create(gomock.Any(), domain.Comment{
+					Biz: "article", BizId: 1, Uid: 123, Content: "评论",
+				}).Return(int64(10), nil)
+				intrSvc.EXPECT().IncrCommentCnt(gomock.Any(), &intrv1.IncrCommentCntRequest{
+					Biz: "article", BizId: 1, Delta: 1,
+				}).Return(&intrv1.IncrCommentCntResponse{}, nil)
+				producer := evtArtMock.NewMockProducer(ctrl)
+				producer.EXPECT().ProduceCommentEvent(gomock.Any(), events.CommentEvent{
+					Biz: "article", BizId: 1, Uid: 123, Cid: 10,
+				}).Return(nil)
+				return repo, artRepo, intrSvc, producer
+			},
+			cmt:    domain.Comment{BizId: 1, Uid: 123, Content: "评论"},
+			wantId: 10,
+		},
+		{
+			name: "回复的回复挂在根评论下面，评论数更新失败和事件发送失败都不影响",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, article.AuthorRepository, intrv1.InteractiveServiceClient, events.Producer) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := artrepomocks.NewMockAuthorRepository(ctrl)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				artRepo.EXPECT().GetPublishedById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusPublished}, nil)
+				repo.EXPECT().FindById(gomock.Any(), int64(11)).
+					Return(domain.Comment{Id: 11, Biz: "article", BizId: 1, Uid: 456, RootId: 10, Pid: 10}, nil)
+				repo.EXPECT().Create(gomock.Any(), domain.Comment{
+					Biz: "article", BizId: 1, Uid: 123, RootId: 10, Pid: 11, Content: "回复",
+				}).Return(int64(12), nil)
+				intrSvc.EXPECT().IncrCommentCnt(gomock.Any(), gomock.Any()).Return(nil, errors.New("rpc error"))
+				producer := evtArtMock.NewMockProducer(ctrl)
+				producer.EXPECT().ProduceCommentEvent(gomock.Any(), events.CommentEvent{
+					Biz: "article", BizId: 1, Uid: 123, Cid: 12, ReplyTo: 456,
+				}).Return(errors.New("kafka error"))
+				return repo, artRepo, intrSvc, producer
+			},
+			cmt:    domain.Comment{BizId: 1, Uid: 123, Pid: 11, Content: "回复"},
+			wantId: 12,
+		},
+		{
+			name: "回复的评论不在这篇帖子下面",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, article.AuthorRepository, intrv1.InteractiveServiceClient, events.Producer) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := artrepomocks.NewMockAuthorRepository(ctrl)
+				artRepo.EXPECT().GetPublishedById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusPublished}, nil)
+				repo.EXPECT().FindById(gomock.Any(), int64(11)).
+					Return(domain.Comment{Id: 11, Biz: "article", BizId: 2}, nil)
+				return repo, artRepo, intrv1mocks.NewMockInteractiveServiceClient(ctrl), evtArtMock.NewMockProducer(ctrl)
+			},
+			cmt:     domain.Comment{BizId: 1, Uid: 123, Pid: 11, Content: "回复"},
+			wantErr: ErrCommentNotFound,
+		},
+		{
+			name: "帖子已经被删除",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, article.AuthorRepository, intrv1.InteractiveServiceClient, events.Producer) {
+				artRepo := artrepomocks.NewMockAuthorRepository(ctrl)
+				artRepo.EXPECT().GetPublishedById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusDeleted}, nil)
+				return repomocks.NewMockCommentRepository(ctrl), artRepo, intrv1mocks.NewMockInteractiveServiceClient(ctrl),
+					evtArtMock.NewMockProducer(ctrl)
+			},
+			cmt:     domain.Comment{BizId: 1, Uid: 123, Content: "评论"},
+			wantErr: ErrArticleNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo, intrSvc, producer := tc.mock(ctrl)
+			svc := NewCommentSvc(repo, artRepo, intrSvc, producer, &logger.NopLogger{})
+			id, err := svc.Create(context.Background(), tc.cmt)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func TestCommentSvc_Delete(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.CommentRepository, article.AuthorRepository, intrv1.InteractiveServiceClient)
+		id      int64
+		uid     int64
+		wantErr error
+	}{
+		{
+			name: "评论者删除自己的根评论，回复一起删掉",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, article.AuthorRepository, intrv1.InteractiveServiceClient) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				cmt := domain.Comment{Id: 10, Biz: "article", BizId: 1, Uid: 123}
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).Return(cmt, nil)
+				repo.EXPECT().Delete(gomock.Any(), cmt).Return(int64(3), nil)
+				intrSvc.EXPECT().IncrCommentCnt(gomock.Any(), &intrv1.IncrCommentCntRequest{
+					Biz: "article", BizId: 1, Delta: -3,
+				}).Return(&intrv1.IncrCommentCntResponse{}, nil)
+				return repo, artrepomocks.NewMockAuthorRepository(ctrl), intrSvc
+			},
+			id:  10,
+			uid: 123,
+		},
+		{
+			name: "帖子的作者删除别人的评论",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, article.AuthorRepository, intrv1.InteractiveServiceClient) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := artrepomocks.NewMockAuthorRepository(ctrl)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				cmt := domain.Comment{Id: 11, Biz: "article", BizId: 1, Uid: 123, RootId: 10}
+				repo.EXPECT().FindById(gomock.Any(), int64(11)).Return(cmt, nil)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 456}}, nil)
+				repo.EXPECT().Delete(gomock.Any(), cmt).Return(int64(1), nil)
+				intrSvc.EXPECT().IncrCommentCnt(gomock.Any(), &intrv1.IncrCommentCntRequest{
+					Biz: "article", BizId: 1, Delta: -1,
+				}).Return(&intrv1.IncrCommentCntResponse{}, nil)
+				return repo, artRepo, intrSvc
+			},
+			id:  11,
+			uid: 456,
+		},
+		{
+			name: "既不是评论者也不是作者",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, article.AuthorRepository, intrv1.InteractiveServiceClient) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := artrepomocks.NewMockAuthorRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(11)).
+					Return(domain.Comment{Id: 11, Biz: "article", BizId: 1, Uid: 123}, nil)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 456}}, nil)
+				return repo, artRepo, intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+			},
+			id:      11,
+			uid:     789,
+			wantErr: ErrCommentPermissionDenied,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo, intrSvc := tc.mock(ctrl)
+			svc := NewCommentSvc(repo, artRepo, intrSvc, evtArtMock.NewMockProducer(ctrl), &logger.NopLogger{})
+			err := svc.Delete(context.Background(), tc.id, tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestCommentSvc_Unpin(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.CommentRepository, article.AuthorRepository)
+		wantErr error
+	}{
+		{
+			name: "作者取消置顶",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, article.AuthorRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := artrepomocks.NewMockAuthorRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).
+					Return(domain.Comment{Id: 10, Biz: "article", BizId: 1, Pinned: true}, nil)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 456}}, nil)
+				repo.EXPECT().Unpin(gomock.Any(), int64(10)).Return(nil)
+				return repo, artRepo
+			},
+		},
+		{
+			name: "没有置顶",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, article.AuthorRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).
+					Return(domain.Comment{Id: 10, Biz: "article", BizId: 1}, nil)
+				return repo, artrepomocks.NewMockAuthorRepository(ctrl)
+			},
+			wantErr: ErrCommentNotFound,
+		},
+		{
+			name: "不是根评论",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, article.AuthorRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).
+					Return(domain.Comment{Id: 10, Biz: "article", BizId: 1, RootId: 9}, nil)
+				return repo, artrepomocks.NewMockAuthorRepository(ctrl)
+			},
+			wantErr: ErrCommentNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewCommentSvc(repo, artRepo, intrv1mocks.NewMockInteractiveServiceClient(ctrl),
+				evtArtMock.NewMockProducer(ctrl), &logger.NopLogger{})
+			err := svc.Unpin(context.Background(), 10, 456)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
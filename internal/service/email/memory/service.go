@@ -0,0 +1,18 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+)
+
+type Service struct {
+}
+
+func NewService() *Service {
+	return &Service{}
+}
+
+func (s *Service) Send(ctx context.Context, subject, content string, to ...string) error {
+	fmt.Println(subject, content)
+	return nil
+}
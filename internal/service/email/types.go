@@ -0,0 +1,8 @@
+package email
+
+import "context"
+
+type Service interface {
+	// Send 发送纯文本邮件
+	Send(ctx context.Context, subject, content string, to ...string) error
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+)
+
+// FeedService 关注的人发表的文章。
+// 粉丝少的作者发表的时候推到每一个粉丝的收件箱里面（推模型），
+// 粉丝多的作者不推，读的时候再去线上库拉（拉模型），两边合并起来就是关注流
+//
+//go:generate mockgen -source=feed.go -package=svcmocks -destination=mocks/feed.mock.go FeedService
+type FeedService interface {
+	// Push 文章发表之后调用，推到作者的粉丝的收件箱里面，粉丝多的作者什么都不做
+	Push(ctx context.Context, aid int64) error
+	// Following 关注的人公开发表的文章，按照 (utime, id) 倒序，从 cursor 的下一条开始。
+	// 只能看到关注之后才发表（或者重新发表）的文章
+	Following(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+}
+
+type feedSvc struct {
+	feedRepo   repository.FeedRepository
+	followRepo repository.FollowRepository
+	artRepo    article.AuthorRepository
+	// bigAuthor 粉丝数达到这个数就不推了，改成读的时候拉
+	bigAuthor int64
+	// batchSize 推的时候每一批查多少个粉丝
+	batchSize int
+}
+
+func NewFeedSvc(feedRepo repository.FeedRepository, followRepo repository.FollowRepository,
+	artRepo article.AuthorRepository) FeedService {
+	return &feedSvc{
+		feedRepo:   feedRepo,
+		followRepo: followRepo,
+		artRepo:    artRepo,
+		bigAuthor:  1000,
+		batchSize:  500,
+	}
+}
+
+func (f *feedSvc) Push(ctx context.Context, aid int64) error {
+	art, err := f.artRepo.GetPublishedById(ctx, aid)
+	if errors.Is(err, ErrArticleNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// 消费的时候可能已经删除或者改成仅自己可见了
+	if art.Status != domain.ArticleStatusPublished {
+		return nil
+	}
+	statics, err := f.followRepo.GetStatics(ctx, art.Author.Id)
+	if err != nil {
+		return err
+	}
+	if statics.Followers >= f.bigAuthor {
+		return nil
+	}
+	var maxId int64
+	for {
+		fs, er := f.followRepo.ListFollowers(ctx, art.Author.Id, maxId, f.batchSize)
+		if er != nil {
+			return er
+		}
+		if len(fs) > 0 {
+			uids := slice.Map[domain.FollowRelation, int64](fs, func(idx int, src domain.FollowRelation) int64 {
+				return src.Follower
+			})
+			if er = f.feedRepo.Push(ctx, uids, art); er != nil {
+				return er
+			}
+		}
+		if len(fs) < f.batchSize {
+			return nil
+		}
+		maxId = fs[len(fs)-1].Id
+	}
+}
+
+func (f *feedSvc) Following(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	var (
+		eg     errgroup.Group
+		pushed []domain.Article
+		pulled []domain.Article
+	)
+	eg.Go(func() error {
+		var err error
+		pushed, err = f.inbox(ctx, uid, cursor, limit)
+		return err
+	})
+	eg.Go(func() error {
+		rs, err := f.followRepo.ListBigFollowees(ctx, uid, f.bigAuthor)
+		if err != nil || len(rs) == 0 {
+			return err
+		}
+		// 只拉关注之后发表的
+		since := make(map[int64]time.Time, len(rs))
+		for _, r := range rs {
+			since[r.Followee] = r.Ctime
+		}
+		pulled, err = f.artRepo.ListPubByAuthors(ctx, since, cursor, limit)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return mergeFeed(limit, pushed, pulled), nil
+}
+
+// inbox 收件箱里面的文章，已经删除或者不再公开的跳过，不够 limit 篇就接着往后翻
+func (f *feedSvc) inbox(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	res := make([]domain.Article, 0, limit)
+	for len(res) < limit {
+		items, err := f.feedRepo.List(ctx, uid, cursor, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			break
+		}
+		arts, err := f.artRepo.ListPubByIds(ctx, slice.Map[domain.FeedItem, int64](items, func(idx int, src domain.FeedItem) int64 {
+			return src.Aid
+		}))
+		if err != nil {
+			return nil, err
+		}
+		artMap := make(map[int64]domain.Article, len(arts))
+		for _, art := range arts {
+			artMap[art.Id] = art
+		}
+		for _, item := range items {
+			if art, ok := artMap[item.Aid]; ok {
+				res = append(res, art)
+			}
+		}
+		if len(items) < limit {
+			break
+		}
+		last := items[len(items)-1]
+		cursor = domain.ArticleCursor{Utime: last.Utime, Id: last.Aid}
+	}
+	return res, nil
+}
+
+// mergeFeed 合并推过来的和拉过来的，按照 (utime, id) 倒序取前 limit 篇。
+// 作者的粉丝数涨过阈值之前推过来的文章，拉的时候也会拉到，所以要去重
+func mergeFeed(limit int, lists ...[]domain.Article) []domain.Article {
+	seen := make(map[int64]struct{})
+	var res []domain.Article
+	for _, list := range lists {
+		for _, art := range list {
+			if _, ok := seen[art.Id]; ok {
+				continue
+			}
+			seen[art.Id] = struct{}{}
+			res = append(res, art)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].Utime.Equal(res[j].Utime) {
+			return res[i].Utime.After(res[j].Utime)
+		}
+		return res[i].Id > res[j].Id
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res
+}
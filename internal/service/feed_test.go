@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+	artrepomocks "github.com/mrhelloboy/wehook/internal/repository/article/mocks"
+	repomocks "github.com/mrhelloboy/wehook/internal/repository/mocks"
+)
+
+func TestFeedSvc_Push(t *testing.T) {
+	art := domain.Article{
+		Id:     1,
+		Status: domain.ArticleStatusPublished,
+		Author: domain.Author{Id: 123},
+		Utime:  time.UnixMilli(100),
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.AuthorRepository)
+	}{
+		{
+			name: "粉丝少，分批推给所有粉丝",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.AuthorRepository) {
+				feedRepo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				artRepo := artrepomocks.NewMockAuthorRepository(ctrl)
+				artRepo.EXPECT().GetPublishedById(gomock.Any(), int64(1)).Return(art, nil)
+				followRepo.EXPECT().GetStatics(gomock.Any(), int64(123)).
+					Return(domain.FollowStatics{Uid: 123, Followers: 3}, nil)
+				followRepo.EXPECT().ListFollowers(gomock.Any(), int64(123), int64(0), 2).
+					Return([]domain.FollowRelation{{Id: 9, Follower: 11}, {Id: 8, Follower: 12}}, nil)
+				feedRepo.EXPECT().Push(gomock.Any(), []int64{11, 12}, art).Return(nil)
+				followRepo.EXPECT().ListFollowers(gomock.Any(), int64(123), int64(8), 2).
+					Return([]domain.FollowRelation{{Id: 7, Follower: 13}}, nil)
+				feedRepo.EXPECT().Push(gomock.Any(), []int64{13}, art).Return(nil)
+				return feedRepo, followRepo, artRepo
+			},
+		},
+		{
+			name: "粉丝多，不推",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.AuthorRepository) {
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				artRepo := artrepomocks.NewMockAuthorRepository(ctrl)
+				artRepo.EXPECT().GetPublishedById(gomock.Any(), int64(1)).Return(art, nil)
+				followRepo.EXPECT().GetStatics(gomock.Any(), int64(123)).
+					Return(domain.FollowStatics{Uid: 123, Followers: 10}, nil)
+				return repomocks.NewMockFeedRepository(ctrl), followRepo, artRepo
+			},
+		},
+		{
+			name: "文章已经不公开了",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.AuthorRepository) {
+				artRepo := artrepomocks.NewMockAuthorRepository(ctrl)
+				artRepo.EXPECT().GetPublishedById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusPrivate}, nil)
+				return repomocks.NewMockFeedRepository(ctrl), repomocks.NewMockFollowRepository(ctrl), artRepo
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			feedRepo, followRepo, artRepo := tc.mock(ctrl)
+			svc := &feedSvc{
+				feedRepo:   feedRepo,
+				followRepo: followRepo,
+				artRepo:    artRepo,
+				bigAuthor:  10,
+				batchSize:  2,
+			}
+			err := svc.Push(context.Background(), 1)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestFeedSvc_Following(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	feedRepo := repomocks.NewMockFeedRepository(ctrl)
+	followRepo := repomocks.NewMockFollowRepository(ctrl)
+	artRepo := artrepomocks.NewMockAuthorRepository(ctrl)
+
+	// 收件箱第一批里面 2 已经删除了，要接着往后翻
+	feedRepo.EXPECT().List(gomock.Any(), int64(1), domain.ArticleCursor{}, 2).Return([]domain.FeedItem{
+		{Aid: 5, Utime: time.UnixMilli(50)},
+		{Aid: 2, Utime: time.UnixMilli(20)},
+	}, nil)
+	artRepo.EXPECT().ListPubByIds(gomock.Any(), []int64{5, 2}).
+		Return([]domain.Article{{Id: 5, Utime: time.UnixMilli(50)}}, nil)
+	feedRepo.EXPECT().List(gomock.Any(), int64(1), domain.ArticleCursor{Utime: time.UnixMilli(20), Id: 2}, 2).
+		Return([]domain.FeedItem{{Aid: 1, Utime: time.UnixMilli(10)}}, nil)
+	artRepo.EXPECT().ListPubByIds(gomock.Any(), []int64{1}).
+		Return([]domain.Article{{Id: 1, Utime: time.UnixMilli(10)}}, nil)
+
+	// 大 V 的文章，只拉关注之后发表的，其中 5 是粉丝数涨上去之前推过的
+	followRepo.EXPECT().ListBigFollowees(gomock.Any(), int64(1), int64(1000)).
+		Return([]domain.FollowRelation{{Follower: 1, Followee: 99, Ctime: time.UnixMilli(25)}}, nil)
+	artRepo.EXPECT().ListPubByAuthors(gomock.Any(), map[int64]time.Time{99: time.UnixMilli(25)}, domain.ArticleCursor{}, 2).
+		Return([]domain.Article{{Id: 5, Utime: time.UnixMilli(50)}, {Id: 3, Utime: time.UnixMilli(30)}}, nil)
+
+	svc := NewFeedSvc(feedRepo, followRepo, artRepo)
+	arts, err := svc.Following(context.Background(), 1, domain.ArticleCursor{}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Article{
+		{Id: 5, Utime: time.UnixMilli(50)},
+		{Id: 3, Utime: time.UnixMilli(30)},
+	}, arts)
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+// ErrFollowSelf 不能关注自己
+var ErrFollowSelf = errors.New("不能关注自己")
+
+// FollowService 关注关系，关注和取消关注都是幂等的
+//
+//go:generate mockgen -source=follow.go -package=svcmocks -destination=mocks/follow.mock.go FollowService
+type FollowService interface {
+	Follow(ctx context.Context, follower, followee int64) error
+	// Unfollow 取消关注，收件箱里面这个人推过来的文章也一起删掉
+	Unfollow(ctx context.Context, follower, followee int64) error
+	Followed(ctx context.Context, follower, followee int64) (bool, error)
+	// ListFollowers 粉丝，最近关注的在前，maxId 为 0 表示第一页
+	ListFollowers(ctx context.Context, uid int64, maxId int64, limit int) ([]domain.FollowRelation, error)
+	// ListFollowees 关注的人，最近关注的在前，maxId 为 0 表示第一页
+	ListFollowees(ctx context.Context, uid int64, maxId int64, limit int) ([]domain.FollowRelation, error)
+	Statics(ctx context.Context, uid int64) (domain.FollowStatics, error)
+}
+
+type followSvc struct {
+	repo     repository.FollowRepository
+	userRepo repository.UserRepository
+	feedRepo repository.FeedRepository
+	l        logger.Logger
+}
+
+func NewFollowSvc(repo repository.FollowRepository, userRepo repository.UserRepository,
+	feedRepo repository.FeedRepository, l logger.Logger) FollowService {
+	return &followSvc{
+		repo:     repo,
+		userRepo: userRepo,
+		feedRepo: feedRepo,
+		l:        l,
+	}
+}
+
+func (f *followSvc) Follow(ctx context.Context, follower, followee int64) error {
+	if follower == followee {
+		return ErrFollowSelf
+	}
+	if _, err := f.userRepo.FindById(ctx, followee); err != nil {
+		return err
+	}
+	return f.repo.Follow(ctx, follower, followee)
+}
+
+func (f *followSvc) Unfollow(ctx context.Context, follower, followee int64) error {
+	if err := f.repo.Unfollow(ctx, follower, followee); err != nil {
+		return err
+	}
+	// 关注关系已经删掉了，收件箱删除失败只是会多看到几篇
+	if err := f.feedRepo.DeleteByAuthor(ctx, follower, followee); err != nil {
+		f.l.Warn("删除收件箱失败", logger.Int64("uid", follower),
+			logger.Int64("author", followee), logger.Error(err))
+	}
+	return nil
+}
+
+func (f *followSvc) Followed(ctx context.Context, follower, followee int64) (bool, error) {
+	return f.repo.Followed(ctx, follower, followee)
+}
+
+func (f *followSvc) ListFollowers(ctx context.Context, uid int64, maxId int64, limit int) ([]domain.FollowRelation, error) {
+	return f.repo.ListFollowers(ctx, uid, maxId, limit)
+}
+
+func (f *followSvc) ListFollowees(ctx context.Context, uid int64, maxId int64, limit int) ([]domain.FollowRelation, error) {
+	return f.repo.ListFollowees(ctx, uid, maxId, limit)
+}
+
+func (f *followSvc) Statics(ctx context.Context, uid int64) (domain.FollowStatics, error) {
+	return f.repo.GetStatics(ctx, uid)
+}
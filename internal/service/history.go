@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository"
+)
+
+// HistoryService 阅读记录，记录本身由消费阅读事件写入
+//
+//go:generate mockgen -source=history.go -package=svcmocks -destination=mocks/history.mock.go HistoryService
+type HistoryService interface {
+	List(ctx context.Context, uid int64, offset, limit int) ([]domain.HistoryRecord, error)
+	Delete(ctx context.Context, uid int64, biz string, bizId int64) error
+	Clear(ctx context.Context, uid int64) error
+}
+
+type historyService struct {
+	repo repository.HistoryRecordRepository
+}
+
+func NewHistoryService(repo repository.HistoryRecordRepository) HistoryService {
+	return &historyService{repo: repo}
+}
+
+func (h *historyService) List(ctx context.Context, uid int64, offset, limit int) ([]domain.HistoryRecord, error) {
+	return h.repo.List(ctx, uid, offset, limit)
+}
+
+func (h *historyService) Delete(ctx context.Context, uid int64, biz string, bizId int64) error {
+	return h.repo.Delete(ctx, uid, biz, bizId)
+}
+
+func (h *historyService) Clear(ctx context.Context, uid int64) error {
+	return h.repo.Clear(ctx, uid)
+}
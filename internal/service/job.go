@@ -10,9 +10,13 @@ import (
 	"github.com/mrhelloboy/wehook/internal/domain"
 )
 
+var ErrNoJob = repository.ErrNoJob
+
 type JobService interface {
 	Preempt(ctx context.Context) (domain.Job, error)
 	ResetNextTime(ctx context.Context, j domain.Job) error
+	// AddJob 注册一个定时任务，已经注册过就什么也不做
+	AddJob(ctx context.Context, j domain.Job) error
 }
 
 type cronJobSvc struct {
@@ -21,8 +25,23 @@ type cronJobSvc struct {
 	l               logger.Logger
 }
 
+func NewCronJobSvc(repo repository.JobRepository, l logger.Logger) JobService {
+	return &cronJobSvc{
+		repo:            repo,
+		refreshInterval: time.Minute,
+		l:               l,
+	}
+}
+
+func (c *cronJobSvc) AddJob(ctx context.Context, j domain.Job) error {
+	return c.repo.Add(ctx, j, j.NextTime())
+}
+
 func (c *cronJobSvc) Preempt(ctx context.Context) (domain.Job, error) {
 	j, err := c.repo.Preempt(ctx)
+	if err != nil {
+		return domain.Job{}, err
+	}
 
 	// 续约
 	ticker := time.NewTicker(c.refreshInterval)
@@ -39,7 +58,7 @@ func (c *cronJobSvc) Preempt(ctx context.Context) (domain.Job, error) {
 		defer cancel()
 		return c.repo.Release(ctx, j.Id)
 	}
-	return j, err
+	return j, nil
 }
 
 func (c *cronJobSvc) ResetNextTime(ctx context.Context, j domain.Job) error {
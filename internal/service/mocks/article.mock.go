@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: article.go
+// Source: internal/service/article.go
 //
 // Generated by this command:
 //
-//	mockgen -source=article.go -package=svcmocks -destination=mocks/article.mock.go ArticleService
+//	mockgen -source=internal/service/article.go -package=svcmocks -destination=internal/service/mocks/article.mock.go
 //
 
 // Package svcmocks is a generated GoMock package.
@@ -12,7 +12,6 @@ package svcmocks
 import (
 	context "context"
 	reflect "reflect"
-	time "time"
 
 	domain "github.com/mrhelloboy/wehook/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +40,36 @@ func (m *MockArticleService) EXPECT() *MockArticleServiceMockRecorder {
 	return m.recorder
 }
 
+// CountPubTags mocks base method.
+func (m *MockArticleService) CountPubTags(ctx context.Context, prefix string, limit int) ([]domain.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPubTags", ctx, prefix, limit)
+	ret0, _ := ret[0].([]domain.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPubTags indicates an expected call of CountPubTags.
+func (mr *MockArticleServiceMockRecorder) CountPubTags(ctx, prefix, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPubTags", reflect.TypeOf((*MockArticleService)(nil).CountPubTags), ctx, prefix, limit)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockArticleService) DiffRevisions(ctx context.Context, uid, from, to int64) (domain.ArticleRevisionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRevisions", ctx, uid, from, to)
+	ret0, _ := ret[0].(domain.ArticleRevisionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockArticleServiceMockRecorder) DiffRevisions(ctx, uid, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockArticleService)(nil).DiffRevisions), ctx, uid, from, to)
+}
+
 // GetById mocks base method.
 func (m *MockArticleService) GetById(ctx context.Context, id int64) (domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -72,33 +101,93 @@ func (mr *MockArticleServiceMockRecorder) GetPublishedById(ctx, id, uid any) *go
 }
 
 // List mocks base method.
-func (m *MockArticleService) List(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+func (m *MockArticleService) List(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, uid, offset, limit)
+	ret := m.ctrl.Call(m, "List", ctx, uid, cursor, limit)
 	ret0, _ := ret[0].([]domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockArticleServiceMockRecorder) List(ctx, uid, offset, limit any) *gomock.Call {
+func (mr *MockArticleServiceMockRecorder) List(ctx, uid, cursor, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleService)(nil).List), ctx, uid, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleService)(nil).List), ctx, uid, cursor, limit)
 }
 
 // ListPub mocks base method.
-func (m *MockArticleService) ListPub(ctx context.Context, start time.Time, offset, limit int) ([]domain.Article, error) {
+func (m *MockArticleService) ListPub(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPub", ctx, start, offset, limit)
+	ret := m.ctrl.Call(m, "ListPub", ctx, cursor, limit)
 	ret0, _ := ret[0].([]domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListPub indicates an expected call of ListPub.
-func (mr *MockArticleServiceMockRecorder) ListPub(ctx, start, offset, limit any) *gomock.Call {
+func (mr *MockArticleServiceMockRecorder) ListPub(ctx, cursor, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleService)(nil).ListPub), ctx, start, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleService)(nil).ListPub), ctx, cursor, limit)
+}
+
+// ListPubByAuthor mocks base method.
+func (m *MockArticleService) ListPubByAuthor(ctx context.Context, author int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByAuthor", ctx, author, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByAuthor indicates an expected call of ListPubByAuthor.
+func (mr *MockArticleServiceMockRecorder) ListPubByAuthor(ctx, author, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByAuthor", reflect.TypeOf((*MockArticleService)(nil).ListPubByAuthor), ctx, author, cursor, limit)
+}
+
+// ListPubByCategory mocks base method.
+func (m *MockArticleService) ListPubByCategory(ctx context.Context, category string, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByCategory", ctx, category, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByCategory indicates an expected call of ListPubByCategory.
+func (mr *MockArticleServiceMockRecorder) ListPubByCategory(ctx, category, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByCategory", reflect.TypeOf((*MockArticleService)(nil).ListPubByCategory), ctx, category, offset, limit)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockArticleService) ListPubByTag(ctx context.Context, tag string, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByTag", ctx, tag, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockArticleServiceMockRecorder) ListPubByTag(ctx, tag, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockArticleService)(nil).ListPubByTag), ctx, tag, offset, limit)
+}
+
+// ListRevisions mocks base method.
+func (m *MockArticleService) ListRevisions(ctx context.Context, artId, uid int64, offset, limit int) ([]domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, artId, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockArticleServiceMockRecorder) ListRevisions(ctx, artId, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockArticleService)(nil).ListRevisions), ctx, artId, uid, offset, limit)
 }
 
 // Publish mocks base method.
@@ -116,6 +205,21 @@ func (mr *MockArticleServiceMockRecorder) Publish(ctx, art any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockArticleService)(nil).Publish), ctx, art)
 }
 
+// RestoreRevision mocks base method.
+func (m *MockArticleService) RestoreRevision(ctx context.Context, uid, id int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", ctx, uid, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockArticleServiceMockRecorder) RestoreRevision(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockArticleService)(nil).RestoreRevision), ctx, uid, id)
+}
+
 // Save mocks base method.
 func (m *MockArticleService) Save(ctx context.Context, art domain.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -131,6 +235,21 @@ func (mr *MockArticleServiceMockRecorder) Save(ctx, art any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockArticleService)(nil).Save), ctx, art)
 }
 
+// Timeline mocks base method.
+func (m *MockArticleService) Timeline(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Timeline", ctx, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Timeline indicates an expected call of Timeline.
+func (mr *MockArticleServiceMockRecorder) Timeline(ctx, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Timeline", reflect.TypeOf((*MockArticleService)(nil).Timeline), ctx, cursor, limit)
+}
+
 // Withdraw mocks base method.
 func (m *MockArticleService) Withdraw(ctx context.Context, art domain.Article) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/article_schedule.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/article_schedule.go -package=svcmocks -destination=internal/service/mocks/article_schedule.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/mrhelloboy/wehook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleScheduleService is a mock of ArticleScheduleService interface.
+type MockArticleScheduleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleScheduleServiceMockRecorder
+}
+
+// MockArticleScheduleServiceMockRecorder is the mock recorder for MockArticleScheduleService.
+type MockArticleScheduleServiceMockRecorder struct {
+	mock *MockArticleScheduleService
+}
+
+// NewMockArticleScheduleService creates a new mock instance.
+func NewMockArticleScheduleService(ctrl *gomock.Controller) *MockArticleScheduleService {
+	mock := &MockArticleScheduleService{ctrl: ctrl}
+	mock.recorder = &MockArticleScheduleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleScheduleService) EXPECT() *MockArticleScheduleServiceMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockArticleScheduleService) Cancel(ctx context.Context, artId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, artId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockArticleScheduleServiceMockRecorder) Cancel(ctx, artId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockArticleScheduleService)(nil).Cancel), ctx, artId, uid)
+}
+
+// ListPending mocks base method.
+func (m *MockArticleScheduleService) ListPending(ctx context.Context, uid int64, offset, limit int) ([]domain.PublishSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.PublishSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockArticleScheduleServiceMockRecorder) ListPending(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockArticleScheduleService)(nil).ListPending), ctx, uid, offset, limit)
+}
+
+// PublishDue mocks base method.
+func (m *MockArticleScheduleService) PublishDue(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDue", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishDue indicates an expected call of PublishDue.
+func (mr *MockArticleScheduleServiceMockRecorder) PublishDue(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDue", reflect.TypeOf((*MockArticleScheduleService)(nil).PublishDue), ctx)
+}
+
+// Reschedule mocks base method.
+func (m *MockArticleScheduleService) Reschedule(ctx context.Context, artId, uid int64, publishTime time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reschedule", ctx, artId, uid, publishTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockArticleScheduleServiceMockRecorder) Reschedule(ctx, artId, uid, publishTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockArticleScheduleService)(nil).Reschedule), ctx, artId, uid, publishTime)
+}
+
+// SchedulePublish mocks base method.
+func (m *MockArticleScheduleService) SchedulePublish(ctx context.Context, art domain.Article, publishTime time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePublish", ctx, art, publishTime)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulePublish indicates an expected call of SchedulePublish.
+func (mr *MockArticleScheduleServiceMockRecorder) SchedulePublish(ctx, art, publishTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePublish", reflect.TypeOf((*MockArticleScheduleService)(nil).SchedulePublish), ctx, art, publishTime)
+}
@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/article_trash.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/article_trash.go -package=svcmocks -destination=internal/service/mocks/article_trash.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mrhelloboy/wehook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleTrashService is a mock of ArticleTrashService interface.
+type MockArticleTrashService struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleTrashServiceMockRecorder
+}
+
+// MockArticleTrashServiceMockRecorder is the mock recorder for MockArticleTrashService.
+type MockArticleTrashServiceMockRecorder struct {
+	mock *MockArticleTrashService
+}
+
+// NewMockArticleTrashService creates a new mock instance.
+func NewMockArticleTrashService(ctrl *gomock.Controller) *MockArticleTrashService {
+	mock := &MockArticleTrashService{ctrl: ctrl}
+	mock.recorder = &MockArticleTrashServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleTrashService) EXPECT() *MockArticleTrashServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockArticleTrashService) Delete(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleTrashServiceMockRecorder) Delete(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleTrashService)(nil).Delete), ctx, id, uid)
+}
+
+// ListTrash mocks base method.
+func (m *MockArticleTrashService) ListTrash(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockArticleTrashServiceMockRecorder) ListTrash(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockArticleTrashService)(nil).ListTrash), ctx, uid, offset, limit)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockArticleTrashService) PurgeExpired(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockArticleTrashServiceMockRecorder) PurgeExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockArticleTrashService)(nil).PurgeExpired), ctx)
+}
+
+// Restore mocks base method.
+func (m *MockArticleTrashService) Restore(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleTrashServiceMockRecorder) Restore(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleTrashService)(nil).Restore), ctx, id, uid)
+}
@@ -53,17 +53,31 @@ func (mr *MockCodeServiceMockRecorder) Send(ctx, biz, phone any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockCodeService)(nil).Send), ctx, biz, phone)
 }
 
+// SendEmail mocks base method.
+func (m *MockCodeService) SendEmail(ctx context.Context, biz, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmail", ctx, biz, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmail indicates an expected call of SendEmail.
+func (mr *MockCodeServiceMockRecorder) SendEmail(ctx, biz, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmail", reflect.TypeOf((*MockCodeService)(nil).SendEmail), ctx, biz, email)
+}
+
 // Verify mocks base method.
-func (m *MockCodeService) Verify(ctx context.Context, biz, phone, inputCode string) (bool, error) {
+func (m *MockCodeService) Verify(ctx context.Context, biz, target, inputCode string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Verify", ctx, biz, phone, inputCode)
+	ret := m.ctrl.Call(m, "Verify", ctx, biz, target, inputCode)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Verify indicates an expected call of Verify.
-func (mr *MockCodeServiceMockRecorder) Verify(ctx, biz, phone, inputCode any) *gomock.Call {
+func (mr *MockCodeServiceMockRecorder) Verify(ctx, biz, target, inputCode any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockCodeService)(nil).Verify), ctx, biz, phone, inputCode)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockCodeService)(nil).Verify), ctx, biz, target, inputCode)
 }
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/comment.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/comment.go -package=svcmocks -destination=internal/service/mocks/comment.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mrhelloboy/wehook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCommentService is a mock of CommentService interface.
+type MockCommentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentServiceMockRecorder
+}
+
+// MockCommentServiceMockRecorder is the mock recorder for MockCommentService.
+type MockCommentServiceMockRecorder struct {
+	mock *MockCommentService
+}
+
+// NewMockCommentService creates a new mock instance.
+func NewMockCommentService(ctrl *gomock.Controller) *MockCommentService {
+	mock := &MockCommentService{ctrl: ctrl}
+	mock.recorder = &MockCommentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentService) EXPECT() *MockCommentServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCommentService) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, c)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentServiceMockRecorder) Create(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentService)(nil).Create), ctx, c)
+}
+
+// Delete mocks base method.
+func (m *MockCommentService) Delete(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentServiceMockRecorder) Delete(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentService)(nil).Delete), ctx, id, uid)
+}
+
+// ListReplies mocks base method.
+func (m *MockCommentService) ListReplies(ctx context.Context, rootId, minId int64, limit int) ([]domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReplies", ctx, rootId, minId, limit)
+	ret0, _ := ret[0].([]domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReplies indicates an expected call of ListReplies.
+func (mr *MockCommentServiceMockRecorder) ListReplies(ctx, rootId, minId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReplies", reflect.TypeOf((*MockCommentService)(nil).ListReplies), ctx, rootId, minId, limit)
+}
+
+// ListRoots mocks base method.
+func (m *MockCommentService) ListRoots(ctx context.Context, aid, maxId int64, limit int) ([]domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoots", ctx, aid, maxId, limit)
+	ret0, _ := ret[0].([]domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoots indicates an expected call of ListRoots.
+func (mr *MockCommentServiceMockRecorder) ListRoots(ctx, aid, maxId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoots", reflect.TypeOf((*MockCommentService)(nil).ListRoots), ctx, aid, maxId, limit)
+}
+
+// Pin mocks base method.
+func (m *MockCommentService) Pin(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pin", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pin indicates an expected call of Pin.
+func (mr *MockCommentServiceMockRecorder) Pin(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pin", reflect.TypeOf((*MockCommentService)(nil).Pin), ctx, id, uid)
+}
+
+// Unpin mocks base method.
+func (m *MockCommentService) Unpin(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unpin", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unpin indicates an expected call of Unpin.
+func (mr *MockCommentServiceMockRecorder) Unpin(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unpin", reflect.TypeOf((*MockCommentService)(nil).Unpin), ctx, id, uid)
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/feed.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/feed.go -package=svcmocks -destination=internal/service/mocks/feed.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mrhelloboy/wehook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeedService is a mock of FeedService interface.
+type MockFeedService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedServiceMockRecorder
+}
+
+// MockFeedServiceMockRecorder is the mock recorder for MockFeedService.
+type MockFeedServiceMockRecorder struct {
+	mock *MockFeedService
+}
+
+// NewMockFeedService creates a new mock instance.
+func NewMockFeedService(ctrl *gomock.Controller) *MockFeedService {
+	mock := &MockFeedService{ctrl: ctrl}
+	mock.recorder = &MockFeedServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedService) EXPECT() *MockFeedServiceMockRecorder {
+	return m.recorder
+}
+
+// Following mocks base method.
+func (m *MockFeedService) Following(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Following", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Following indicates an expected call of Following.
+func (mr *MockFeedServiceMockRecorder) Following(ctx, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Following", reflect.TypeOf((*MockFeedService)(nil).Following), ctx, uid, cursor, limit)
+}
+
+// Push mocks base method.
+func (m *MockFeedService) Push(ctx context.Context, aid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Push", ctx, aid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockFeedServiceMockRecorder) Push(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockFeedService)(nil).Push), ctx, aid)
+}
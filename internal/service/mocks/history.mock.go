@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/history.go -package=svcmocks -destination=internal/service/mocks/history.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mrhelloboy/wehook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHistoryService is a mock of HistoryService interface.
+type MockHistoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryServiceMockRecorder
+}
+
+// MockHistoryServiceMockRecorder is the mock recorder for MockHistoryService.
+type MockHistoryServiceMockRecorder struct {
+	mock *MockHistoryService
+}
+
+// NewMockHistoryService creates a new mock instance.
+func NewMockHistoryService(ctrl *gomock.Controller) *MockHistoryService {
+	mock := &MockHistoryService{ctrl: ctrl}
+	mock.recorder = &MockHistoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryService) EXPECT() *MockHistoryServiceMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockHistoryService) Clear(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockHistoryServiceMockRecorder) Clear(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockHistoryService)(nil).Clear), ctx, uid)
+}
+
+// Delete mocks base method.
+func (m *MockHistoryService) Delete(ctx context.Context, uid int64, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, uid, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockHistoryServiceMockRecorder) Delete(ctx, uid, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHistoryService)(nil).Delete), ctx, uid, biz, bizId)
+}
+
+// List mocks base method.
+func (m *MockHistoryService) List(ctx context.Context, uid int64, offset, limit int) ([]domain.HistoryRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.HistoryRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockHistoryServiceMockRecorder) List(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHistoryService)(nil).List), ctx, uid, offset, limit)
+}
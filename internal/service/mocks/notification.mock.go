@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/notification.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/notification.go -package=svcmocks -destination=internal/service/mocks/notification.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mrhelloboy/wehook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationService is a mock of NotificationService interface.
+type MockNotificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationServiceMockRecorder
+}
+
+// MockNotificationServiceMockRecorder is the mock recorder for MockNotificationService.
+type MockNotificationServiceMockRecorder struct {
+	mock *MockNotificationService
+}
+
+// NewMockNotificationService creates a new mock instance.
+func NewMockNotificationService(ctrl *gomock.Controller) *MockNotificationService {
+	mock := &MockNotificationService{ctrl: ctrl}
+	mock.recorder = &MockNotificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationService) EXPECT() *MockNotificationServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockNotificationService) List(ctx context.Context, uid int64, offset, limit int) ([]domain.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockNotificationServiceMockRecorder) List(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNotificationService)(nil).List), ctx, uid, offset, limit)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotificationService) MarkAllRead(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationServiceMockRecorder) MarkAllRead(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotificationService)(nil).MarkAllRead), ctx, uid)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationService) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, uid, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationServiceMockRecorder) MarkRead(ctx, uid, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationService)(nil).MarkRead), ctx, uid, ids)
+}
+
+// Notify mocks base method.
+func (m *MockNotificationService) Notify(ctx context.Context, n domain.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, n)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotificationServiceMockRecorder) Notify(ctx, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotificationService)(nil).Notify), ctx, n)
+}
+
+// UnreadCnt mocks base method.
+func (m *MockNotificationService) UnreadCnt(ctx context.Context, uid int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnreadCnt", ctx, uid)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnreadCnt indicates an expected call of UnreadCnt.
+func (mr *MockNotificationServiceMockRecorder) UnreadCnt(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnreadCnt", reflect.TypeOf((*MockNotificationService)(nil).UnreadCnt), ctx, uid)
+}
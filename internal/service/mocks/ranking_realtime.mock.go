@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/ranking_realtime.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/ranking_realtime.go -package=svcmocks -destination=internal/service/mocks/ranking_realtime.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mrhelloboy/wehook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRealtimeRankingService is a mock of RealtimeRankingService interface.
+type MockRealtimeRankingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRealtimeRankingServiceMockRecorder
+}
+
+// MockRealtimeRankingServiceMockRecorder is the mock recorder for MockRealtimeRankingService.
+type MockRealtimeRankingServiceMockRecorder struct {
+	mock *MockRealtimeRankingService
+}
+
+// NewMockRealtimeRankingService creates a new mock instance.
+func NewMockRealtimeRankingService(ctrl *gomock.Controller) *MockRealtimeRankingService {
+	mock := &MockRealtimeRankingService{ctrl: ctrl}
+	mock.recorder = &MockRealtimeRankingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRealtimeRankingService) EXPECT() *MockRealtimeRankingServiceMockRecorder {
+	return m.recorder
+}
+
+// GetTopN mocks base method.
+func (m *MockRealtimeRankingService) GetTopN(ctx context.Context, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopN", ctx, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopN indicates an expected call of GetTopN.
+func (mr *MockRealtimeRankingServiceMockRecorder) GetTopN(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopN", reflect.TypeOf((*MockRealtimeRankingService)(nil).GetTopN), ctx, offset, limit)
+}
+
+// Incr mocks base method.
+func (m *MockRealtimeRankingService) Incr(ctx context.Context, aid int64, action domain.RankingAction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Incr", ctx, aid, action)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Incr indicates an expected call of Incr.
+func (mr *MockRealtimeRankingServiceMockRecorder) Incr(ctx, aid, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockRealtimeRankingService)(nil).Incr), ctx, aid, action)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/search.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/search.go -package=svcmocks -destination=internal/service/mocks/search.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/mrhelloboy/wehook/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSearchService is a mock of SearchService interface.
+type MockSearchService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchServiceMockRecorder
+}
+
+// MockSearchServiceMockRecorder is the mock recorder for MockSearchService.
+type MockSearchServiceMockRecorder struct {
+	mock *MockSearchService
+}
+
+// NewMockSearchService creates a new mock instance.
+func NewMockSearchService(ctrl *gomock.Controller) *MockSearchService {
+	mock := &MockSearchService{ctrl: ctrl}
+	mock.recorder = &MockSearchServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchService) EXPECT() *MockSearchServiceMockRecorder {
+	return m.recorder
+}
+
+// SearchAuthorArticles mocks base method.
+func (m *MockSearchService) SearchAuthorArticles(ctx context.Context, uid int64, q domain.ArticleSearchQuery) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAuthorArticles", ctx, uid, q)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAuthorArticles indicates an expected call of SearchAuthorArticles.
+func (mr *MockSearchServiceMockRecorder) SearchAuthorArticles(ctx, uid, q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAuthorArticles", reflect.TypeOf((*MockSearchService)(nil).SearchAuthorArticles), ctx, uid, q)
+}
+
+// SearchPubArticles mocks base method.
+func (m *MockSearchService) SearchPubArticles(ctx context.Context, q domain.ArticleSearchQuery) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPubArticles", ctx, q)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPubArticles indicates an expected call of SearchPubArticles.
+func (mr *MockSearchServiceMockRecorder) SearchPubArticles(ctx, q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPubArticles", reflect.TypeOf((*MockSearchService)(nil).SearchPubArticles), ctx, q)
+}
@@ -55,6 +55,21 @@ func (mr *MockUserServiceMockRecorder) FindOrCreate(ctx, phone any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreate", reflect.TypeOf((*MockUserService)(nil).FindOrCreate), ctx, phone)
 }
 
+// FindOrCreateByEmail mocks base method.
+func (m *MockUserService) FindOrCreateByEmail(ctx context.Context, email string) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrCreateByEmail", ctx, email)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrCreateByEmail indicates an expected call of FindOrCreateByEmail.
+func (mr *MockUserServiceMockRecorder) FindOrCreateByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreateByEmail", reflect.TypeOf((*MockUserService)(nil).FindOrCreateByEmail), ctx, email)
+}
+
 // FindOrCreateByWechat mocks base method.
 func (m *MockUserService) FindOrCreateByWechat(ctx context.Context, info domain.WechatInfo) (domain.User, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +115,36 @@ func (mr *MockUserServiceMockRecorder) Profile(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*MockUserService)(nil).Profile), ctx, id)
 }
 
+// ResetPassword mocks base method.
+func (m *MockUserService) ResetPassword(ctx context.Context, phone, password string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, phone, password)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUserServiceMockRecorder) ResetPassword(ctx, phone, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUserService)(nil).ResetPassword), ctx, phone, password)
+}
+
+// ResetPasswordByEmail mocks base method.
+func (m *MockUserService) ResetPasswordByEmail(ctx context.Context, email, password string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPasswordByEmail", ctx, email, password)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPasswordByEmail indicates an expected call of ResetPasswordByEmail.
+func (mr *MockUserServiceMockRecorder) ResetPasswordByEmail(ctx, email, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPasswordByEmail", reflect.TypeOf((*MockUserService)(nil).ResetPasswordByEmail), ctx, email, password)
+}
+
 // Signup mocks base method.
 func (m *MockUserService) Signup(ctx context.Context, u domain.User) error {
 	m.ctrl.T.Helper()
@@ -113,3 +158,59 @@ func (mr *MockUserServiceMockRecorder) Signup(ctx, u any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Signup", reflect.TypeOf((*MockUserService)(nil).Signup), ctx, u)
 }
+
+// UpdateEmail mocks base method.
+func (m *MockUserService) UpdateEmail(ctx context.Context, id int64, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmail", ctx, id, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmail indicates an expected call of UpdateEmail.
+func (mr *MockUserServiceMockRecorder) UpdateEmail(ctx, id, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmail", reflect.TypeOf((*MockUserService)(nil).UpdateEmail), ctx, id, email)
+}
+
+// UpdateNonSensitiveInfo mocks base method.
+func (m *MockUserService) UpdateNonSensitiveInfo(ctx context.Context, u domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNonSensitiveInfo", ctx, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNonSensitiveInfo indicates an expected call of UpdateNonSensitiveInfo.
+func (mr *MockUserServiceMockRecorder) UpdateNonSensitiveInfo(ctx, u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNonSensitiveInfo", reflect.TypeOf((*MockUserService)(nil).UpdateNonSensitiveInfo), ctx, u)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserService) UpdatePassword(ctx context.Context, id int64, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, id, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserServiceMockRecorder) UpdatePassword(ctx, id, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserService)(nil).UpdatePassword), ctx, id, password)
+}
+
+// UpdatePhone mocks base method.
+func (m *MockUserService) UpdatePhone(ctx context.Context, id int64, phone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePhone", ctx, id, phone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePhone indicates an expected call of UpdatePhone.
+func (mr *MockUserServiceMockRecorder) UpdatePhone(ctx, id, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePhone", reflect.TypeOf((*MockUserService)(nil).UpdatePhone), ctx, id, phone)
+}
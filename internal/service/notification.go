@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+// NotificationService 站内通知，由点赞、收藏、评论这些交互事件触发
+//
+//go:generate mockgen -source=notification.go -package=svcmocks -destination=mocks/notification.mock.go NotificationService
+type NotificationService interface {
+	// Notify n.LastActor 对资源做了 n.Type 这个动作，Uid 为 0 表示通知资源的作者。
+	// 自己对自己的资源做的动作不通知
+	Notify(ctx context.Context, n domain.Notification) error
+	// List 最近有更新的在前，LastActorName 会补上
+	List(ctx context.Context, uid int64, offset, limit int) ([]domain.Notification, error)
+	MarkRead(ctx context.Context, uid int64, ids []int64) error
+	MarkAllRead(ctx context.Context, uid int64) error
+	UnreadCnt(ctx context.Context, uid int64) (int64, error)
+}
+
+type notificationSvc struct {
+	repo     repository.NotificationRepository
+	artRepo  article.AuthorRepository
+	userRepo repository.UserRepository
+	l        logger.Logger
+}
+
+func NewNotificationSvc(repo repository.NotificationRepository, artRepo article.AuthorRepository,
+	userRepo repository.UserRepository, l logger.Logger) NotificationService {
+	return &notificationSvc{
+		repo:     repo,
+		artRepo:  artRepo,
+		userRepo: userRepo,
+		l:        l,
+	}
+}
+
+func (s *notificationSvc) Notify(ctx context.Context, n domain.Notification) error {
+	if n.Uid == 0 {
+		uid, err := s.owner(ctx, n.Biz, n.BizId)
+		if err != nil {
+			return err
+		}
+		n.Uid = uid
+	}
+	if n.Uid == 0 || n.Uid == n.LastActor {
+		return nil
+	}
+	return s.repo.Add(ctx, n)
+}
+
+// owner 资源的作者，资源已经删除了或者不认识的资源返回 0
+func (s *notificationSvc) owner(ctx context.Context, biz string, bizId int64) (int64, error) {
+	if biz != "article" {
+		return 0, nil
+	}
+	art, err := s.artRepo.GetById(ctx, bizId)
+	if errors.Is(err, ErrArticleNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return art.Author.Id, nil
+}
+
+func (s *notificationSvc) List(ctx context.Context, uid int64, offset, limit int) ([]domain.Notification, error) {
+	ns, err := s.repo.List(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	s.fillActors(ctx, ns)
+	return ns, nil
+}
+
+// fillActors 补上最近一个触发通知的人的昵称，同一个人只查一次。查不到只记录日志，昵称留空
+func (s *notificationSvc) fillActors(ctx context.Context, ns []domain.Notification) {
+	names := make(map[int64]string, len(ns))
+	for i := range ns {
+		id := ns[i].LastActor
+		name, ok := names[id]
+		if !ok {
+			u, err := s.userRepo.FindById(ctx, id)
+			if err != nil {
+				s.l.Warn("查询用户失败", logger.Int64("uid", id), logger.Error(err))
+			}
+			name = u.Nickname
+			names[id] = name
+		}
+		ns[i].LastActorName = name
+	}
+}
+
+func (s *notificationSvc) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	return s.repo.MarkRead(ctx, uid, ids)
+}
+
+func (s *notificationSvc) MarkAllRead(ctx context.Context, uid int64) error {
+	return s.repo.MarkAllRead(ctx, uid)
+}
+
+func (s *notificationSvc) UnreadCnt(ctx context.Context, uid int64) (int64, error) {
+	return s.repo.CountUnread(ctx, uid)
+}
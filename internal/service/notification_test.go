@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+	artrepomocks "github.com/mrhelloboy/wehook/internal/repository/article/mocks"
+	repomocks "github.com/mrhelloboy/wehook/internal/repository/mocks"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+func TestNotificationSvc_Notify(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.NotificationRepository, article.AuthorRepository)
+		n       domain.Notification
+		wantErr error
+	}{
+		{
+			name: "通知帖子的作者",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, article.AuthorRepository) {
+				repo := repomocks.NewMockNotificationRepository(ctrl)
+				artRepo := artrepomocks.NewMockAuthorRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().Add(gomock.Any(), domain.Notification{
+					Uid: 123, Type: domain.NotificationTypeLike, Biz: "article", BizId: 1, LastActor: 456,
+				}).Return(nil)
+				return repo, artRepo
+			},
+			n: domain.Notification{Type: domain.NotificationTypeLike, Biz: "article", BizId: 1, LastActor: 456},
+		},
+		{
+			name: "给自己的帖子点赞，不通知",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, article.AuthorRepository) {
+				artRepo := artrepomocks.NewMockAuthorRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				return repomocks.NewMockNotificationRepository(ctrl), artRepo
+			},
+			n: domain.Notification{Type: domain.NotificationTypeLike, Biz: "article", BizId: 1, LastActor: 123},
+		},
+		{
+			name: "帖子已经删除了，不通知",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, article.AuthorRepository) {
+				artRepo := artrepomocks.NewMockAuthorRepository(ctrl)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(1)).Return(domain.Article{}, ErrArticleNotFound)
+				return repomocks.NewMockNotificationRepository(ctrl), artRepo
+			},
+			n: domain.Notification{Type: domain.NotificationTypeCollect, Biz: "article", BizId: 1, LastActor: 456},
+		},
+		{
+			name: "回复直接通知被回复的人，不用查帖子",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, article.AuthorRepository) {
+				repo := repomocks.NewMockNotificationRepository(ctrl)
+				repo.EXPECT().Add(gomock.Any(), domain.Notification{
+					Uid: 789, Type: domain.NotificationTypeReply, Biz: "article", BizId: 1, LastActor: 456,
+				}).Return(nil)
+				return repo, artrepomocks.NewMockAuthorRepository(ctrl)
+			},
+			n: domain.Notification{Uid: 789, Type: domain.NotificationTypeReply, Biz: "article", BizId: 1, LastActor: 456},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewNotificationSvc(repo, artRepo, repomocks.NewMockUserRepository(ctrl), &logger.NopLogger{})
+			err := svc.Notify(context.Background(), tc.n)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
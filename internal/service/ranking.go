@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"errors"
-	"math"
 	"time"
 
 	intrv1 "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1"
@@ -16,49 +15,73 @@ import (
 	"github.com/mrhelloboy/wehook/internal/domain"
 )
 
+// ErrUnknownRanking 没有注册过的榜单
+var ErrUnknownRanking = errors.New("未知的榜单")
+
 type RankingService interface {
-	TopN(ctx context.Context) error
+	// TopN 计算指定榜单的前 N 名
+	TopN(ctx context.Context, name string) error
+	// GetTopN 分页获取指定榜单的数据
+	GetTopN(ctx context.Context, name string, offset, limit int) ([]domain.Article, error)
 }
 
 type BatchRankingSrv struct {
-	artSvc    ArticleService
-	intrSvc   intrv1.InteractiveServiceClient
-	repo      repository.RankingRepository
-	batchSize int
-	n         int
-	scoreFunc func(t time.Time, likeCnt int64) float64 // 不能返回负数
-	load      int64                                    // 负载
+	artSvc     ArticleService
+	intrSvc    intrv1.InteractiveServiceClient
+	repo       repository.RankingRepository
+	strategies RankingStrategies
+	batchSize  int
+	n          int
+	load       int64 // 负载
 }
 
-func NewBatchRankingSrv(artSvc ArticleService, intrSvc intrv1.InteractiveServiceClient, repo repository.RankingRepository) RankingService {
+func NewBatchRankingSrv(artSvc ArticleService, intrSvc intrv1.InteractiveServiceClient,
+	repo repository.RankingRepository, strategies RankingStrategies) RankingService {
 	return &BatchRankingSrv{
-		artSvc:    artSvc,
-		intrSvc:   intrSvc,
-		repo:      repo,
-		batchSize: 100,
-		n:         100,
-		scoreFunc: func(t time.Time, likeCnt int64) float64 {
-			// 假设 likeCnt 是点赞数，t 是文章发布时间
-			// 可以根据需要定义自己的评分函数
-			sec := time.Since(t).Seconds()
-			return float64(likeCnt-1) / math.Pow(sec+2, 1.5)
-		},
+		artSvc:     artSvc,
+		intrSvc:    intrSvc,
+		repo:       repo,
+		strategies: strategies,
+		batchSize:  100,
+		n:          100,
 	}
 }
 
-func (s *BatchRankingSrv) TopN(ctx context.Context) error {
-	arts, err := s.topN(ctx)
+func (s *BatchRankingSrv) TopN(ctx context.Context, name string) error {
+	strategy, ok := s.strategies[name]
+	if !ok {
+		return ErrUnknownRanking
+	}
+	arts, err := s.topN(ctx, strategy)
 	if err != nil {
 		return err
 	}
-	return s.repo.ReplaceTopN(ctx, arts)
+	return s.repo.ReplaceTopN(ctx, name, arts)
+}
+
+func (s *BatchRankingSrv) GetTopN(ctx context.Context, name string, offset, limit int) ([]domain.Article, error) {
+	if _, ok := s.strategies[name]; !ok {
+		return nil, ErrUnknownRanking
+	}
+	arts, err := s.repo.GetTopN(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if offset >= len(arts) {
+		return []domain.Article{}, nil
+	}
+	end := offset + limit
+	if end > len(arts) {
+		end = len(arts)
+	}
+	return arts[offset:end], nil
 }
 
-func (s *BatchRankingSrv) topN(ctx context.Context) ([]domain.Article, error) {
-	// 只取 7 天内的数据
+func (s *BatchRankingSrv) topN(ctx context.Context, strategy RankingStrategy) ([]domain.Article, error) {
+	// 只取时间窗口内的数据
 	now := time.Now()
-	// 先拿一批数据
-	offset := 0
+	// 先拿一批数据，从 now 开始往前翻
+	cursor := domain.ArticleCursor{Utime: now}
 	type Score struct {
 		art   domain.Article
 		score float64
@@ -76,10 +99,14 @@ func (s *BatchRankingSrv) topN(ctx context.Context) ([]domain.Article, error) {
 
 	for {
 		// 这里拿了一批
-		arts, err := s.artSvc.ListPub(ctx, now, offset, s.batchSize)
+		arts, err := s.artSvc.ListPub(ctx, cursor, s.batchSize)
 		if err != nil {
 			return nil, err
 		}
+		if len(arts) == 0 {
+			// 上一批刚好取完
+			break
+		}
 		ids := slice.Map[domain.Article, int64](arts, func(idx int, art domain.Article) int64 {
 			return art.Id
 		})
@@ -97,8 +124,12 @@ func (s *BatchRankingSrv) topN(ctx context.Context) ([]domain.Article, error) {
 		// 合并计算 score
 		// 排序
 		for _, art := range arts {
+			if now.Sub(art.Utime) > strategy.Window {
+				// 已经超出时间窗口了
+				continue
+			}
 			intr := intrs.Intrs[art.Id]
-			score := s.scoreFunc(art.Utime, intr.LikeCnt)
+			score := strategy.Score(art.Utime, intr)
 			// 考虑这个 score 在不在前 100 名内
 			// 拿到热度最低的
 			err = topN.Enqueue(Score{art: art, score: score})
@@ -114,17 +145,19 @@ func (s *BatchRankingSrv) topN(ctx context.Context) ([]domain.Article, error) {
 		}
 
 		// 处理完一批数据，要不要进入下一批？
-		if len(arts) < s.batchSize || now.Sub(arts[len(arts)-1].Utime).Hours() > 7*24 {
+		if len(arts) < s.batchSize || now.Sub(arts[len(arts)-1].Utime) > strategy.Window {
 			// 这一批都没有取够，当前肯定没有下一批了
-			// 或者已经取到了 7 天之前的数据了，说明可以中断了
+			// 或者已经取到了时间窗口之前的数据了，说明可以中断了
 			break
 		}
-		offset = offset + len(arts)
+		last := arts[len(arts)-1]
+		cursor = domain.ArticleCursor{Utime: last.Utime, Id: last.Id}
 	}
 
 	// 最后得出结果
 	res := make([]domain.Article, s.n)
-	for i := s.n - 1; i >= 0; i-- {
+	i := s.n - 1
+	for ; i >= 0; i-- {
 		val, err := topN.Dequeue()
 		if err != nil {
 			// 说明取完了，不够 n
@@ -132,5 +165,6 @@ func (s *BatchRankingSrv) topN(ctx context.Context) ([]domain.Article, error) {
 		}
 		res[i] = val.art
 	}
-	return res, nil
+	// 不够 n 的时候，前面会留下空位，需要去掉
+	return res[i+1:], nil
 }
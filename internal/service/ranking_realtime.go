@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ecodeclub/ekit/slice"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+// RankingRealtime 实时榜单，由交互事件增量计算，不走定时任务
+const RankingRealtime = "realtime"
+
+// RealtimeRankingService 实时榜单
+//
+//go:generate mockgen -source=ranking_realtime.go -package=svcmocks -destination=mocks/ranking_realtime.mock.go RealtimeRankingService
+type RealtimeRankingService interface {
+	// Incr 记录一次交互行为，实时更新文章分数
+	Incr(ctx context.Context, aid int64, action domain.RankingAction) error
+	GetTopN(ctx context.Context, offset, limit int) ([]domain.Article, error)
+}
+
+type realtimeRankingSrv struct {
+	repo       repository.RankingRepository
+	authorRepo article.AuthorRepository
+	// weights 每种行为对应的分数，取消类的行为是负分
+	weights map[domain.RankingAction]float64
+	l       logger.Logger
+}
+
+func NewRealtimeRankingSrv(repo repository.RankingRepository, authorRepo article.AuthorRepository, l logger.Logger) RealtimeRankingService {
+	return &realtimeRankingSrv{
+		repo:       repo,
+		authorRepo: authorRepo,
+		// 和 trending-24h 榜单保持一致
+		weights: map[domain.RankingAction]float64{
+			domain.RankingActionRead:          1,
+			domain.RankingActionLike:          3,
+			domain.RankingActionCancelLike:    -3,
+			domain.RankingActionCollect:       5,
+			domain.RankingActionCancelCollect: -5,
+		},
+		l: l,
+	}
+}
+
+func (r *realtimeRankingSrv) Incr(ctx context.Context, aid int64, action domain.RankingAction) error {
+	delta, ok := r.weights[action]
+	if !ok {
+		return nil
+	}
+	return r.repo.IncrRealtimeScore(ctx, aid, delta)
+}
+
+func (r *realtimeRankingSrv) GetTopN(ctx context.Context, offset, limit int) ([]domain.Article, error) {
+	ids, err := r.repo.GetRealtimeTopN(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	arts := make([]domain.Article, len(ids))
+	var eg errgroup.Group
+	for i, id := range ids {
+		i, id := i, id
+		eg.Go(func() error {
+			art, er := r.authorRepo.GetPublishedById(ctx, id)
+			if er != nil {
+				// 文章可能已经撤回了，跳过就可以
+				r.l.Warn("实时榜单获取文章失败", logger.Int64("aid", id), logger.Error(er))
+				return nil
+			}
+			arts[i] = art
+			return nil
+		})
+	}
+	_ = eg.Wait()
+	return slice.FilterMap[domain.Article, domain.Article](arts, func(idx int, src domain.Article) (domain.Article, bool) {
+		return src, src.Id > 0
+	}), nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+	artrepomocks "github.com/mrhelloboy/wehook/internal/repository/article/mocks"
+	repomocks "github.com/mrhelloboy/wehook/internal/repository/mocks"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRealtimeRankingSrv_GetTopN(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) (repository.RankingRepository, article.AuthorRepository)
+		wantErr  error
+		wantArts []domain.Article
+	}{
+		{
+			name: "查询成功，跳过已经撤回的文章",
+			mock: func(ctrl *gomock.Controller) (repository.RankingRepository, article.AuthorRepository) {
+				repo := repomocks.NewMockRankingRepository(ctrl)
+				repo.EXPECT().GetRealtimeTopN(gomock.Any(), 0, 3).Return([]int64{3, 2, 1}, nil)
+				authorRepo := artrepomocks.NewMockAuthorRepository(ctrl)
+				authorRepo.EXPECT().GetPublishedById(gomock.Any(), int64(3)).Return(domain.Article{Id: 3}, nil)
+				authorRepo.EXPECT().GetPublishedById(gomock.Any(), int64(2)).Return(domain.Article{}, errors.New("not found"))
+				authorRepo.EXPECT().GetPublishedById(gomock.Any(), int64(1)).Return(domain.Article{Id: 1}, nil)
+				return repo, authorRepo
+			},
+			wantArts: []domain.Article{{Id: 3}, {Id: 1}},
+		},
+		{
+			name: "缓存出错",
+			mock: func(ctrl *gomock.Controller) (repository.RankingRepository, article.AuthorRepository) {
+				repo := repomocks.NewMockRankingRepository(ctrl)
+				repo.EXPECT().GetRealtimeTopN(gomock.Any(), 0, 3).Return(nil, errors.New("redis error"))
+				return repo, artrepomocks.NewMockAuthorRepository(ctrl)
+			},
+			wantErr: errors.New("redis error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, authorRepo := tc.mock(ctrl)
+			svc := NewRealtimeRankingSrv(repo, authorRepo, &logger.NopLogger{})
+			arts, err := svc.GetTopN(context.Background(), 0, 3)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArts, arts)
+		})
+	}
+}
+
+func TestRealtimeRankingSrv_Incr(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockRankingRepository(ctrl)
+	repo.EXPECT().IncrRealtimeScore(gomock.Any(), int64(1), float64(3)).Return(nil)
+	repo.EXPECT().IncrRealtimeScore(gomock.Any(), int64(1), float64(-5)).Return(nil)
+	svc := NewRealtimeRankingSrv(repo, nil, &logger.NopLogger{})
+	assert.NoError(t, svc.Incr(context.Background(), 1, domain.RankingActionLike))
+	assert.NoError(t, svc.Incr(context.Background(), 1, domain.RankingActionCancelCollect))
+	// 未知的行为直接忽略
+	assert.NoError(t, svc.Incr(context.Background(), 1, domain.RankingActionUnknown))
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	intrv1 "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1"
+)
+
+// 内置的榜单名
+const (
+	RankingHot           = "hot"
+	RankingTrending24h   = "trending-24h"
+	RankingMostCollected = "most-collected"
+	RankingMostRead      = "most-read"
+)
+
+// RankingStrategy 榜单的计算策略
+type RankingStrategy struct {
+	// Name 榜单名，缓存 key 和定时任务都按照它来区分
+	Name string
+	// Window 只计算这段时间内更新过的文章
+	Window time.Duration
+	// Score 根据文章更新时间和阅读、点赞、收藏数计算分数，不能返回负数
+	Score func(t time.Time, intr *intrv1.Interactive) float64
+}
+
+// RankingStrategies 榜单策略注册表，key 是榜单名
+type RankingStrategies map[string]RankingStrategy
+
+// Register 注册榜单，同名的会被覆盖
+func (r RankingStrategies) Register(s RankingStrategy) {
+	r[s.Name] = s
+}
+
+// DefaultRankingStrategies 内置的四个榜单
+func DefaultRankingStrategies() RankingStrategies {
+	res := RankingStrategies{}
+	// 综合热榜，按点赞数和时间衰减计算
+	res.Register(RankingStrategy{
+		Name:   RankingHot,
+		Window: time.Hour * 24 * 7,
+		Score: func(t time.Time, intr *intrv1.Interactive) float64 {
+			sec := time.Since(t).Seconds()
+			return float64(intr.GetLikeCnt()-1) / math.Pow(sec+2, 1.5)
+		},
+	})
+	// 24 小时飙升榜，阅读、点赞、收藏加权，衰减更快
+	res.Register(RankingStrategy{
+		Name:   RankingTrending24h,
+		Window: time.Hour * 24,
+		Score: func(t time.Time, intr *intrv1.Interactive) float64 {
+			hours := time.Since(t).Hours()
+			weighted := float64(intr.GetReadCnt() + intr.GetLikeCnt()*3 + intr.GetCollectCnt()*5)
+			return weighted / math.Pow(hours+2, 1.8)
+		},
+	})
+	// 收藏榜
+	res.Register(RankingStrategy{
+		Name:   RankingMostCollected,
+		Window: time.Hour * 24 * 7,
+		Score: func(t time.Time, intr *intrv1.Interactive) float64 {
+			return float64(intr.GetCollectCnt())
+		},
+	})
+	// 阅读榜
+	res.Register(RankingStrategy{
+		Name:   RankingMostRead,
+		Window: time.Hour * 24 * 7,
+		Score: func(t time.Time, intr *intrv1.Interactive) float64 {
+			return float64(intr.GetReadCnt())
+		},
+	})
+	return res
+}
@@ -2,14 +2,16 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	intrv1 "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1"
-
-	domain2 "github.com/mrhelloboy/wehook/interactive/domain"
+	intrv1mocks "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1/mocks"
 
 	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository"
+	repomocks "github.com/mrhelloboy/wehook/internal/repository/mocks"
 	svcmocks "github.com/mrhelloboy/wehook/internal/service/mocks"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -27,19 +29,23 @@ func TestBatchRankingSrv_TopN(t *testing.T) {
 			name: "计算成功",
 			mock: func(ctrl *gomock.Controller) (ArticleService, intrv1.InteractiveServiceClient) {
 				artSvc := svcmocks.NewMockArticleService(ctrl)
-				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 0, 3).Return([]domain.Article{
+				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 3).Return([]domain.Article{
 					{Id: 1, Utime: now, Ctime: now},
 					{Id: 2, Utime: now, Ctime: now},
 					{Id: 3, Utime: now, Ctime: now},
 				}, nil)
-				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 3, 3).Return([]domain.Article{}, nil)
-				intrSvc := svcmocks.NewMockInteractiveService(ctrl)
-				intrSvc.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2, 3}).Return(map[int64]domain2.Interactive{
-					1: {BizId: 1, LikeCnt: 1},
-					2: {BizId: 2, LikeCnt: 2},
-					3: {BizId: 3, LikeCnt: 3},
+				// 下一批从上一批的最后一篇开始
+				artSvc.EXPECT().ListPub(gomock.Any(), domain.ArticleCursor{Utime: now, Id: 3}, 3).Return([]domain.Article{}, nil)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				intrSvc.EXPECT().GetByIds(gomock.Any(), &intrv1.GetByIdsRequest{
+					Biz: "article", Ids: []int64{1, 2, 3},
+				}).Return(&intrv1.GetByIdsResponse{
+					Intrs: map[int64]*intrv1.Interactive{
+						1: {BizId: 1, LikeCnt: 1},
+						2: {BizId: 2, LikeCnt: 2},
+						3: {BizId: 3, LikeCnt: 3},
+					},
 				}, nil)
-				intrSvc.EXPECT().GetByIds(gomock.Any(), "article", []int64{}).Return(map[int64]domain2.Interactive{}, nil)
 				return artSvc, intrSvc
 			},
 			wantArts: []domain.Article{
@@ -48,20 +54,129 @@ func TestBatchRankingSrv_TopN(t *testing.T) {
 				{Id: 1, Utime: now, Ctime: now},
 			},
 		},
+		{
+			name: "数据不足 n 条",
+			mock: func(ctrl *gomock.Controller) (ArticleService, intrv1.InteractiveServiceClient) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 3).Return([]domain.Article{
+					{Id: 1, Utime: now, Ctime: now},
+					{Id: 2, Utime: now, Ctime: now},
+				}, nil)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				intrSvc.EXPECT().GetByIds(gomock.Any(), &intrv1.GetByIdsRequest{
+					Biz: "article", Ids: []int64{1, 2},
+				}).Return(&intrv1.GetByIdsResponse{
+					Intrs: map[int64]*intrv1.Interactive{
+						1: {BizId: 1, LikeCnt: 1},
+						2: {BizId: 2, LikeCnt: 2},
+					},
+				}, nil)
+				return artSvc, intrSvc
+			},
+			wantArts: []domain.Article{
+				{Id: 2, Utime: now, Ctime: now},
+				{Id: 1, Utime: now, Ctime: now},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			artSvc, intrSvc := tc.mock(ctrl)
-			svc := NewBatchRankingSrv(artSvc, intrSvc, nil).(*BatchRankingSrv)
-			// 为了方便测试，修改batchSize，n 和 scoreFunc
+			svc := NewBatchRankingSrv(artSvc, intrSvc, nil, DefaultRankingStrategies()).(*BatchRankingSrv)
+			// 为了方便测试，修改batchSize，n 和 score 计算方式
 			svc.batchSize = 3
 			svc.n = 3
-			svc.scoreFunc = func(t time.Time, likeCnt int64) float64 {
-				return float64(likeCnt)
-			}
-			arts, err := svc.topN(context.Background())
+			arts, err := svc.topN(context.Background(), RankingStrategy{
+				Name:   "test",
+				Window: time.Hour,
+				Score: func(t time.Time, intr *intrv1.Interactive) float64 {
+					return float64(intr.GetLikeCnt())
+				},
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArts, arts)
+		})
+	}
+}
+
+func TestBatchRankingSrv_GetTopN(t *testing.T) {
+	topN := []domain.Article{{Id: 3}, {Id: 2}, {Id: 1}}
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) repository.RankingRepository
+		ranking  string
+		offset   int
+		limit    int
+		wantErr  error
+		wantArts []domain.Article
+	}{
+		{
+			name: "第一页",
+			mock: func(ctrl *gomock.Controller) repository.RankingRepository {
+				repo := repomocks.NewMockRankingRepository(ctrl)
+				repo.EXPECT().GetTopN(gomock.Any(), RankingHot).Return(topN, nil)
+				return repo
+			},
+			ranking:  RankingHot,
+			offset:   0,
+			limit:    2,
+			wantArts: []domain.Article{{Id: 3}, {Id: 2}},
+		},
+		{
+			name: "最后一页不足 limit",
+			mock: func(ctrl *gomock.Controller) repository.RankingRepository {
+				repo := repomocks.NewMockRankingRepository(ctrl)
+				repo.EXPECT().GetTopN(gomock.Any(), RankingHot).Return(topN, nil)
+				return repo
+			},
+			ranking:  RankingHot,
+			offset:   2,
+			limit:    2,
+			wantArts: []domain.Article{{Id: 1}},
+		},
+		{
+			name: "超出范围",
+			mock: func(ctrl *gomock.Controller) repository.RankingRepository {
+				repo := repomocks.NewMockRankingRepository(ctrl)
+				repo.EXPECT().GetTopN(gomock.Any(), RankingHot).Return(topN, nil)
+				return repo
+			},
+			ranking:  RankingHot,
+			offset:   3,
+			limit:    2,
+			wantArts: []domain.Article{},
+		},
+		{
+			name: "缓存出错",
+			mock: func(ctrl *gomock.Controller) repository.RankingRepository {
+				repo := repomocks.NewMockRankingRepository(ctrl)
+				repo.EXPECT().GetTopN(gomock.Any(), RankingHot).Return(nil, errors.New("cache error"))
+				return repo
+			},
+			ranking: RankingHot,
+			offset:  0,
+			limit:   2,
+			wantErr: errors.New("cache error"),
+		},
+		{
+			name: "未知的榜单",
+			mock: func(ctrl *gomock.Controller) repository.RankingRepository {
+				return repomocks.NewMockRankingRepository(ctrl)
+			},
+			ranking: "unknown",
+			offset:  0,
+			limit:   2,
+			wantErr: ErrUnknownRanking,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewBatchRankingSrv(nil, nil, tc.mock(ctrl), DefaultRankingStrategies())
+			arts, err := svc.GetTopN(context.Background(), tc.ranking, tc.offset, tc.limit)
 			assert.Equal(t, tc.wantErr, err)
 			assert.Equal(t, tc.wantArts, arts)
 		})
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	intrv1 "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1"
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+//go:generate mockgen -source=search.go -package=svcmocks -destination=mocks/search.mock.go SearchService
+type SearchService interface {
+	// SearchAuthorArticles 作者搜索自己的文章，包括草稿和仅自己可见的
+	SearchAuthorArticles(ctx context.Context, uid int64, q domain.ArticleSearchQuery) ([]domain.Article, error)
+	// SearchPubArticles 读者搜索已发表的文章
+	SearchPubArticles(ctx context.Context, q domain.ArticleSearchQuery) ([]domain.Article, error)
+}
+
+type articleSearchSvc struct {
+	repo    article.AuthorRepository
+	intrSvc intrv1.InteractiveServiceClient
+	l       logger.Logger
+	// 按热度排序的时候，只在最近更新的这么多篇里面排
+	maxCandidates int
+}
+
+func NewArticleSearchSvc(repo article.AuthorRepository, intrSvc intrv1.InteractiveServiceClient, l logger.Logger) SearchService {
+	return &articleSearchSvc{
+		repo:          repo,
+		intrSvc:       intrSvc,
+		l:             l,
+		maxCandidates: 500,
+	}
+}
+
+func (s *articleSearchSvc) SearchAuthorArticles(ctx context.Context, uid int64, q domain.ArticleSearchQuery) ([]domain.Article, error) {
+	q.AuthorId = uid
+	return s.search(ctx, q, s.repo.Search)
+}
+
+func (s *articleSearchSvc) SearchPubArticles(ctx context.Context, q domain.ArticleSearchQuery) ([]domain.Article, error) {
+	// 线上库里撤回的文章状态是仅自己可见，读者搜不到
+	q.Status = domain.ArticleStatusPublished
+	return s.search(ctx, q, s.repo.SearchPub)
+}
+
+func (s *articleSearchSvc) search(ctx context.Context, q domain.ArticleSearchQuery,
+	fn func(ctx context.Context, q domain.ArticleSearchQuery) ([]domain.Article, error)) ([]domain.Article, error) {
+	if q.SortBy != domain.ArticleSortByPopularity {
+		return fn(ctx, q)
+	}
+	// 索引只按更新时间排序，热度要拿到交互数据之后自己排
+	offset, limit := q.Offset, q.Limit
+	q.Offset, q.Limit = 0, s.maxCandidates
+	arts, err := fn(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if len(arts) > 0 {
+		s.sortByPopularity(ctx, arts)
+	}
+	if offset >= len(arts) {
+		return []domain.Article{}, nil
+	}
+	end := offset + limit
+	if end > len(arts) {
+		end = len(arts)
+	}
+	return arts[offset:end], nil
+}
+
+// sortByPopularity 拿不到交互数据就退化成按更新时间排序
+func (s *articleSearchSvc) sortByPopularity(ctx context.Context, arts []domain.Article) {
+	ids := slice.Map[domain.Article, int64](arts, func(idx int, src domain.Article) int64 {
+		return src.Id
+	})
+	resp, err := s.intrSvc.GetByIds(ctx, &intrv1.GetByIdsRequest{Biz: "article", Ids: ids})
+	if err != nil {
+		s.l.Warn("搜索时获取交互数据失败，按更新时间排序", logger.Error(err))
+		return
+	}
+	intrs := resp.GetIntrs()
+	score := func(id int64) int64 {
+		intr := intrs[id]
+		return intr.GetReadCnt() + 3*intr.GetLikeCnt() + 5*intr.GetCollectCnt()
+	}
+	sort.SliceStable(arts, func(i, j int) bool {
+		return score(arts[i].Id) > score(arts[j].Id)
+	})
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	intrv1 "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1"
+	intrv1mocks "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1/mocks"
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+	artrepomocks "github.com/mrhelloboy/wehook/internal/repository/article/mocks"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+func TestArticleSearchSvc_SearchPubArticles(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) (article.AuthorRepository, intrv1.InteractiveServiceClient)
+		q        domain.ArticleSearchQuery
+		wantErr  error
+		wantArts []domain.Article
+	}{
+		{
+			name: "按更新时间，直接交给索引",
+			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, intrv1.InteractiveServiceClient) {
+				repo := artrepomocks.NewMockAuthorRepository(ctrl)
+				repo.EXPECT().SearchPub(gomock.Any(), domain.ArticleSearchQuery{
+					Keyword: "go", Status: domain.ArticleStatusPublished, Offset: 0, Limit: 2,
+				}).Return([]domain.Article{{Id: 2}, {Id: 1}}, nil)
+				return repo, intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+			},
+			q:        domain.ArticleSearchQuery{Keyword: "go", Limit: 2},
+			wantArts: []domain.Article{{Id: 2}, {Id: 1}},
+		},
+		{
+			name: "按热度排序后分页",
+			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, intrv1.InteractiveServiceClient) {
+				repo := artrepomocks.NewMockAuthorRepository(ctrl)
+				repo.EXPECT().SearchPub(gomock.Any(), domain.ArticleSearchQuery{
+					Status: domain.ArticleStatusPublished, SortBy: domain.ArticleSortByPopularity, Limit: 500,
+				}).Return([]domain.Article{{Id: 1}, {Id: 2}, {Id: 3}}, nil)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				intrSvc.EXPECT().GetByIds(gomock.Any(), &intrv1.GetByIdsRequest{
+					Biz: "article", Ids: []int64{1, 2, 3},
+				}).Return(&intrv1.GetByIdsResponse{
+					Intrs: map[int64]*intrv1.Interactive{
+						1: {BizId: 1, ReadCnt: 1},
+						2: {BizId: 2, LikeCnt: 2},
+						3: {BizId: 3, CollectCnt: 1},
+					},
+				}, nil)
+				return repo, intrSvc
+			},
+			q:        domain.ArticleSearchQuery{SortBy: domain.ArticleSortByPopularity, Offset: 0, Limit: 2},
+			wantArts: []domain.Article{{Id: 2}, {Id: 3}},
+		},
+		{
+			name: "交互数据失败，按更新时间",
+			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, intrv1.InteractiveServiceClient) {
+				repo := artrepomocks.NewMockAuthorRepository(ctrl)
+				repo.EXPECT().SearchPub(gomock.Any(), gomock.Any()).
+					Return([]domain.Article{{Id: 1}, {Id: 2}, {Id: 3}}, nil)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				intrSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any()).Return(nil, errors.New("rpc error"))
+				return repo, intrSvc
+			},
+			q:        domain.ArticleSearchQuery{SortBy: domain.ArticleSortByPopularity, Offset: 1, Limit: 5},
+			wantArts: []domain.Article{{Id: 2}, {Id: 3}},
+		},
+		{
+			name: "索引失败",
+			mock: func(ctrl *gomock.Controller) (article.AuthorRepository, intrv1.InteractiveServiceClient) {
+				repo := artrepomocks.NewMockAuthorRepository(ctrl)
+				repo.EXPECT().SearchPub(gomock.Any(), gomock.Any()).Return(nil, errors.New("index error"))
+				return repo, intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+			},
+			q:       domain.ArticleSearchQuery{Limit: 2},
+			wantErr: errors.New("index error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, intrSvc := tc.mock(ctrl)
+			svc := NewArticleSearchSvc(repo, intrSvc, &logger.NopLogger{})
+			arts, err := svc.SearchPubArticles(context.Background(), tc.q)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArts, arts)
+		})
+	}
+}
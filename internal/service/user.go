@@ -12,6 +12,7 @@ import (
 
 var (
 	ErrUserDuplicate         = repository.ErrUserDuplicate
+	ErrUserNotFound          = repository.ErrUserNotFound
 	ErrInvalidUserOrPassword = errors.New("账号/邮箱或密码不对")
 )
 
@@ -19,8 +20,22 @@ type UserService interface {
 	Login(ctx context.Context, email, password string) (domain.User, error)
 	Signup(ctx context.Context, u domain.User) error
 	FindOrCreate(ctx context.Context, phone string) (domain.User, error)
+	// FindOrCreateByEmail 通过邮箱验证码登录，没有注册过的直接注册
+	FindOrCreateByEmail(ctx context.Context, email string) (domain.User, error)
 	FindOrCreateByWechat(ctx context.Context, info domain.WechatInfo) (domain.User, error)
 	Profile(ctx context.Context, id int64) (domain.User, error)
+	// UpdateNonSensitiveInfo 修改昵称、生日、简介和头像，不需要验证
+	UpdateNonSensitiveInfo(ctx context.Context, u domain.User) error
+	// UpdatePhone、UpdateEmail、UpdatePassword 修改敏感信息，
+	// 调用之前要先在 web 层通过验证码确认是本人操作
+	UpdatePhone(ctx context.Context, id int64, phone string) error
+	UpdateEmail(ctx context.Context, id int64, email string) error
+	UpdatePassword(ctx context.Context, id int64, password string) error
+	// ResetPassword 忘记密码，调用之前要先在 web 层通过发到 phone 上的验证码确认是本人操作。
+	// 返回用户的 id，用来让这个用户所有的登录态都失效
+	ResetPassword(ctx context.Context, phone string, password string) (int64, error)
+	// ResetPasswordByEmail 和 ResetPassword 一样，验证码是发到 email 上的，给没有绑定手机号的用户用
+	ResetPasswordByEmail(ctx context.Context, email string, password string) (int64, error)
 }
 
 type UserSvc struct {
@@ -86,6 +101,23 @@ func (svc *UserSvc) FindOrCreate(ctx context.Context, phone string) (domain.User
 	return svc.repo.FindByPhone(ctx, phone)
 }
 
+func (svc *UserSvc) FindOrCreateByEmail(ctx context.Context, email string) (domain.User, error) {
+	// 快路径
+	u, err := svc.repo.FindByEmail(ctx, email)
+	if !errors.Is(err, repository.ErrUserNotFound) {
+		return u, err
+	}
+	// 慢路径
+	err = svc.repo.Create(ctx, domain.User{Email: email})
+	// 注册有问题，但是又不是邮箱冲突，说明系统异常
+	if err != nil && !errors.Is(err, repository.ErrUserDuplicate) {
+		return domain.User{}, err
+	}
+
+	// todo: 这里有主从延迟的坑
+	return svc.repo.FindByEmail(ctx, email)
+}
+
 func (svc *UserSvc) FindOrCreateByWechat(ctx context.Context, info domain.WechatInfo) (domain.User, error) {
 	// 快路径
 	u, err := svc.repo.FindByWechat(ctx, info.OpenID)
@@ -118,3 +150,46 @@ func (svc *UserSvc) Profile(ctx context.Context, id int64) (domain.User, error)
 	}
 	return u, nil
 }
+
+func (svc *UserSvc) UpdateNonSensitiveInfo(ctx context.Context, u domain.User) error {
+	return svc.repo.UpdateNonSensitiveInfo(ctx, u)
+}
+
+func (svc *UserSvc) UpdatePhone(ctx context.Context, id int64, phone string) error {
+	return svc.repo.UpdatePhone(ctx, id, phone)
+}
+
+func (svc *UserSvc) UpdateEmail(ctx context.Context, id int64, email string) error {
+	return svc.repo.UpdateEmail(ctx, id, email)
+}
+
+func (svc *UserSvc) UpdatePassword(ctx context.Context, id int64, password string) error {
+	// 和注册的时候一样加密
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	return svc.repo.UpdatePassword(ctx, id, string(hash))
+}
+
+func (svc *UserSvc) ResetPassword(ctx context.Context, phone string, password string) (int64, error) {
+	u, err := svc.repo.FindByPhone(ctx, phone)
+	if err != nil {
+		return 0, err
+	}
+	if err = svc.UpdatePassword(ctx, u.Id, password); err != nil {
+		return 0, err
+	}
+	return u.Id, nil
+}
+
+func (svc *UserSvc) ResetPasswordByEmail(ctx context.Context, email string, password string) (int64, error) {
+	u, err := svc.repo.FindByEmail(ctx, email)
+	if err != nil {
+		return 0, err
+	}
+	if err = svc.UpdatePassword(ctx, u.Id, password); err != nil {
+		return 0, err
+	}
+	return u.Id, nil
+}
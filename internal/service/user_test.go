@@ -120,3 +120,103 @@ func TestEncryptedPassword(t *testing.T) {
 	hash, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	t.Log(string(hash))
 }
+
+func TestUserSvc_ResetPassword(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctl *gomock.Controller) repository.UserRepository
+		phone    string
+		password string
+		wantUid  int64
+		wantErr  error
+	}{
+		{
+			name:     "重置成功，存的是加密之后的密码",
+			phone:    "18612345678",
+			password: "hello#world123",
+			mock: func(ctl *gomock.Controller) repository.UserRepository {
+				userRepo := repomocks.NewMockUserRepository(ctl)
+				userRepo.EXPECT().FindByPhone(gomock.Any(), "18612345678").Return(domain.User{Id: 1}, nil)
+				userRepo.EXPECT().UpdatePassword(gomock.Any(), int64(1), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id int64, password string) error {
+						return bcrypt.CompareHashAndPassword([]byte(password), []byte("hello#world123"))
+					})
+				return userRepo
+			},
+			wantUid: 1,
+		},
+		{
+			name:     "手机号码没有注册",
+			phone:    "18612345678",
+			password: "hello#world123",
+			mock: func(ctl *gomock.Controller) repository.UserRepository {
+				userRepo := repomocks.NewMockUserRepository(ctl)
+				userRepo.EXPECT().FindByPhone(gomock.Any(), "18612345678").Return(domain.User{}, repository.ErrUserNotFound)
+				return userRepo
+			},
+			wantErr: ErrUserNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+
+			userSvc := NewUserSvc(tc.mock(ctl), nil)
+			uid, err := userSvc.ResetPassword(context.Background(), tc.phone, tc.password)
+
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantUid, uid)
+		})
+	}
+}
+
+func TestUserSvc_ResetPasswordByEmail(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctl *gomock.Controller) repository.UserRepository
+		email    string
+		password string
+		wantUid  int64
+		wantErr  error
+	}{
+		{
+			name:     "重置成功，存的是加密之后的密码",
+			email:    "123@qq.com",
+			password: "hello#world123",
+			mock: func(ctl *gomock.Controller) repository.UserRepository {
+				userRepo := repomocks.NewMockUserRepository(ctl)
+				userRepo.EXPECT().FindByEmail(gomock.Any(), "123@qq.com").Return(domain.User{Id: 1}, nil)
+				userRepo.EXPECT().UpdatePassword(gomock.Any(), int64(1), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id int64, password string) error {
+						return bcrypt.CompareHashAndPassword([]byte(password), []byte("hello#world123"))
+					})
+				return userRepo
+			},
+			wantUid: 1,
+		},
+		{
+			name:     "邮箱没有注册",
+			email:    "123@qq.com",
+			password: "hello#world123",
+			mock: func(ctl *gomock.Controller) repository.UserRepository {
+				userRepo := repomocks.NewMockUserRepository(ctl)
+				userRepo.EXPECT().FindByEmail(gomock.Any(), "123@qq.com").Return(domain.User{}, repository.ErrUserNotFound)
+				return userRepo
+			},
+			wantErr: ErrUserNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+
+			userSvc := NewUserSvc(tc.mock(ctl), nil)
+			uid, err := userSvc.ResetPasswordByEmail(context.Background(), tc.email, tc.password)
+
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantUid, uid)
+		})
+	}
+}
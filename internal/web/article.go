@@ -1,9 +1,14 @@
 package web
 
 import (
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	intrv1 "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1"
 
@@ -13,26 +18,34 @@ import (
 	"github.com/mrhelloboy/wehook/internal/service"
 	ijwt "github.com/mrhelloboy/wehook/internal/web/jwt"
 	"github.com/mrhelloboy/wehook/pkg/logger"
+	"github.com/mrhelloboy/wehook/pkg/markdownx"
 
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var _ Handler = (*ArticleHandler)(nil)
 
 type ArticleHandler struct {
-	svc      service.ArticleService
-	interSvc intrv1.InteractiveServiceClient
-	l        logger.Logger
-	biz      string
+	svc         service.ArticleService
+	rankingSvc  service.RankingService
+	realtimeSvc service.RealtimeRankingService
+	interSvc    intrv1.InteractiveServiceClient
+	l           logger.Logger
+	biz         string
 }
 
-func NewArticleHandler(svc service.ArticleService, interSvc intrv1.InteractiveServiceClient, l logger.Logger) *ArticleHandler {
+func NewArticleHandler(svc service.ArticleService, rankingSvc service.RankingService, realtimeSvc service.RealtimeRankingService,
+	interSvc intrv1.InteractiveServiceClient, l logger.Logger) *ArticleHandler {
 	return &ArticleHandler{
-		svc:      svc,
-		interSvc: interSvc,
-		l:        l,
-		biz:      "article",
+		svc:         svc,
+		rankingSvc:  rankingSvc,
+		realtimeSvc: realtimeSvc,
+		interSvc:    interSvc,
+		l:           l,
+		biz:         "article",
 	}
 }
 
@@ -44,10 +57,307 @@ func (a *ArticleHandler) RegisterRouters(server *gin.Engine) {
 	g.POST("/list", a.List)
 	g.GET("/detail/:id", a.Detail)
 
+	// 历史版本
+	rev := g.Group("/revision")
+	rev.POST("/list", a.ListRevisions)
+	rev.POST("/diff", a.DiffRevisions)
+	rev.POST("/restore", a.RestoreRevision)
+	// 热榜，不需要登录
+	g.GET("/ranking", a.Ranking)
+
 	// 普通用户
 	pub := g.Group("/pub")
 	pub.GET("/:id", a.PubDetail)
 	pub.POST("/like", a.Like)
+	pub.POST("/collect", a.Collect)
+	// 标签和分类
+	pub.POST("/tag", a.ListByTag)
+	pub.POST("/category", a.ListByCategory)
+	pub.GET("/tags", a.TagCounts)
+	pub.GET("/tags/suggest", a.SuggestTags)
+	// 不需要登录
+	pub.GET("/timeline", a.Timeline)
+	pub.GET("/author", a.ListByAuthor)
+
+	// 收藏夹
+	col := g.Group("/collection")
+	col.POST("/create", a.CreateCollection)
+	col.POST("/rename", a.RenameCollection)
+	col.POST("/delete", a.DeleteCollection)
+	col.POST("/list", a.ListCollections)
+	col.POST("/items", a.ListCollectionItems)
+}
+
+// Ranking 榜单（分页），附带实时的阅读、点赞、收藏计数
+func (a *ArticleHandler) Ranking(ctx *gin.Context) {
+	type Req struct {
+		// Name 榜单名，默认是综合热榜
+		Name   string `form:"name"`
+		Offset int    `form:"offset"`
+		Limit  int    `form:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Offset < 0 || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	if req.Name == "" {
+		req.Name = service.RankingHot
+	}
+
+	var arts []domain.Article
+	var err error
+	if req.Name == service.RankingRealtime {
+		arts, err = a.realtimeSvc.GetTopN(ctx, req.Offset, req.Limit)
+	} else {
+		arts, err = a.rankingSvc.GetTopN(ctx, req.Name, req.Offset, req.Limit)
+	}
+	if errors.Is(err, service.ErrUnknownRanking) {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "榜单不存在"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error("获取热榜失败", logger.Error(err))
+		return
+	}
+
+	intrs := map[int64]*intrv1.Interactive{}
+	if len(arts) > 0 {
+		ids := slice.Map[domain.Article, int64](arts, func(idx int, src domain.Article) int64 {
+			return src.Id
+		})
+		resp, er := a.interSvc.GetByIds(ctx, &intrv1.GetByIdsRequest{
+			Biz: a.biz,
+			Ids: ids,
+		})
+		// 这里可以容错，拿不到计数也照样返回热榜
+		if er != nil {
+			a.l.Error("获取热榜计数失败", logger.Error(er))
+		} else {
+			intrs = resp.Intrs
+		}
+	}
+
+	ctx.JSON(http.StatusOK, Result{
+		Data: slice.Map[domain.Article, ArticleVO](arts, func(idx int, src domain.Article) ArticleVO {
+			intr := intrs[src.Id]
+			return ArticleVO{
+				Id:         src.Id,
+				Title:      src.Title,
+				Status:     src.Status.ToUint8(),
+				Author:     src.Author.Name,
+				ReadCnt:    intr.GetReadCnt(),
+				LikeCnt:    intr.GetLikeCnt(),
+				CollectCnt: intr.GetCollectCnt(),
+				CommentCnt: intr.GetCommentCnt(),
+				Ctime:      src.Ctime.Format(time.DateTime),
+				Utime:      src.Utime.Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+// ListByTag 按照标签分页获取公开发表的帖子（只显示摘要）
+func (a *ArticleHandler) ListByTag(ctx *gin.Context) {
+	type Req struct {
+		Tag    string `json:"tag"`
+		Offset int    `json:"offset"`
+		Limit  int    `json:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Tag == "" || req.Offset < 0 || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	arts, err := a.svc.ListPubByTag(ctx, req.Tag, req.Offset, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error("按照标签获取帖子失败", logger.String("tag", req.Tag), logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Data: toPubListVO(arts)})
+}
+
+// ListByCategory 按照分类分页获取公开发表的帖子（只显示摘要）
+func (a *ArticleHandler) ListByCategory(ctx *gin.Context) {
+	type Req struct {
+		Category string `json:"category"`
+		Offset   int    `json:"offset"`
+		Limit    int    `json:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Category == "" || req.Offset < 0 || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	arts, err := a.svc.ListPubByCategory(ctx, req.Category, req.Offset, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error("按照分类获取帖子失败", logger.String("category", req.Category), logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Data: toPubListVO(arts)})
+}
+
+// TagCounts 最常用的标签和对应的帖子数
+func (a *ArticleHandler) TagCounts(ctx *gin.Context) {
+	type Req struct {
+		Limit int `form:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	a.tagCounts(ctx, "", req.Limit)
+}
+
+// SuggestTags 输入标签时的自动补全，以 prefix 开头的标签，常用的在前
+func (a *ArticleHandler) SuggestTags(ctx *gin.Context) {
+	type Req struct {
+		Prefix string `form:"prefix"`
+		Limit  int    `form:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	req.Prefix = strings.TrimSpace(req.Prefix)
+	if req.Prefix == "" {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	a.tagCounts(ctx, req.Prefix, req.Limit)
+}
+
+func (a *ArticleHandler) tagCounts(ctx *gin.Context, prefix string, limit int) {
+	if limit <= 0 || limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	res, err := a.svc.CountPubTags(ctx, prefix, limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error("获取标签失败", logger.String("prefix", prefix), logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: slice.Map[domain.TagCount, TagCountVO](res, func(idx int, src domain.TagCount) TagCountVO {
+			return TagCountVO{Tag: src.Tag, Cnt: src.Cnt}
+		}),
+	})
+}
+
+// Timeline 全站公开发表的帖子，新的在前（只显示摘要），按照游标翻页
+func (a *ArticleHandler) Timeline(ctx *gin.Context) {
+	type Req struct {
+		Cursor string `form:"cursor"`
+		Limit  int    `form:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	cursor, err := decodeArticleCursor(req.Cursor)
+	if err != nil || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	arts, err := a.svc.Timeline(ctx, cursor, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error("获取时间线失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Data: toPubPageVO(arts, req.Limit)})
+}
+
+// ListByAuthor 某个作者公开发表的帖子，新的在前（只显示摘要），按照游标翻页
+func (a *ArticleHandler) ListByAuthor(ctx *gin.Context) {
+	type Req struct {
+		Uid    int64  `form:"uid"`
+		Cursor string `form:"cursor"`
+		Limit  int    `form:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	cursor, err := decodeArticleCursor(req.Cursor)
+	if err != nil || req.Uid <= 0 || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	arts, err := a.svc.ListPubByAuthor(ctx, req.Uid, cursor, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error("获取作者的帖子失败", logger.Int64("author", req.Uid), logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Data: toPubPageVO(arts, req.Limit)})
+}
+
+func toPubPageVO(arts []domain.Article, limit int) ArticlePageVO {
+	return ArticlePageVO{Arts: toPubListVO(arts), Next: nextArticleCursor(arts, limit)}
+}
+
+// nextArticleCursor 取满了 limit 条才有下一页，下一页从这一页的最后一篇开始
+func nextArticleCursor(arts []domain.Article, limit int) string {
+	if len(arts) == 0 || len(arts) < limit {
+		return ""
+	}
+	last := arts[len(arts)-1]
+	return encodeArticleCursor(domain.ArticleCursor{Utime: last.Utime, Id: last.Id})
+}
+
+// encodeArticleCursor 游标对前端是不透明的字符串
+func encodeArticleCursor(c domain.ArticleCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d_%d", c.Utime.UnixMilli(), c.Id)))
+}
+
+// decodeArticleCursor 空字符串就是第一页
+func decodeArticleCursor(s string) (domain.ArticleCursor, error) {
+	if s == "" {
+		return domain.ArticleCursor{}, nil
+	}
+	bs, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return domain.ArticleCursor{}, err
+	}
+	var utime, id int64
+	if _, err = fmt.Sscanf(string(bs), "%d_%d", &utime, &id); err != nil {
+		return domain.ArticleCursor{}, err
+	}
+	if utime <= 0 || id <= 0 {
+		return domain.ArticleCursor{}, errors.New("游标不合法")
+	}
+	return domain.ArticleCursor{Utime: time.UnixMilli(utime), Id: id}, nil
+}
+
+func toPubListVO(arts []domain.Article) []ArticleVO {
+	return slice.Map[domain.Article, ArticleVO](arts, func(idx int, src domain.Article) ArticleVO {
+		return ArticleVO{
+			Id:       src.Id,
+			Title:    src.Title,
+			Abstract: src.Abstract,
+			Author:   src.Author.Name,
+			Status:   src.Status.ToUint8(),
+			Tags:     src.Tags,
+			Category: src.Category,
+			Cover:    src.Cover,
+			Ctime:    src.Ctime.Format(time.DateTime),
+			Utime:    src.Utime.Format(time.DateTime),
+		}
+	})
 }
 
 // Like 点赞 or 取消点赞
@@ -95,6 +405,49 @@ func (a *ArticleHandler) Like(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, Result{Msg: "OK"})
 }
 
+// Collect 收藏 or 取消收藏，cid 为 0 表示默认收藏夹
+func (a *ArticleHandler) Collect(ctx *gin.Context) {
+	type Req struct {
+		Id      int64 `json:"id"`
+		Cid     int64 `json:"cid"`
+		Collect bool  `json:"collect"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc, ok := a.userClaims(ctx)
+	if !ok {
+		return
+	}
+	var err error
+	if req.Collect {
+		_, err = a.interSvc.Collect(ctx, &intrv1.CollectRequest{
+			Biz:   a.biz,
+			BizId: req.Id,
+			Uid:   uc.Id,
+			Cid:   req.Cid,
+		})
+	} else {
+		_, err = a.interSvc.CancelCollect(ctx, &intrv1.CancelCollectRequest{
+			Biz:   a.biz,
+			BizId: req.Id,
+			Uid:   uc.Id,
+			Cid:   req.Cid,
+		})
+	}
+	if status.Code(err) == codes.NotFound {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "收藏夹不存在"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error("收藏/取消收藏出错", logger.Int64("aid", req.Id), logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+}
+
 func (a *ArticleHandler) PubDetail(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -137,6 +490,10 @@ func (a *ArticleHandler) PubDetail(ctx *gin.Context) {
 	})
 
 	err = eg.Wait()
+	if errors.Is(err, service.ErrArticleNotFound) {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "帖子不存在"})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
 		return
@@ -154,19 +511,31 @@ func (a *ArticleHandler) PubDetail(ctx *gin.Context) {
 	//	}
 	//}()
 
+	html, err := markdownx.Render(art.Content)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error("渲染帖子内容失败", logger.Int64("aid", art.Id), logger.Error(err))
+		return
+	}
+
 	intr := getResp.Intr
 
+	// 读者只需要渲染并且清理过的 HTML，原文不返回
 	ctx.JSON(http.StatusOK, Result{Data: ArticleVO{
 		Id:         art.Id,
 		Title:      art.Title,
-		Content:    art.Content,
+		Html:       html,
 		Status:     art.Status.ToUint8(),
+		Tags:       art.Tags,
+		Category:   art.Category,
+		Cover:      art.Cover,
 		Author:     art.Author.Name,
 		Liked:      intr.Liked,
 		Collected:  intr.Collected,
 		LikeCnt:    intr.LikeCnt,
 		ReadCnt:    intr.ReadCnt,
 		CollectCnt: intr.CollectCnt,
+		CommentCnt: intr.CommentCnt,
 		Ctime:      art.Ctime.Format(time.DateTime),
 		Utime:      art.Utime.Format(time.DateTime),
 	}})
@@ -207,24 +576,33 @@ func (a *ArticleHandler) Detail(ctx *gin.Context) {
 		Id:    art.Id,
 		Title: art.Title,
 		// Abstract: art.Abstract(),
-		Content: art.Content,
-		Status:  art.Status.ToUint8(),
+		Content:  art.Content,
+		Status:   art.Status.ToUint8(),
+		Tags:     art.Tags,
+		Category: art.Category,
+		Cover:    art.Cover,
+		Summary:  art.Summary,
 		// Author:   art.Author.Name,
 		Ctime: art.Ctime.Format(time.DateTime),
 		Utime: art.Utime.Format(time.DateTime),
 	}})
 }
 
-// List 获取作者帖子列表(分页，只显示摘要）
+// List 获取作者帖子列表(按照游标分页，只显示摘要）
 func (a *ArticleHandler) List(ctx *gin.Context) {
 	type Req struct {
-		Offset int `json:"offset"`
-		Limit  int `json:"limit"`
+		Cursor string `json:"cursor"`
+		Limit  int    `json:"limit"`
 	}
 	var req Req
 	if err := ctx.Bind(&req); err != nil {
 		return
 	}
+	cursor, err := decodeArticleCursor(req.Cursor)
+	if err != nil || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
 
 	c := ctx.MustGet("claims")
 	claims, ok := c.(*ijwt.UserClaims)
@@ -233,25 +611,29 @@ func (a *ArticleHandler) List(ctx *gin.Context) {
 		a.l.Error("未发现用户的 session 信息")
 		return
 	}
-	res, err := a.svc.List(ctx, claims.Id, req.Offset, req.Limit)
+	res, err := a.svc.List(ctx, claims.Id, cursor, req.Limit)
 	if err != nil {
 		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
 		return
 	}
-	ctx.JSON(http.StatusOK, Result{
-		Data: slice.Map[domain.Article, ArticleVO](res, func(idx int, src domain.Article) ArticleVO {
+	ctx.JSON(http.StatusOK, Result{Data: ArticlePageVO{
+		Arts: slice.Map[domain.Article, ArticleVO](res, func(idx int, src domain.Article) ArticleVO {
 			return ArticleVO{
 				Id:       src.Id,
 				Title:    src.Title,
-				Abstract: src.Abstract(),
+				Abstract: src.Abstract,
 				// Content:  src.Content,
-				Status: src.Status.ToUint8(),
+				Status:   src.Status.ToUint8(),
+				Tags:     src.Tags,
+				Category: src.Category,
+				Cover:    src.Cover,
 				// Author:   src.Author.Name,
 				Ctime: src.Ctime.Format(time.DateTime),
 				Utime: src.Utime.Format(time.DateTime),
 			}
 		}),
-	})
+		Next: nextArticleCursor(res, req.Limit),
+	}})
 }
 
 // Withdraw 撤回公开发表状态的帖子，改为不可见状态
@@ -291,6 +673,10 @@ func (a *ArticleHandler) Publish(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "请求信息错误"})
 		return
 	}
+	if msg, ok := req.normalize(); !ok {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: msg})
+		return
+	}
 
 	// user info
 	c := ctx.MustGet("claims")
@@ -318,6 +704,10 @@ func (a *ArticleHandler) Edit(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "请求信息错误"})
 		return
 	}
+	if msg, ok := req.normalize(); !ok {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: msg})
+		return
+	}
 
 	c := ctx.MustGet("claims")
 	claims, ok := c.(*ijwt.UserClaims)
@@ -344,18 +734,375 @@ func (a *ArticleHandler) Edit(ctx *gin.Context) {
 }
 
 type ArticleReq struct {
-	Id      int64  `json:"id"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Id       int64    `json:"id"`
+	Title    string   `json:"title"`
+	Content  string   `json:"content"`
+	Tags     []string `json:"tags"`
+	Category string   `json:"category"`
+	Cover    string   `json:"cover"`
+	// Summary 不填就从内容里面生成摘要
+	Summary string `json:"summary"`
+}
+
+const (
+	maxTagCnt      = 5
+	maxTagLen      = 20
+	maxCategoryLen = 20
+	maxCoverLen    = 1024
+	maxSummaryLen  = 200
+)
+
+// normalize 去掉标签前后的空格、空标签和重复的标签，然后校验标签、分类、封面和摘要。
+// 标签去重不区分大小写，保留第一次出现的写法，和线上库标签表的唯一索引保持一致。
+// 返回的 string 是给前端的提示
+func (r *ArticleReq) normalize() (string, bool) {
+	var tags []string
+	seen := make(map[string]struct{}, len(r.Tags))
+	for _, tag := range r.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		key := strings.ToLower(tag)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTagLen {
+			return fmt.Sprintf("标签不能超过 %d 个字", maxTagLen), false
+		}
+		seen[key] = struct{}{}
+		tags = append(tags, tag)
+	}
+	if len(tags) > maxTagCnt {
+		return fmt.Sprintf("最多 %d 个标签", maxTagCnt), false
+	}
+	r.Tags = tags
+	r.Category = strings.TrimSpace(r.Category)
+	if utf8.RuneCountInString(r.Category) > maxCategoryLen {
+		return fmt.Sprintf("分类不能超过 %d 个字", maxCategoryLen), false
+	}
+	if r.Cover != "" && (len(r.Cover) > maxCoverLen ||
+		!(strings.HasPrefix(r.Cover, "https://") || strings.HasPrefix(r.Cover, "http://"))) {
+		return "封面地址不合法", false
+	}
+	r.Summary = strings.TrimSpace(r.Summary)
+	if utf8.RuneCountInString(r.Summary) > maxSummaryLen {
+		return fmt.Sprintf("摘要不能超过 %d 个字", maxSummaryLen), false
+	}
+	return "", true
 }
 
 func (r ArticleReq) toDomain(uid int64) domain.Article {
 	return domain.Article{
-		Id:      r.Id,
-		Title:   r.Title,
-		Content: r.Content,
+		Id:       r.Id,
+		Title:    r.Title,
+		Content:  r.Content,
+		Tags:     r.Tags,
+		Category: r.Category,
+		Cover:    r.Cover,
+		Summary:  r.Summary,
 		Author: domain.Author{
 			Id: uid,
 		},
 	}
 }
+
+// ListRevisions 分页获取自己某篇帖子的历史版本，新的在前
+func (a *ArticleHandler) ListRevisions(ctx *gin.Context) {
+	type Req struct {
+		Id     int64 `json:"id"`
+		Offset int   `json:"offset"`
+		Limit  int   `json:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Id <= 0 || req.Offset < 0 || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	uc, ok := a.userClaims(ctx)
+	if !ok {
+		return
+	}
+	revs, err := a.svc.ListRevisions(ctx, req.Id, uc.Id, req.Offset, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error("获取帖子历史版本失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: slice.Map[domain.ArticleRevision, ArticleRevisionVO](revs, func(idx int, src domain.ArticleRevision) ArticleRevisionVO {
+			return a.toRevisionVO(src)
+		}),
+	})
+}
+
+// DiffRevisions 比较同一篇帖子的两个版本
+func (a *ArticleHandler) DiffRevisions(ctx *gin.Context) {
+	type Req struct {
+		From int64 `json:"from"`
+		To   int64 `json:"to"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.From <= 0 || req.To <= 0 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	uc, ok := a.userClaims(ctx)
+	if !ok {
+		return
+	}
+	diff, err := a.svc.DiffRevisions(ctx, uc.Id, req.From, req.To)
+	if errors.Is(err, service.ErrRevisionNotFound) {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "版本不存在"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error("比较帖子版本失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Data: ArticleRevisionDiffVO{
+		From:        a.toRevisionVO(diff.From),
+		To:          a.toRevisionVO(diff.To),
+		TitleDiff:   diff.TitleDiff,
+		ContentDiff: diff.ContentDiff,
+	}})
+}
+
+// RestoreRevision 把某个历史版本恢复成当前草稿，需要重新发表才会对读者可见
+func (a *ArticleHandler) RestoreRevision(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Id <= 0 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	uc, ok := a.userClaims(ctx)
+	if !ok {
+		return
+	}
+	id, err := a.svc.RestoreRevision(ctx, uc.Id, req.Id)
+	if errors.Is(err, service.ErrRevisionNotFound) {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "版本不存在"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error("恢复帖子版本失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Msg: "OK", Data: id})
+}
+
+func (a *ArticleHandler) toRevisionVO(rev domain.ArticleRevision) ArticleRevisionVO {
+	return ArticleRevisionVO{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		Title:     rev.Title,
+		Content:   rev.Content,
+		Status:    rev.Status.ToUint8(),
+		Ctime:     rev.Ctime.Format(time.DateTime),
+	}
+}
+
+// CreateCollection 创建收藏夹
+func (a *ArticleHandler) CreateCollection(ctx *gin.Context) {
+	type Req struct {
+		Name string `json:"name"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Name == "" {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "收藏夹名字不能为空"})
+		return
+	}
+	uc, ok := a.userClaims(ctx)
+	if !ok {
+		return
+	}
+	resp, err := a.interSvc.CreateCollection(ctx, &intrv1.CreateCollectionRequest{
+		Uid:  uc.Id,
+		Name: req.Name,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error("创建收藏夹失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Msg: "OK", Data: resp.GetId()})
+}
+
+// RenameCollection 重命名自己的收藏夹
+func (a *ArticleHandler) RenameCollection(ctx *gin.Context) {
+	type Req struct {
+		Id   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Name == "" {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "收藏夹名字不能为空"})
+		return
+	}
+	uc, ok := a.userClaims(ctx)
+	if !ok {
+		return
+	}
+	_, err := a.interSvc.RenameCollection(ctx, &intrv1.RenameCollectionRequest{
+		Id:   req.Id,
+		Uid:  uc.Id,
+		Name: req.Name,
+	})
+	a.collectionResult(ctx, err, "重命名收藏夹失败")
+}
+
+// DeleteCollection 删除自己的收藏夹，里面的内容会被取消收藏
+func (a *ArticleHandler) DeleteCollection(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	uc, ok := a.userClaims(ctx)
+	if !ok {
+		return
+	}
+	_, err := a.interSvc.DeleteCollection(ctx, &intrv1.DeleteCollectionRequest{
+		Id:  req.Id,
+		Uid: uc.Id,
+	})
+	a.collectionResult(ctx, err, "删除收藏夹失败")
+}
+
+// ListCollections 分页获取自己的收藏夹
+func (a *ArticleHandler) ListCollections(ctx *gin.Context) {
+	type Req struct {
+		Offset int `json:"offset"`
+		Limit  int `json:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Offset < 0 || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	uc, ok := a.userClaims(ctx)
+	if !ok {
+		return
+	}
+	resp, err := a.interSvc.ListCollections(ctx, &intrv1.ListCollectionsRequest{
+		Uid:    uc.Id,
+		Offset: int32(req.Offset),
+		Limit:  int32(req.Limit),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error("获取收藏夹失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: slice.Map[*intrv1.Collection, CollectionVO](resp.GetCollections(), func(idx int, src *intrv1.Collection) CollectionVO {
+			return CollectionVO{
+				Id:      src.GetId(),
+				Name:    src.GetName(),
+				ItemCnt: src.GetItemCnt(),
+				Ctime:   time.UnixMilli(src.GetCtime()).Format(time.DateTime),
+				Utime:   time.UnixMilli(src.GetUtime()).Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+// ListCollectionItems 分页获取自己收藏夹里面的内容
+func (a *ArticleHandler) ListCollectionItems(ctx *gin.Context) {
+	type Req struct {
+		Cid    int64 `json:"cid"`
+		Offset int   `json:"offset"`
+		Limit  int   `json:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Offset < 0 || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	uc, ok := a.userClaims(ctx)
+	if !ok {
+		return
+	}
+	resp, err := a.interSvc.ListCollectionItems(ctx, &intrv1.ListCollectionItemsRequest{
+		Cid:    req.Cid,
+		Uid:    uc.Id,
+		Offset: int32(req.Offset),
+		Limit:  int32(req.Limit),
+	})
+	if status.Code(err) == codes.NotFound {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "收藏夹不存在"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error("获取收藏夹内容失败", logger.Int64("cid", req.Cid), logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: slice.Map[*intrv1.CollectionItem, CollectionItemVO](resp.GetItems(), func(idx int, src *intrv1.CollectionItem) CollectionItemVO {
+			return CollectionItemVO{
+				Biz:   src.GetBiz(),
+				BizId: src.GetBizId(),
+				Ctime: time.UnixMilli(src.GetCtime()).Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+// collectionResult 收藏夹不存在是用户的输入有问题，其它的是系统错误
+func (a *ArticleHandler) collectionResult(ctx *gin.Context, err error, msg string) {
+	if status.Code(err) == codes.NotFound {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "收藏夹不存在"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error(msg, logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+}
+
+// userClaims 拿不到登录信息的时候已经写好了响应
+func (a *ArticleHandler) userClaims(ctx *gin.Context) (*ijwt.UserClaims, bool) {
+	c, ok := ctx.Get("claims")
+	if !ok {
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return nil, false
+	}
+	uc, ok := c.(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		a.l.Error("未发现用户的 session 信息")
+		return nil, false
+	}
+	return uc, true
+}
@@ -0,0 +1,153 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/service"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+var _ Handler = (*ArticleScheduleHandler)(nil)
+
+// ArticleScheduleHandler 定时发表
+type ArticleScheduleHandler struct {
+	svc service.ArticleScheduleService
+	l   logger.Logger
+}
+
+func NewArticleScheduleHandler(svc service.ArticleScheduleService, l logger.Logger) *ArticleScheduleHandler {
+	return &ArticleScheduleHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *ArticleScheduleHandler) RegisterRouters(server *gin.Engine) {
+	g := server.Group("/article/schedule")
+	g.POST("/publish", h.Publish)
+	g.POST("/list", h.List)
+	g.POST("/reschedule", h.Reschedule)
+	g.POST("/cancel", h.Cancel)
+}
+
+// Publish 保存帖子并设置定时发表，publish_time 是毫秒时间戳
+func (h *ArticleScheduleHandler) Publish(ctx *gin.Context) {
+	type Req struct {
+		ArticleReq
+		PublishTime int64 `json:"publish_time"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if msg, ok := req.normalize(); !ok {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: msg})
+		return
+	}
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	id, err := h.svc.SchedulePublish(ctx, req.toDomain(claims.Id), time.UnixMilli(req.PublishTime))
+	if errors.Is(err, service.ErrInvalidPublishTime) {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "发表时间必须晚于当前时间"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("设置定时发表失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Msg: "OK", Data: id})
+}
+
+// List 分页获取还没发表的定时，最早发表的在前
+func (h *ArticleScheduleHandler) List(ctx *gin.Context) {
+	type Req struct {
+		Offset int `json:"offset"`
+		Limit  int `json:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Offset < 0 || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	res, err := h.svc.ListPending(ctx, claims.Id, req.Offset, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("获取定时发表列表失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: slice.Map[domain.PublishSchedule, PublishScheduleVO](res, func(idx int, src domain.PublishSchedule) PublishScheduleVO {
+			return PublishScheduleVO{
+				ArticleId:   src.ArticleId,
+				PublishTime: src.PublishTime.Format(time.DateTime),
+				Ctime:       src.Ctime.Format(time.DateTime),
+				Utime:       src.Utime.Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+// Reschedule 修改还没发表的定时
+func (h *ArticleScheduleHandler) Reschedule(ctx *gin.Context) {
+	type Req struct {
+		Id          int64 `json:"id"`
+		PublishTime int64 `json:"publish_time"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	err := h.svc.Reschedule(ctx, req.Id, claims.Id, time.UnixMilli(req.PublishTime))
+	h.result(ctx, err, "修改定时发表失败")
+}
+
+// Cancel 取消还没发表的定时，帖子留在草稿里
+func (h *ArticleScheduleHandler) Cancel(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	err := h.svc.Cancel(ctx, req.Id, claims.Id)
+	h.result(ctx, err, "取消定时发表失败")
+}
+
+func (h *ArticleScheduleHandler) result(ctx *gin.Context, err error, msg string) {
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+	case errors.Is(err, service.ErrInvalidPublishTime):
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "发表时间必须晚于当前时间"})
+	case errors.Is(err, service.ErrPublishScheduleNotFound):
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "定时发表不存在或已发表"})
+	default:
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error(msg, logger.Error(err))
+	}
+}
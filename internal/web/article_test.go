@@ -6,9 +6,15 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	intrv1 "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1"
+	intrv1mocks "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1/mocks"
 	"github.com/mrhelloboy/wehook/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	svcmocks "github.com/mrhelloboy/wehook/internal/service/mocks"
 
@@ -78,7 +84,7 @@ func TestArticleHandler_Publish(t *testing.T) {
 			server.Use(func(ctx *gin.Context) {
 				ctx.Set("claims", &ijwt.UserClaims{Id: 123})
 			})
-			h := NewArticleHandler(tc.mock(ctrl), nil, &logger.NopLogger{})
+			h := NewArticleHandler(tc.mock(ctrl), nil, nil, nil, &logger.NopLogger{})
 			h.RegisterRouters(server)
 
 			// request
@@ -100,3 +106,540 @@ func TestArticleHandler_Publish(t *testing.T) {
 		})
 	}
 }
+
+func TestArticleHandler_Collect(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) intrv1.InteractiveServiceClient
+		reqBody string
+		wantRes Result
+	}{
+		{
+			name: "收藏成功",
+			mock: func(ctrl *gomock.Controller) intrv1.InteractiveServiceClient {
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				intrSvc.EXPECT().Collect(gomock.Any(), &intrv1.CollectRequest{
+					Biz: "article", BizId: 1, Uid: 123, Cid: 2,
+				}).Return(&intrv1.CollectResponse{}, nil)
+				return intrSvc
+			},
+			reqBody: `{"id":1,"cid":2,"collect":true}`,
+			wantRes: Result{Msg: "OK"},
+		},
+		{
+			name: "收藏夹不存在",
+			mock: func(ctrl *gomock.Controller) intrv1.InteractiveServiceClient {
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				intrSvc.EXPECT().Collect(gomock.Any(), &intrv1.CollectRequest{
+					Biz: "article", BizId: 1, Uid: 123, Cid: 2,
+				}).Return(nil, status.Error(codes.NotFound, "收藏夹不存在"))
+				return intrSvc
+			},
+			reqBody: `{"id":1,"cid":2,"collect":true}`,
+			wantRes: Result{Code: 4, Msg: "收藏夹不存在"},
+		},
+		{
+			name: "取消收藏成功",
+			mock: func(ctrl *gomock.Controller) intrv1.InteractiveServiceClient {
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				intrSvc.EXPECT().CancelCollect(gomock.Any(), &intrv1.CancelCollectRequest{
+					Biz: "article", BizId: 1, Uid: 123,
+				}).Return(&intrv1.CancelCollectResponse{}, nil)
+				return intrSvc
+			},
+			reqBody: `{"id":1,"collect":false}`,
+			wantRes: Result{Msg: "OK"},
+		},
+		{
+			name: "收藏失败",
+			mock: func(ctrl *gomock.Controller) intrv1.InteractiveServiceClient {
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				intrSvc.EXPECT().Collect(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("mock error"))
+				return intrSvc
+			},
+			reqBody: `{"id":1,"cid":2,"collect":true}`,
+			wantRes: Result{Code: 5, Msg: "系统错误"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("claims", &ijwt.UserClaims{Id: 123})
+			})
+			h := NewArticleHandler(nil, nil, nil, tc.mock(ctrl), &logger.NopLogger{})
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/article/pub/collect", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestArticleHandler_EditTags(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) service.ArticleService
+		reqBody string
+		wantRes Result
+	}{
+		{
+			name: "去掉空格、空标签和重复的标签，重复不区分大小写",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().Save(gomock.Any(), domain.Article{
+					Title:    "标题",
+					Content:  "内容",
+					Tags:     []string{"Go", "后端"},
+					Category: "技术",
+					Cover:    "https://example.com/cover.png",
+					Summary:  "摘要",
+					Author:   domain.Author{Id: 123},
+				}).Return(int64(1), nil)
+				return svc
+			},
+			reqBody: `{"title":"标题","content":"内容","tags":[" Go ","","后端","Go","go","GO "],"category":" 技术 ","cover":"https://example.com/cover.png","summary":" 摘要 "}`,
+			wantRes: Result{Msg: "OK", Data: float64(1)},
+		},
+		{
+			name: "标签太多",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			reqBody: `{"title":"标题","tags":["1","2","3","4","5","6"]}`,
+			wantRes: Result{Code: 4, Msg: "最多 5 个标签"},
+		},
+		{
+			name: "标签太长",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			reqBody: `{"title":"标题","tags":["一二三四五六七八九十一二三四五六七八九十一"]}`,
+			wantRes: Result{Code: 4, Msg: "标签不能超过 20 个字"},
+		},
+		{
+			name: "封面不是 http 地址",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			reqBody: `{"title":"标题","cover":"javascript:alert(1)"}`,
+			wantRes: Result{Code: 4, Msg: "封面地址不合法"},
+		},
+		{
+			name: "摘要太长",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			reqBody: `{"title":"标题","summary":"` + strings.Repeat("长", 201) + `"}`,
+			wantRes: Result{Code: 4, Msg: "摘要不能超过 200 个字"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("claims", &ijwt.UserClaims{Id: 123})
+			})
+			h := NewArticleHandler(tc.mock(ctrl), nil, nil, nil, &logger.NopLogger{})
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/article/edit", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestArticleHandler_Tags(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) service.ArticleService
+		method  string
+		url     string
+		reqBody string
+		wantRes Result
+	}{
+		{
+			name: "按照标签获取",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().ListPubByTag(gomock.Any(), "Go", 0, 10).Return([]domain.Article{
+					{Id: 1, Title: "标题", Content: "# 标题\n\n**加粗**的内容", Abstract: "标题 加粗的内容",
+						Tags: []string{"Go"}, Category: "技术", Status: domain.ArticleStatusPublished, Ctime: now, Utime: now},
+					{Id: 2, Title: "标题", Content: "内容", Summary: "作者的摘要", Abstract: "作者的摘要", Tags: []string{"Go"},
+						Status: domain.ArticleStatusPublished, Ctime: now, Utime: now},
+				}, nil)
+				return svc
+			},
+			method:  http.MethodPost,
+			url:     "/article/pub/tag",
+			reqBody: `{"tag":"Go","offset":0,"limit":10}`,
+			// 列表只返回保存的时候生成的摘要，不返回内容
+			wantRes: Result{Data: []any{
+				map[string]any{
+					"id": float64(1), "title": "标题", "abstract": "标题 加粗的内容", "content": "", "html": "",
+					"author": "", "status": float64(2),
+					"tags": []any{"Go"}, "category": "技术", "cover": "", "summary": "",
+					"read_cnt": float64(0), "like_cnt": float64(0), "collect_cnt": float64(0), "comment_cnt": float64(0),
+					"liked": false, "collected": false,
+					"ctime": now.Format(time.DateTime), "utime": now.Format(time.DateTime),
+				},
+				map[string]any{
+					"id": float64(2), "title": "标题", "abstract": "作者的摘要", "content": "", "html": "",
+					"author": "", "status": float64(2),
+					"tags": []any{"Go"}, "category": "", "cover": "", "summary": "",
+					"read_cnt": float64(0), "like_cnt": float64(0), "collect_cnt": float64(0), "comment_cnt": float64(0),
+					"liked": false, "collected": false,
+					"ctime": now.Format(time.DateTime), "utime": now.Format(time.DateTime),
+				},
+			}},
+		},
+		{
+			name: "没有标签",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			method:  http.MethodPost,
+			url:     "/article/pub/tag",
+			reqBody: `{"offset":0,"limit":10}`,
+			wantRes: Result{Code: 4, Msg: "参数错误"},
+		},
+		{
+			name: "常用标签",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().CountPubTags(gomock.Any(), "", 10).Return([]domain.TagCount{
+					{Tag: "Go", Cnt: 3}, {Tag: "后端", Cnt: 1},
+				}, nil)
+				return svc
+			},
+			method: http.MethodGet,
+			url:    "/article/pub/tags?limit=10",
+			wantRes: Result{Data: []any{
+				map[string]any{"tag": "Go", "cnt": float64(3)},
+				map[string]any{"tag": "后端", "cnt": float64(1)},
+			}},
+		},
+		{
+			name: "标签补全",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().CountPubTags(gomock.Any(), "G", 5).Return([]domain.TagCount{
+					{Tag: "Go", Cnt: 3},
+				}, nil)
+				return svc
+			},
+			method:  http.MethodGet,
+			url:     "/article/pub/tags/suggest?prefix=G&limit=5",
+			wantRes: Result{Data: []any{map[string]any{"tag": "Go", "cnt": float64(3)}}},
+		},
+		{
+			name: "补全没有前缀",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			method:  http.MethodGet,
+			url:     "/article/pub/tags/suggest?prefix=%20&limit=5",
+			wantRes: Result{Code: 4, Msg: "参数错误"},
+		},
+		{
+			name: "获取标签失败",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().CountPubTags(gomock.Any(), "", 10).Return(nil, errors.New("db error"))
+				return svc
+			},
+			method:  http.MethodGet,
+			url:     "/article/pub/tags?limit=10",
+			wantRes: Result{Code: 5, Msg: "系统错误"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			h := NewArticleHandler(tc.mock(ctrl), nil, nil, nil, &logger.NopLogger{})
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestArticleHandler_PubDetail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.UnixMilli(1700000000000)
+	svc := svcmocks.NewMockArticleService(ctrl)
+	svc.EXPECT().GetPublishedById(gomock.Any(), int64(1), int64(123)).Return(domain.Article{
+		Id:      1,
+		Title:   "标题",
+		Content: "**加粗**<script>alert(1)</script>",
+		Author:  domain.Author{Id: 234, Name: "作者"},
+		Status:  domain.ArticleStatusPublished,
+		Ctime:   now,
+		Utime:   now,
+	}, nil)
+	intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+	intrSvc.EXPECT().Get(gomock.Any(), &intrv1.GetRequest{Biz: "article", BizId: 1, Uid: 123}).
+		Return(&intrv1.GetResponse{Intr: &intrv1.Interactive{LikeCnt: 2, Liked: true}}, nil)
+
+	server := gin.Default()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("claims", &ijwt.UserClaims{Id: 123})
+	})
+	h := NewArticleHandler(svc, nil, nil, intrSvc, &logger.NopLogger{})
+	h.RegisterRouters(server)
+
+	req, err := http.NewRequest(http.MethodGet, "/article/pub/1", nil)
+	require.NoError(t, err)
+	resp := httptest.NewRecorder()
+	server.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var res struct {
+		Data ArticleVO `json:"data"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	require.NoError(t, err)
+	// 原文只给作者编辑用，读者只能拿到渲染并且清理过的 HTML
+	assert.Empty(t, res.Data.Content)
+	assert.Equal(t, "<p><strong>加粗</strong></p>\n", res.Data.Html)
+	assert.Equal(t, "作者", res.Data.Author)
+	assert.Equal(t, int64(2), res.Data.LikeCnt)
+	assert.True(t, res.Data.Liked)
+}
+
+func TestArticleHandler_Timeline(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	cursor := encodeArticleCursor(domain.ArticleCursor{Utime: now, Id: 2})
+	arts := []domain.Article{
+		{Id: 3, Title: "3", Content: "内容", Author: domain.Author{Id: 1, Name: "作者"}, Utime: now.Add(time.Second)},
+		{Id: 2, Title: "2", Content: "内容", Author: domain.Author{Id: 1, Name: "作者"}, Utime: now},
+	}
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) service.ArticleService
+		url      string
+		wantCode int
+		wantArts []int64
+		wantNext string
+	}{
+		{
+			name: "第一页取满了，有下一页",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().Timeline(gomock.Any(), domain.ArticleCursor{}, 2).Return(arts, nil)
+				return svc
+			},
+			url:      "/article/pub/timeline?limit=2",
+			wantArts: []int64{3, 2},
+			wantNext: cursor,
+		},
+		{
+			name: "最后一页",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().Timeline(gomock.Any(), domain.ArticleCursor{Utime: now, Id: 2}, 2).
+					Return([]domain.Article{{Id: 1, Title: "1", Utime: now}}, nil)
+				return svc
+			},
+			url:      "/article/pub/timeline?limit=2&cursor=" + cursor,
+			wantArts: []int64{1},
+		},
+		{
+			name: "作者的帖子",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().ListPubByAuthor(gomock.Any(), int64(1), domain.ArticleCursor{Utime: now, Id: 2}, 10).
+					Return([]domain.Article{{Id: 1, Title: "1", Utime: now}}, nil)
+				return svc
+			},
+			url:      "/article/pub/author?uid=1&limit=10&cursor=" + cursor,
+			wantArts: []int64{1},
+		},
+		{
+			name: "游标不合法",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			url:      "/article/pub/timeline?limit=2&cursor=abc",
+			wantCode: 4,
+		},
+		{
+			name: "没有作者",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			url:      "/article/pub/author?limit=10",
+			wantCode: 4,
+		},
+		{
+			name: "查询失败",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().Timeline(gomock.Any(), domain.ArticleCursor{}, 10).Return(nil, errors.New("db error"))
+				return svc
+			},
+			url:      "/article/pub/timeline?limit=10",
+			wantCode: 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			h := NewArticleHandler(tc.mock(ctrl), nil, nil, nil, &logger.NopLogger{})
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res struct {
+				Code int           `json:"code"`
+				Data ArticlePageVO `json:"data"`
+			}
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCode, res.Code)
+			if res.Code != 0 {
+				return
+			}
+			ids := make([]int64, 0, len(res.Data.Arts))
+			for _, art := range res.Data.Arts {
+				ids = append(ids, art.Id)
+			}
+			assert.Equal(t, tc.wantArts, ids)
+			assert.Equal(t, tc.wantNext, res.Data.Next)
+		})
+	}
+}
+
+func TestArticleHandler_List(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) service.ArticleService
+		reqBody  string
+		wantCode int
+		wantArts []int64
+		wantNext string
+	}{
+		{
+			name: "第一页",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().List(gomock.Any(), int64(123), domain.ArticleCursor{}, 1).
+					Return([]domain.Article{{Id: 2, Title: "2", Utime: now}}, nil)
+				return svc
+			},
+			reqBody:  `{"limit":1}`,
+			wantArts: []int64{2},
+			wantNext: encodeArticleCursor(domain.ArticleCursor{Utime: now, Id: 2}),
+		},
+		{
+			name: "下一页没有了",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().List(gomock.Any(), int64(123), domain.ArticleCursor{Utime: now, Id: 2}, 1).
+					Return([]domain.Article{}, nil)
+				return svc
+			},
+			reqBody:  `{"limit":1,"cursor":"` + encodeArticleCursor(domain.ArticleCursor{Utime: now, Id: 2}) + `"}`,
+			wantArts: []int64{},
+		},
+		{
+			name: "limit 不合法",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			reqBody:  `{"limit":0}`,
+			wantCode: 4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("claims", &ijwt.UserClaims{Id: 123})
+			})
+			h := NewArticleHandler(tc.mock(ctrl), nil, nil, nil, &logger.NopLogger{})
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/article/list", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res struct {
+				Code int           `json:"code"`
+				Data ArticlePageVO `json:"data"`
+			}
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCode, res.Code)
+			if res.Code != 0 {
+				return
+			}
+			ids := make([]int64, 0, len(res.Data.Arts))
+			for _, art := range res.Data.Arts {
+				ids = append(ids, art.Id)
+			}
+			assert.Equal(t, tc.wantArts, ids)
+			assert.Equal(t, tc.wantNext, res.Data.Next)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/service"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+var _ Handler = (*ArticleTrashHandler)(nil)
+
+// ArticleTrashHandler 删除帖子和回收站
+type ArticleTrashHandler struct {
+	svc service.ArticleTrashService
+	l   logger.Logger
+}
+
+func NewArticleTrashHandler(svc service.ArticleTrashService, l logger.Logger) *ArticleTrashHandler {
+	return &ArticleTrashHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *ArticleTrashHandler) RegisterRouters(server *gin.Engine) {
+	server.POST("/article/delete", h.Delete)
+	g := server.Group("/article/trash")
+	g.POST("/list", h.List)
+	g.POST("/restore", h.Restore)
+}
+
+// Delete 把帖子放进回收站，草稿和线上都看不到了
+func (h *ArticleTrashHandler) Delete(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	err := h.svc.Delete(ctx, req.Id, claims.Id)
+	h.result(ctx, err, "删除帖子失败")
+}
+
+// List 分页获取回收站里的帖子，最近删除的在前
+func (h *ArticleTrashHandler) List(ctx *gin.Context) {
+	type Req struct {
+		Offset int `json:"offset"`
+		Limit  int `json:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Offset < 0 || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	res, err := h.svc.ListTrash(ctx, claims.Id, req.Offset, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("获取回收站列表失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: slice.Map[domain.Article, ArticleVO](res, func(idx int, src domain.Article) ArticleVO {
+			return ArticleVO{
+				Id:       src.Id,
+				Title:    src.Title,
+				Abstract: src.Abstract,
+				Status:   src.Status.ToUint8(),
+				Ctime:    src.Ctime.Format(time.DateTime),
+				// 删除时间
+				Utime: src.Utime.Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+// Restore 从回收站恢复，恢复后是草稿或者仅自己可见，需要重新发表
+func (h *ArticleTrashHandler) Restore(ctx *gin.Context) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	err := h.svc.Restore(ctx, req.Id, claims.Id)
+	h.result(ctx, err, "恢复帖子失败")
+}
+
+func (h *ArticleTrashHandler) result(ctx *gin.Context, err error, msg string) {
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+	case errors.Is(err, service.ErrArticleNotFound):
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "帖子不存在"})
+	default:
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error(msg, logger.Error(err))
+	}
+}
@@ -6,16 +6,73 @@ type ArticleVO struct {
 	Id       int64  `json:"id"`
 	Title    string `json:"title"`
 	Abstract string `json:"abstract"`
-	Content  string `json:"content"`
-	Status   uint8  `json:"status"`
+	// Content 作者写的 Markdown，只返回给作者
+	Content string `json:"content"`
+	// Html 渲染并且清理过的内容，给读者展示用
+	Html   string `json:"html"`
+	Status uint8  `json:"status"`
+	// 标签、分类、封面和作者填写的摘要
+	Tags     []string `json:"tags"`
+	Category string   `json:"category"`
+	Cover    string   `json:"cover"`
+	Summary  string   `json:"summary"`
 	// 计数
 	Author     string `json:"author"`
 	ReadCnt    int64  `json:"read_cnt"`
 	LikeCnt    int64  `json:"like_cnt"`
 	CollectCnt int64  `json:"collect_cnt"`
+	CommentCnt int64  `json:"comment_cnt"`
 	// 本人是否点赞、收藏
 	Liked     bool   `json:"liked"`
 	Collected bool   `json:"collected"`
 	Ctime     string `json:"ctime"`
 	Utime     string `json:"utime"`
 }
+
+// ArticlePageVO 按照游标翻页，Next 是下一页的游标，为空表示没有下一页了
+type ArticlePageVO struct {
+	Arts []ArticleVO `json:"arts"`
+	Next string      `json:"next"`
+}
+
+type TagCountVO struct {
+	Tag string `json:"tag"`
+	Cnt int64  `json:"cnt"`
+}
+
+type CollectionVO struct {
+	Id      int64  `json:"id"`
+	Name    string `json:"name"`
+	ItemCnt int64  `json:"item_cnt"`
+	Ctime   string `json:"ctime"`
+	Utime   string `json:"utime"`
+}
+
+type CollectionItemVO struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	Ctime string `json:"ctime"`
+}
+
+type ArticleRevisionVO struct {
+	Id        int64  `json:"id"`
+	ArticleId int64  `json:"article_id"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	Status    uint8  `json:"status"`
+	Ctime     string `json:"ctime"`
+}
+
+type ArticleRevisionDiffVO struct {
+	From        ArticleRevisionVO `json:"from"`
+	To          ArticleRevisionVO `json:"to"`
+	TitleDiff   string            `json:"title_diff"`
+	ContentDiff string            `json:"content_diff"`
+}
+
+type PublishScheduleVO struct {
+	ArticleId   int64  `json:"article_id"`
+	PublishTime string `json:"publish_time"`
+	Ctime       string `json:"ctime"`
+	Utime       string `json:"utime"`
+}
@@ -57,6 +57,10 @@ func (g *GreyScaleInteractiveServiceClient) Collect(ctx context.Context, in *int
 	return g.client().Collect(ctx, in, opts...)
 }
 
+func (g *GreyScaleInteractiveServiceClient) CancelCollect(ctx context.Context, in *intrv1.CancelCollectRequest, opts ...grpc.CallOption) (*intrv1.CancelCollectResponse, error) {
+	return g.client().CancelCollect(ctx, in, opts...)
+}
+
 func (g *GreyScaleInteractiveServiceClient) Get(ctx context.Context, in *intrv1.GetRequest, opts ...grpc.CallOption) (*intrv1.GetResponse, error) {
 	return g.client().Get(ctx, in, opts...)
 }
@@ -65,6 +69,34 @@ func (g *GreyScaleInteractiveServiceClient) GetByIds(ctx context.Context, in *in
 	return g.client().GetByIds(ctx, in, opts...)
 }
 
+func (g *GreyScaleInteractiveServiceClient) DeleteBiz(ctx context.Context, in *intrv1.DeleteBizRequest, opts ...grpc.CallOption) (*intrv1.DeleteBizResponse, error) {
+	return g.client().DeleteBiz(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) IncrCommentCnt(ctx context.Context, in *intrv1.IncrCommentCntRequest, opts ...grpc.CallOption) (*intrv1.IncrCommentCntResponse, error) {
+	return g.client().IncrCommentCnt(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) CreateCollection(ctx context.Context, in *intrv1.CreateCollectionRequest, opts ...grpc.CallOption) (*intrv1.CreateCollectionResponse, error) {
+	return g.client().CreateCollection(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) RenameCollection(ctx context.Context, in *intrv1.RenameCollectionRequest, opts ...grpc.CallOption) (*intrv1.RenameCollectionResponse, error) {
+	return g.client().RenameCollection(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) DeleteCollection(ctx context.Context, in *intrv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*intrv1.DeleteCollectionResponse, error) {
+	return g.client().DeleteCollection(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) ListCollections(ctx context.Context, in *intrv1.ListCollectionsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionsResponse, error) {
+	return g.client().ListCollections(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) ListCollectionItems(ctx context.Context, in *intrv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionItemsResponse, error) {
+	return g.client().ListCollectionItems(ctx, in, opts...)
+}
+
 func (g *GreyScaleInteractiveServiceClient) UpdateThreshold(newThreshold int32) {
 	g.threshold.Store(newThreshold)
 }
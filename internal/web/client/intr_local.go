@@ -2,11 +2,15 @@ package client
 
 import (
 	"context"
+	"errors"
+
 	domain2 "github.com/mrhelloboy/wehook/interactive/domain"
 
 	intrv1 "github.com/mrhelloboy/wehook/api/proto/gen/intr/v1"
 	"github.com/mrhelloboy/wehook/interactive/service"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // InteractiveServiceAdapter 将一个本地实现伪装成一个 gRPC 客户端
@@ -34,8 +38,13 @@ func (i *InteractiveServiceAdapter) CancelLike(ctx context.Context, in *intrv1.C
 }
 
 func (i *InteractiveServiceAdapter) Collect(ctx context.Context, in *intrv1.CollectRequest, opts ...grpc.CallOption) (*intrv1.CollectResponse, error) {
-	err := i.svc.Collect(ctx, in.GetBiz(), in.GetBizId(), in.GetUid(), in.GetCid())
-	return &intrv1.CollectResponse{}, err
+	err := i.svc.Collect(ctx, in.GetBiz(), in.GetBizId(), in.GetCid(), in.GetUid())
+	return &intrv1.CollectResponse{}, i.toStatusErr(err)
+}
+
+func (i *InteractiveServiceAdapter) CancelCollect(ctx context.Context, in *intrv1.CancelCollectRequest, opts ...grpc.CallOption) (*intrv1.CancelCollectResponse, error) {
+	err := i.svc.CancelCollect(ctx, in.GetBiz(), in.GetBizId(), in.GetCid(), in.GetUid())
+	return &intrv1.CancelCollectResponse{}, err
 }
 
 func (i *InteractiveServiceAdapter) Get(ctx context.Context, in *intrv1.GetRequest, opts ...grpc.CallOption) (*intrv1.GetResponse, error) {
@@ -63,14 +72,87 @@ func (i *InteractiveServiceAdapter) GetByIds(ctx context.Context, in *intrv1.Get
 	}, nil
 }
 
+func (i *InteractiveServiceAdapter) DeleteBiz(ctx context.Context, in *intrv1.DeleteBizRequest, opts ...grpc.CallOption) (*intrv1.DeleteBizResponse, error) {
+	err := i.svc.DeleteBiz(ctx, in.GetBiz(), in.GetBizId())
+	return &intrv1.DeleteBizResponse{}, err
+}
+
+func (i *InteractiveServiceAdapter) IncrCommentCnt(ctx context.Context, in *intrv1.IncrCommentCntRequest, opts ...grpc.CallOption) (*intrv1.IncrCommentCntResponse, error) {
+	err := i.svc.IncrCommentCnt(ctx, in.GetBiz(), in.GetBizId(), in.GetDelta())
+	return &intrv1.IncrCommentCntResponse{}, err
+}
+
 func (i *InteractiveServiceAdapter) toDTO(intr domain2.Interactive) *intrv1.Interactive {
 	return &intrv1.Interactive{
 		Biz:        intr.Biz,
 		BizId:      intr.BizId,
 		CollectCnt: intr.CollectCnt,
 		Collected:  intr.Collected,
+		CommentCnt: intr.CommentCnt,
 		LikeCnt:    intr.LikeCnt,
 		Liked:      intr.Liked,
 		ReadCnt:    intr.ReadCnt,
 	}
 }
+
+func (i *InteractiveServiceAdapter) CreateCollection(ctx context.Context, in *intrv1.CreateCollectionRequest, opts ...grpc.CallOption) (*intrv1.CreateCollectionResponse, error) {
+	id, err := i.svc.CreateCollection(ctx, in.GetUid(), in.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.CreateCollectionResponse{Id: id}, nil
+}
+
+func (i *InteractiveServiceAdapter) RenameCollection(ctx context.Context, in *intrv1.RenameCollectionRequest, opts ...grpc.CallOption) (*intrv1.RenameCollectionResponse, error) {
+	err := i.svc.RenameCollection(ctx, in.GetId(), in.GetUid(), in.GetName())
+	return &intrv1.RenameCollectionResponse{}, i.toStatusErr(err)
+}
+
+func (i *InteractiveServiceAdapter) DeleteCollection(ctx context.Context, in *intrv1.DeleteCollectionRequest, opts ...grpc.CallOption) (*intrv1.DeleteCollectionResponse, error) {
+	err := i.svc.DeleteCollection(ctx, in.GetId(), in.GetUid())
+	return &intrv1.DeleteCollectionResponse{}, i.toStatusErr(err)
+}
+
+func (i *InteractiveServiceAdapter) ListCollections(ctx context.Context, in *intrv1.ListCollectionsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionsResponse, error) {
+	cols, err := i.svc.ListCollections(ctx, in.GetUid(), int(in.GetOffset()), int(in.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*intrv1.Collection, 0, len(cols))
+	for _, col := range cols {
+		res = append(res, &intrv1.Collection{
+			Id:      col.Id,
+			Uid:     col.Uid,
+			Name:    col.Name,
+			ItemCnt: col.ItemCnt,
+			Ctime:   col.Ctime.UnixMilli(),
+			Utime:   col.Utime.UnixMilli(),
+		})
+	}
+	return &intrv1.ListCollectionsResponse{Collections: res}, nil
+}
+
+func (i *InteractiveServiceAdapter) ListCollectionItems(ctx context.Context, in *intrv1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*intrv1.ListCollectionItemsResponse, error) {
+	items, err := i.svc.ListCollectionItems(ctx, in.GetCid(), in.GetUid(), int(in.GetOffset()), int(in.GetLimit()))
+	if err != nil {
+		return nil, i.toStatusErr(err)
+	}
+	res := make([]*intrv1.CollectionItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, &intrv1.CollectionItem{
+			Cid:   item.Cid,
+			Biz:   item.Biz,
+			BizId: item.BizId,
+			Ctime: item.Ctime.UnixMilli(),
+		})
+	}
+	return &intrv1.ListCollectionItemsResponse{Items: res}, nil
+}
+
+// toStatusErr 和 gRPC 服务端保持一致，调用方不需要关心是本地还是远程
+func (i *InteractiveServiceAdapter) toStatusErr(err error) error {
+	if errors.Is(err, service.ErrCollectionNotFound) {
+		return status.Error(codes.NotFound, "收藏夹不存在")
+	}
+	return err
+}
@@ -0,0 +1,214 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/service"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+const maxCommentLen = 1000
+
+var _ Handler = (*CommentHandler)(nil)
+
+// CommentHandler 帖子的评论，查看不需要登录
+type CommentHandler struct {
+	svc service.CommentService
+	l   logger.Logger
+}
+
+func NewCommentHandler(svc service.CommentService, l logger.Logger) *CommentHandler {
+	return &CommentHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *CommentHandler) RegisterRouters(server *gin.Engine) {
+	g := server.Group("/comment")
+	g.POST("/create", h.Create)
+	g.POST("/delete", h.Delete)
+	g.POST("/pin", h.Pin)
+	g.POST("/unpin", h.Unpin)
+	g.GET("/list", h.List)
+	g.GET("/replies", h.Replies)
+}
+
+// Create 评论帖子，pid 不为 0 的是回复某一条评论
+func (h *CommentHandler) Create(ctx *gin.Context) {
+	type Req struct {
+		Aid     int64  `json:"aid"`
+		Pid     int64  `json:"pid"`
+		Content string `json:"content"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Aid <= 0 || req.Pid < 0 || req.Content == "" {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	if utf8.RuneCountInString(req.Content) > maxCommentLen {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: fmt.Sprintf("评论不能超过 %d 个字", maxCommentLen)})
+		return
+	}
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	id, err := h.svc.Create(ctx, domain.Comment{
+		BizId:   req.Aid,
+		Uid:     claims.Id,
+		Pid:     req.Pid,
+		Content: req.Content,
+	})
+	if err != nil {
+		h.result(ctx, err, "发表评论失败")
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Data: id})
+}
+
+// Delete 评论者本人或者帖子的作者删除评论
+func (h *CommentHandler) Delete(ctx *gin.Context) {
+	h.moderate(ctx, h.svc.Delete, "删除评论失败")
+}
+
+// Pin 帖子的作者置顶评论
+func (h *CommentHandler) Pin(ctx *gin.Context) {
+	h.moderate(ctx, h.svc.Pin, "置顶评论失败")
+}
+
+// Unpin 帖子的作者取消置顶
+func (h *CommentHandler) Unpin(ctx *gin.Context) {
+	h.moderate(ctx, h.svc.Unpin, "取消置顶失败")
+}
+
+// List 帖子的根评论，第一页置顶的在最前面，其余的新的在前
+func (h *CommentHandler) List(ctx *gin.Context) {
+	type Req struct {
+		Aid    int64 `form:"aid"`
+		Cursor int64 `form:"cursor"`
+		Limit  int   `form:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Aid <= 0 || req.Cursor < 0 || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	cmts, err := h.svc.ListRoots(ctx, req.Aid, req.Cursor, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("获取评论失败", logger.Int64("aid", req.Aid), logger.Error(err))
+		return
+	}
+	// 置顶评论不算在分页里面
+	normal := slice.FilterMap[domain.Comment, domain.Comment](cmts, func(idx int, src domain.Comment) (domain.Comment, bool) {
+		return src, !src.Pinned
+	})
+	ctx.JSON(http.StatusOK, Result{Data: h.toPageVO(cmts, nextCommentCursor(normal, req.Limit))})
+}
+
+// Replies 根评论下面的回复，先回复的在前
+func (h *CommentHandler) Replies(ctx *gin.Context) {
+	type Req struct {
+		RootId int64 `form:"root_id"`
+		Cursor int64 `form:"cursor"`
+		Limit  int   `form:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.RootId <= 0 || req.Cursor < 0 || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	cmts, err := h.svc.ListReplies(ctx, req.RootId, req.Cursor, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("获取回复失败", logger.Int64("root_id", req.RootId), logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Data: h.toPageVO(cmts, nextCommentCursor(cmts, req.Limit))})
+}
+
+// moderate 删除、置顶和取消置顶的参数和结果处理都是一样的
+func (h *CommentHandler) moderate(ctx *gin.Context,
+	fn func(ctx context.Context, id int64, uid int64) error, msg string) {
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Id <= 0 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	if err := fn(ctx, req.Id, claims.Id); err != nil {
+		h.result(ctx, err, msg)
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+}
+
+func (h *CommentHandler) result(ctx *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrArticleNotFound):
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "帖子不存在"})
+	case errors.Is(err, service.ErrCommentNotFound):
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "评论不存在"})
+	case errors.Is(err, service.ErrCommentPermissionDenied):
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "没有权限"})
+	case errors.Is(err, service.ErrCommentNotRoot):
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "只能置顶根评论"})
+	default:
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error(msg, logger.Error(err))
+	}
+}
+
+func (h *CommentHandler) toPageVO(cmts []domain.Comment, next int64) CommentPageVO {
+	return CommentPageVO{
+		Comments: slice.Map[domain.Comment, CommentVO](cmts, func(idx int, src domain.Comment) CommentVO {
+			return CommentVO{
+				Id:       src.Id,
+				Uid:      src.Uid,
+				RootId:   src.RootId,
+				Pid:      src.Pid,
+				Content:  src.Content,
+				Pinned:   src.Pinned,
+				ReplyCnt: src.ReplyCnt,
+				Ctime:    src.Ctime.Format(time.DateTime),
+			}
+		}),
+		Next: next,
+	}
+}
+
+// nextCommentCursor 不满一页说明没有下一页了
+func nextCommentCursor(cmts []domain.Comment, limit int) int64 {
+	if len(cmts) < limit || len(cmts) == 0 {
+		return 0
+	}
+	return cmts[len(cmts)-1].Id
+}
@@ -0,0 +1,113 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/service"
+	svcmocks "github.com/mrhelloboy/wehook/internal/service/mocks"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+func TestCommentHandler_List(t *testing.T) {
+	ctime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local)
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) service.CommentService
+		query   string
+		wantRes Result
+	}{
+		{
+			name: "置顶评论不算在分页里面",
+			mock: func(ctrl *gomock.Controller) service.CommentService {
+				svc := svcmocks.NewMockCommentService(ctrl)
+				svc.EXPECT().ListRoots(gomock.Any(), int64(1), int64(0), 2).Return([]domain.Comment{
+					{Id: 3, Uid: 10, Content: "置顶", Pinned: true, Ctime: ctime},
+					{Id: 5, Uid: 11, Content: "最新", ReplyCnt: 2, Ctime: ctime},
+					{Id: 4, Uid: 12, Content: "第二", Ctime: ctime},
+				}, nil)
+				return svc
+			},
+			query: "aid=1&limit=2",
+			wantRes: Result{Data: map[string]any{
+				"comments": []any{
+					map[string]any{"id": float64(3), "uid": float64(10), "root_id": float64(0), "pid": float64(0),
+						"content": "置顶", "pinned": true, "reply_cnt": float64(0), "ctime": "2024-01-02 03:04:05"},
+					map[string]any{"id": float64(5), "uid": float64(11), "root_id": float64(0), "pid": float64(0),
+						"content": "最新", "pinned": false, "reply_cnt": float64(2), "ctime": "2024-01-02 03:04:05"},
+					map[string]any{"id": float64(4), "uid": float64(12), "root_id": float64(0), "pid": float64(0),
+						"content": "第二", "pinned": false, "reply_cnt": float64(0), "ctime": "2024-01-02 03:04:05"},
+				},
+				"next": float64(4),
+			}},
+		},
+		{
+			name: "最后一页",
+			mock: func(ctrl *gomock.Controller) service.CommentService {
+				svc := svcmocks.NewMockCommentService(ctrl)
+				svc.EXPECT().ListRoots(gomock.Any(), int64(1), int64(4), 2).Return([]domain.Comment{
+					{Id: 2, Uid: 11, Content: "最早", Ctime: ctime},
+				}, nil)
+				return svc
+			},
+			query: "aid=1&cursor=4&limit=2",
+			wantRes: Result{Data: map[string]any{
+				"comments": []any{
+					map[string]any{"id": float64(2), "uid": float64(11), "root_id": float64(0), "pid": float64(0),
+						"content": "最早", "pinned": false, "reply_cnt": float64(0), "ctime": "2024-01-02 03:04:05"},
+				},
+				"next": float64(0),
+			}},
+		},
+		{
+			name: "缺少帖子 id",
+			mock: func(ctrl *gomock.Controller) service.CommentService {
+				return svcmocks.NewMockCommentService(ctrl)
+			},
+			query:   "limit=2",
+			wantRes: Result{Code: 4, Msg: "参数错误"},
+		},
+		{
+			name: "查询失败",
+			mock: func(ctrl *gomock.Controller) service.CommentService {
+				svc := svcmocks.NewMockCommentService(ctrl)
+				svc.EXPECT().ListRoots(gomock.Any(), int64(1), int64(0), 2).Return(nil, errors.New("db error"))
+				return svc
+			},
+			query:   "aid=1&limit=2",
+			wantRes: Result{Code: 5, Msg: "系统错误"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			h := NewCommentHandler(tc.mock(ctrl), &logger.NopLogger{})
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodGet, "/comment/list?"+tc.query, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package web
+
+type CommentVO struct {
+	Id      int64  `json:"id"`
+	Uid     int64  `json:"uid"`
+	RootId  int64  `json:"root_id"`
+	Pid     int64  `json:"pid"`
+	Content string `json:"content"`
+	Pinned  bool   `json:"pinned"`
+	// ReplyCnt 只有根评论有
+	ReplyCnt int64  `json:"reply_cnt"`
+	Ctime    string `json:"ctime"`
+}
+
+// CommentPageVO 按照 id 翻页，Next 是下一页的游标，为 0 表示没有下一页了
+type CommentPageVO struct {
+	Comments []CommentVO `json:"comments"`
+	Next     int64       `json:"next"`
+}
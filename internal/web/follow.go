@@ -0,0 +1,210 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/service"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+var _ Handler = (*FollowHandler)(nil)
+
+// FollowHandler 关注关系和关注流，粉丝和关注列表不需要登录
+type FollowHandler struct {
+	svc     service.FollowService
+	feedSvc service.FeedService
+	l       logger.Logger
+}
+
+func NewFollowHandler(svc service.FollowService, feedSvc service.FeedService, l logger.Logger) *FollowHandler {
+	return &FollowHandler{
+		svc:     svc,
+		feedSvc: feedSvc,
+		l:       l,
+	}
+}
+
+func (h *FollowHandler) RegisterRouters(server *gin.Engine) {
+	g := server.Group("/follow")
+	g.POST("/follow", h.Follow)
+	g.POST("/cancel", h.Cancel)
+	g.GET("/followers", h.Followers)
+	g.GET("/followees", h.Followees)
+	g.GET("/statics", h.Statics)
+	server.GET("/feed/following", h.Feed)
+}
+
+// Follow 关注，已经关注过的也返回成功
+func (h *FollowHandler) Follow(ctx *gin.Context) {
+	type Req struct {
+		Followee int64 `json:"followee"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Followee <= 0 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	err := h.svc.Follow(ctx, claims.Id, req.Followee)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+	case errors.Is(err, service.ErrFollowSelf):
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "不能关注自己"})
+	case errors.Is(err, service.ErrUserNotFound):
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "用户不存在"})
+	default:
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("关注失败", logger.Int64("followee", req.Followee), logger.Error(err))
+	}
+}
+
+// Cancel 取消关注，没有关注过的也返回成功
+func (h *FollowHandler) Cancel(ctx *gin.Context) {
+	type Req struct {
+		Followee int64 `json:"followee"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Followee <= 0 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	if err := h.svc.Unfollow(ctx, claims.Id, req.Followee); err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("取消关注失败", logger.Int64("followee", req.Followee), logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+}
+
+// Followers 某个人的粉丝，最近关注的在前
+func (h *FollowHandler) Followers(ctx *gin.Context) {
+	h.list(ctx, h.svc.ListFollowers, "获取粉丝列表失败")
+}
+
+// Followees 某个人关注的人，最近关注的在前
+func (h *FollowHandler) Followees(ctx *gin.Context) {
+	h.list(ctx, h.svc.ListFollowees, "获取关注列表失败")
+}
+
+// Statics 某个人的粉丝数、关注数，以及当前用户有没有关注他
+func (h *FollowHandler) Statics(ctx *gin.Context) {
+	type Req struct {
+		Uid int64 `form:"uid"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Uid <= 0 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	statics, err := h.svc.Statics(ctx, req.Uid)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("获取关注计数失败", logger.Int64("uid", req.Uid), logger.Error(err))
+		return
+	}
+	followed, err := h.svc.Followed(ctx, claims.Id, req.Uid)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("获取关注关系失败", logger.Int64("uid", req.Uid), logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Data: FollowStaticsVO{
+		Followers: statics.Followers,
+		Followees: statics.Followees,
+		Followed:  followed,
+	}})
+}
+
+// Feed 关注的人发表的文章，新的在前（只显示摘要），按照游标翻页
+func (h *FollowHandler) Feed(ctx *gin.Context) {
+	type Req struct {
+		Cursor string `form:"cursor"`
+		Limit  int    `form:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	cursor, err := decodeArticleCursor(req.Cursor)
+	if err != nil || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	arts, err := h.feedSvc.Following(ctx, claims.Id, cursor, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("获取关注流失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Data: toPubPageVO(arts, req.Limit)})
+}
+
+// list 粉丝列表和关注列表的参数和结果是一样的
+func (h *FollowHandler) list(ctx *gin.Context,
+	fn func(ctx context.Context, uid int64, maxId int64, limit int) ([]domain.FollowRelation, error), msg string) {
+	type Req struct {
+		Uid    int64 `form:"uid"`
+		Cursor int64 `form:"cursor"`
+		Limit  int   `form:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Uid <= 0 || req.Cursor < 0 || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	rs, err := fn(ctx, req.Uid, req.Cursor, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error(msg, logger.Int64("uid", req.Uid), logger.Error(err))
+		return
+	}
+	var next int64
+	if len(rs) > 0 && len(rs) == req.Limit {
+		next = rs[len(rs)-1].Id
+	}
+	ctx.JSON(http.StatusOK, Result{Data: FollowPageVO{
+		Relations: slice.Map[domain.FollowRelation, FollowRelationVO](rs, func(idx int, src domain.FollowRelation) FollowRelationVO {
+			return FollowRelationVO{
+				Follower: src.Follower,
+				Followee: src.Followee,
+				Ctime:    src.Ctime.Format(time.DateTime),
+			}
+		}),
+		Next: next,
+	}})
+}
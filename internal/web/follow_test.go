@@ -0,0 +1,96 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mrhelloboy/wehook/internal/service"
+	svcmocks "github.com/mrhelloboy/wehook/internal/service/mocks"
+	ijwt "github.com/mrhelloboy/wehook/internal/web/jwt"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+func TestFollowHandler_Follow(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) service.FollowService
+		reqBody string
+		wantRes Result
+	}{
+		{
+			name: "关注成功",
+			mock: func(ctrl *gomock.Controller) service.FollowService {
+				svc := svcmocks.NewMockFollowService(ctrl)
+				svc.EXPECT().Follow(gomock.Any(), int64(123), int64(456)).Return(nil)
+				return svc
+			},
+			reqBody: `{"followee":456}`,
+			wantRes: Result{Msg: "OK"},
+		},
+		{
+			name: "关注自己",
+			mock: func(ctrl *gomock.Controller) service.FollowService {
+				svc := svcmocks.NewMockFollowService(ctrl)
+				svc.EXPECT().Follow(gomock.Any(), int64(123), int64(123)).Return(service.ErrFollowSelf)
+				return svc
+			},
+			reqBody: `{"followee":123}`,
+			wantRes: Result{Code: 4, Msg: "不能关注自己"},
+		},
+		{
+			name: "用户不存在",
+			mock: func(ctrl *gomock.Controller) service.FollowService {
+				svc := svcmocks.NewMockFollowService(ctrl)
+				svc.EXPECT().Follow(gomock.Any(), int64(123), int64(456)).Return(service.ErrUserNotFound)
+				return svc
+			},
+			reqBody: `{"followee":456}`,
+			wantRes: Result{Code: 4, Msg: "用户不存在"},
+		},
+		{
+			name: "关注失败",
+			mock: func(ctrl *gomock.Controller) service.FollowService {
+				svc := svcmocks.NewMockFollowService(ctrl)
+				svc.EXPECT().Follow(gomock.Any(), int64(123), int64(456)).Return(errors.New("db error"))
+				return svc
+			},
+			reqBody: `{"followee":456}`,
+			wantRes: Result{Code: 5, Msg: "系统错误"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("claims", &ijwt.UserClaims{Id: 123})
+			})
+			h := NewFollowHandler(tc.mock(ctrl), svcmocks.NewMockFeedService(ctrl), &logger.NopLogger{})
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/follow/follow", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
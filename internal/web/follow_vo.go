@@ -0,0 +1,20 @@
+package web
+
+type FollowRelationVO struct {
+	Follower int64  `json:"follower"`
+	Followee int64  `json:"followee"`
+	Ctime    string `json:"ctime"`
+}
+
+// FollowPageVO 按照关注的先后翻页，Next 是下一页的游标，为 0 表示没有下一页了
+type FollowPageVO struct {
+	Relations []FollowRelationVO `json:"relations"`
+	Next      int64              `json:"next"`
+}
+
+type FollowStaticsVO struct {
+	Followers int64 `json:"followers"`
+	Followees int64 `json:"followees"`
+	// Followed 当前用户是否关注了这个人
+	Followed bool `json:"followed"`
+}
@@ -0,0 +1,127 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/service"
+	ijwt "github.com/mrhelloboy/wehook/internal/web/jwt"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+var _ Handler = (*HistoryHandler)(nil)
+
+// HistoryHandler 阅读记录，只能看到和操作自己的
+type HistoryHandler struct {
+	svc service.HistoryService
+	l   logger.Logger
+}
+
+func NewHistoryHandler(svc service.HistoryService, l logger.Logger) *HistoryHandler {
+	return &HistoryHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *HistoryHandler) RegisterRouters(server *gin.Engine) {
+	g := server.Group("/history")
+	g.POST("/list", h.List)
+	g.POST("/delete", h.Delete)
+	g.POST("/clear", h.Clear)
+}
+
+// List 分页获取阅读记录，最近读过的在前面
+func (h *HistoryHandler) List(ctx *gin.Context) {
+	type Req struct {
+		Offset int `json:"offset"`
+		Limit  int `json:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Offset < 0 || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+
+	c := ctx.MustGet("claims")
+	claims, ok := c.(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("未发现用户的 session 信息")
+		return
+	}
+
+	res, err := h.svc.List(ctx, claims.Id, req.Offset, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("获取阅读记录失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: slice.Map[domain.HistoryRecord, HistoryRecordVO](res, func(idx int, src domain.HistoryRecord) HistoryRecordVO {
+			return HistoryRecordVO{
+				Biz:      src.Biz,
+				BizId:    src.BizId,
+				ReadTime: src.ReadTime.Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+// Delete 删除一条阅读记录
+func (h *HistoryHandler) Delete(ctx *gin.Context) {
+	type Req struct {
+		Biz   string `json:"biz"`
+		BizId int64  `json:"biz_id"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Biz == "" || req.BizId <= 0 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+
+	c := ctx.MustGet("claims")
+	claims, ok := c.(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("未发现用户的 session 信息")
+		return
+	}
+
+	err := h.svc.Delete(ctx, claims.Id, req.Biz, req.BizId)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("删除阅读记录失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+}
+
+// Clear 清空自己的阅读记录
+func (h *HistoryHandler) Clear(ctx *gin.Context) {
+	c := ctx.MustGet("claims")
+	claims, ok := c.(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("未发现用户的 session 信息")
+		return
+	}
+
+	err := h.svc.Clear(ctx, claims.Id)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("清空阅读记录失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+}
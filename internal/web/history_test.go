@@ -0,0 +1,244 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/service"
+	svcmocks "github.com/mrhelloboy/wehook/internal/service/mocks"
+	ijwt "github.com/mrhelloboy/wehook/internal/web/jwt"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+func TestHistoryHandler_List(t *testing.T) {
+	readTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local)
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) service.HistoryService
+		reqBody  string
+		wantCode int
+		wantRes  Result
+	}{
+		{
+			name: "获取成功",
+			mock: func(ctrl *gomock.Controller) service.HistoryService {
+				svc := svcmocks.NewMockHistoryService(ctrl)
+				svc.EXPECT().List(gomock.Any(), int64(123), 0, 10).Return([]domain.HistoryRecord{
+					{Uid: 123, Biz: "article", BizId: 1, ReadTime: readTime},
+				}, nil)
+				return svc
+			},
+			reqBody:  `{"offset":0,"limit":10}`,
+			wantCode: 200,
+			wantRes: Result{Data: []any{
+				map[string]any{"biz": "article", "biz_id": float64(1), "read_time": "2024-01-02 03:04:05"},
+			}},
+		},
+		{
+			name: "limit 不合法",
+			mock: func(ctrl *gomock.Controller) service.HistoryService {
+				return svcmocks.NewMockHistoryService(ctrl)
+			},
+			reqBody:  `{"offset":0,"limit":0}`,
+			wantCode: 200,
+			wantRes:  Result{Code: 4, Msg: "参数错误"},
+		},
+		{
+			name: "查询失败",
+			mock: func(ctrl *gomock.Controller) service.HistoryService {
+				svc := svcmocks.NewMockHistoryService(ctrl)
+				svc.EXPECT().List(gomock.Any(), int64(123), 0, 10).Return(nil, errors.New("db error"))
+				return svc
+			},
+			reqBody:  `{"offset":0,"limit":10}`,
+			wantCode: 200,
+			wantRes:  Result{Code: 5, Msg: "系统错误"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("claims", &ijwt.UserClaims{Id: 123})
+			})
+			h := NewHistoryHandler(tc.mock(ctrl), &logger.NopLogger{})
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/history/list", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, tc.wantCode, resp.Code)
+			if resp.Code != 200 {
+				return
+			}
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestHistoryHandler_Delete(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) service.HistoryService
+		reqBody  string
+		wantCode int
+		wantRes  Result
+	}{
+		{
+			name: "删除成功",
+			mock: func(ctrl *gomock.Controller) service.HistoryService {
+				svc := svcmocks.NewMockHistoryService(ctrl)
+				svc.EXPECT().Delete(gomock.Any(), int64(123), "article", int64(1)).Return(nil)
+				return svc
+			},
+			reqBody:  `{"biz":"article","biz_id":1}`,
+			wantCode: 200,
+			wantRes:  Result{Msg: "OK"},
+		},
+		{
+			name: "biz 为空",
+			mock: func(ctrl *gomock.Controller) service.HistoryService {
+				return svcmocks.NewMockHistoryService(ctrl)
+			},
+			reqBody:  `{"biz":"","biz_id":1}`,
+			wantCode: 200,
+			wantRes:  Result{Code: 4, Msg: "参数错误"},
+		},
+		{
+			name: "biz_id 不合法",
+			mock: func(ctrl *gomock.Controller) service.HistoryService {
+				return svcmocks.NewMockHistoryService(ctrl)
+			},
+			reqBody:  `{"biz":"article","biz_id":0}`,
+			wantCode: 200,
+			wantRes:  Result{Code: 4, Msg: "参数错误"},
+		},
+		{
+			name: "Bind 失败",
+			mock: func(ctrl *gomock.Controller) service.HistoryService {
+				return svcmocks.NewMockHistoryService(ctrl)
+			},
+			reqBody:  `{"biz":"article",`,
+			wantCode: 400,
+		},
+		{
+			name: "删除失败",
+			mock: func(ctrl *gomock.Controller) service.HistoryService {
+				svc := svcmocks.NewMockHistoryService(ctrl)
+				svc.EXPECT().Delete(gomock.Any(), int64(123), "article", int64(1)).Return(errors.New("db error"))
+				return svc
+			},
+			reqBody:  `{"biz":"article","biz_id":1}`,
+			wantCode: 200,
+			wantRes:  Result{Code: 5, Msg: "系统错误"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("claims", &ijwt.UserClaims{Id: 123})
+			})
+			h := NewHistoryHandler(tc.mock(ctrl), &logger.NopLogger{})
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/history/delete", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, tc.wantCode, resp.Code)
+			if resp.Code != 200 {
+				return
+			}
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestHistoryHandler_Clear(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) service.HistoryService
+		wantCode int
+		wantRes  Result
+	}{
+		{
+			name: "清空成功",
+			mock: func(ctrl *gomock.Controller) service.HistoryService {
+				svc := svcmocks.NewMockHistoryService(ctrl)
+				svc.EXPECT().Clear(gomock.Any(), int64(123)).Return(nil)
+				return svc
+			},
+			wantCode: 200,
+			wantRes:  Result{Msg: "OK"},
+		},
+		{
+			name: "清空失败",
+			mock: func(ctrl *gomock.Controller) service.HistoryService {
+				svc := svcmocks.NewMockHistoryService(ctrl)
+				svc.EXPECT().Clear(gomock.Any(), int64(123)).Return(errors.New("db error"))
+				return svc
+			},
+			wantCode: 200,
+			wantRes:  Result{Code: 5, Msg: "系统错误"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("claims", &ijwt.UserClaims{Id: 123})
+			})
+			h := NewHistoryHandler(tc.mock(ctrl), &logger.NopLogger{})
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/history/clear", nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, tc.wantCode, resp.Code)
+			if resp.Code != 200 {
+				return
+			}
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package web
+
+type HistoryRecordVO struct {
+	Biz      string `json:"biz"`
+	BizId    int64  `json:"biz_id"`
+	ReadTime string `json:"read_time"`
+}
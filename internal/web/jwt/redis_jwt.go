@@ -19,6 +19,9 @@ var (
 	RtKey = []byte("Xorxo9JJUq0v0PbqVbrRjThJXTCGORka")
 )
 
+// sessionExpiration 和 refresh token 的有效期一样，过了这个时间 ssid 就没用了
+const sessionExpiration = time.Hour * 24 * 7
+
 type RedisJWTHandler struct {
 	cmd redis.Cmdable
 }
@@ -39,13 +42,18 @@ func (h *RedisJWTHandler) SetLoginToken(ctx *gin.Context, uid int64) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	// 记下这个用户有哪些 ssid，改密码的时候要让它们都失效
+	pipe := h.cmd.TxPipeline()
+	pipe.SAdd(ctx, h.sessionsKey(uid), ssid)
+	pipe.Expire(ctx, h.sessionsKey(uid), sessionExpiration)
+	_, err = pipe.Exec(ctx)
+	return err
 }
 
 func (h *RedisJWTHandler) setRefreshToken(ctx *gin.Context, uid int64, ssid string) error {
 	claims := RefreshClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24 * 7)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(sessionExpiration)),
 		},
 		Id:   uid,
 		Ssid: ssid,
@@ -68,12 +76,29 @@ func (h *RedisJWTHandler) ClearToken(ctx *gin.Context) error {
 	ctx.Header("x-refresh-token", "")
 
 	uc := ctx.MustGet("claims").(*UserClaims)
-	err := h.cmd.Set(ctx, fmt.Sprintf("user:ssid:%s", uc.Ssid), "", time.Hour*24*7).Err()
+	pipe := h.cmd.TxPipeline()
+	pipe.Set(ctx, h.ssidKey(uc.Ssid), "", sessionExpiration)
+	pipe.SRem(ctx, h.sessionsKey(uc.Id), uc.Ssid)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (h *RedisJWTHandler) ClearAllSessions(ctx *gin.Context, uid int64) error {
+	ssids, err := h.cmd.SMembers(ctx, h.sessionsKey(uid)).Result()
+	if err != nil {
+		return err
+	}
+	pipe := h.cmd.TxPipeline()
+	for _, ssid := range ssids {
+		pipe.Set(ctx, h.ssidKey(ssid), "", sessionExpiration)
+	}
+	pipe.Del(ctx, h.sessionsKey(uid))
+	_, err = pipe.Exec(ctx)
 	return err
 }
 
 func (h *RedisJWTHandler) CheckSession(ctx *gin.Context, ssid string) error {
-	cnt, err := h.cmd.Exists(ctx, fmt.Sprintf("user:ssid:%s", ssid)).Result()
+	cnt, err := h.cmd.Exists(ctx, h.ssidKey(ssid)).Result()
 	switch {
 	case errors.Is(err, redis.Nil):
 		return nil
@@ -122,3 +147,13 @@ func (h *RedisJWTHandler) SetJWTToken(ctx *gin.Context, uid int64, ssid string)
 	fmt.Printf("-- token: %s\n", tokenStr)
 	return nil
 }
+
+// ssidKey 已经失效的 ssid
+func (h *RedisJWTHandler) ssidKey(ssid string) string {
+	return fmt.Sprintf("user:ssid:%s", ssid)
+}
+
+// sessionsKey 用户登录过的 ssid 集合
+func (h *RedisJWTHandler) sessionsKey(uid int64) string {
+	return fmt.Sprintf("user:sessions:%d", uid)
+}
@@ -5,10 +5,13 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+//go:generate mockgen -source=types.go -package=jwtmocks -destination=mocks/handler.mock.go Handler
 type Handler interface {
 	SetLoginToken(ctx *gin.Context, uid int64) error
 	SetJWTToken(ctx *gin.Context, uid int64, ssid string) error
 	ClearToken(ctx *gin.Context) error
+	// ClearAllSessions 让用户所有的登录态都失效，比如修改了密码
+	ClearAllSessions(ctx *gin.Context, uid int64) error
 	CheckSession(ctx *gin.Context, ssid string) error
 	ExtractToken(ctx *gin.Context) string
 }
@@ -0,0 +1,119 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/service"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+var _ Handler = (*NotificationHandler)(nil)
+
+// NotificationHandler 当前用户的站内通知
+type NotificationHandler struct {
+	svc service.NotificationService
+	l   logger.Logger
+}
+
+func NewNotificationHandler(svc service.NotificationService, l logger.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *NotificationHandler) RegisterRouters(server *gin.Engine) {
+	g := server.Group("/notification")
+	g.GET("/list", h.List)
+	g.POST("/read", h.Read)
+	g.POST("/read_all", h.ReadAll)
+	g.GET("/unread_cnt", h.UnreadCnt)
+}
+
+// List 最近有更新的通知在前
+func (h *NotificationHandler) List(ctx *gin.Context) {
+	type Req struct {
+		Offset int `form:"offset"`
+		Limit  int `form:"limit"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Offset < 0 || req.Limit <= 0 || req.Limit > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	ns, err := h.svc.List(ctx, claims.Id, req.Offset, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("获取通知列表失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Data: slice.Map[domain.Notification, NotificationVO](ns,
+		func(idx int, src domain.Notification) NotificationVO {
+			return toNotificationVO(src)
+		})})
+}
+
+// Read 标记已读，不是自己的通知会被忽略
+func (h *NotificationHandler) Read(ctx *gin.Context) {
+	type Req struct {
+		Ids []int64 `json:"ids"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if len(req.Ids) == 0 || len(req.Ids) > 100 {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	if err := h.svc.MarkRead(ctx, claims.Id, req.Ids); err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("标记通知已读失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+}
+
+// ReadAll 全部标记已读
+func (h *NotificationHandler) ReadAll(ctx *gin.Context) {
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	if err := h.svc.MarkAllRead(ctx, claims.Id); err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("全部标记已读失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+}
+
+// UnreadCnt 未读通知的条数，合并过的算一条
+func (h *NotificationHandler) UnreadCnt(ctx *gin.Context) {
+	claims, ok := claimsOf(ctx, h.l)
+	if !ok {
+		return
+	}
+	cnt, err := h.svc.UnreadCnt(ctx, claims.Id)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("获取未读通知数失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Data: cnt})
+}
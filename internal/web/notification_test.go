@@ -0,0 +1,105 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/service"
+	svcmocks "github.com/mrhelloboy/wehook/internal/service/mocks"
+	ijwt "github.com/mrhelloboy/wehook/internal/web/jwt"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+func TestNotificationHandler_List(t *testing.T) {
+	utime := time.Date(2023, 10, 1, 12, 0, 0, 0, time.Local)
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) service.NotificationService
+		query   string
+		wantRes Result
+	}{
+		{
+			name: "合并过的和没合并过的文案",
+			mock: func(ctrl *gomock.Controller) service.NotificationService {
+				svc := svcmocks.NewMockNotificationService(ctrl)
+				svc.EXPECT().List(gomock.Any(), int64(123), 0, 10).Return([]domain.Notification{
+					{
+						Id: 2, Uid: 123, Type: domain.NotificationTypeLike, Biz: "article", BizId: 1,
+						LastActor: 456, LastActorName: "张三", ActorCnt: 12, Utime: utime,
+					},
+					{
+						Id: 1, Uid: 123, Type: domain.NotificationTypeReply, Biz: "article", BizId: 1,
+						LastActor: 789, LastActorName: "李四", ActorCnt: 1, Read: true, Utime: utime,
+					},
+				}, nil)
+				return svc
+			},
+			query: "?offset=0&limit=10",
+			wantRes: Result{Data: []any{
+				map[string]any{
+					"id": float64(2), "type": float64(1), "biz": "article", "biz_id": float64(1),
+					"last_actor": float64(456), "last_actor_name": "张三", "actor_cnt": float64(12),
+					"text": "张三 等 12 人赞了你的帖子", "read": false, "utime": "2023-10-01 12:00:00",
+				},
+				map[string]any{
+					"id": float64(1), "type": float64(4), "biz": "article", "biz_id": float64(1),
+					"last_actor": float64(789), "last_actor_name": "李四", "actor_cnt": float64(1),
+					"text": "李四 回复了你的评论", "read": true, "utime": "2023-10-01 12:00:00",
+				},
+			}},
+		},
+		{
+			name: "参数错误",
+			mock: func(ctrl *gomock.Controller) service.NotificationService {
+				return svcmocks.NewMockNotificationService(ctrl)
+			},
+			query:   "?offset=0&limit=1000",
+			wantRes: Result{Code: 4, Msg: "参数错误"},
+		},
+		{
+			name: "查询失败",
+			mock: func(ctrl *gomock.Controller) service.NotificationService {
+				svc := svcmocks.NewMockNotificationService(ctrl)
+				svc.EXPECT().List(gomock.Any(), int64(123), 0, 10).Return(nil, errors.New("db error"))
+				return svc
+			},
+			query:   "?offset=0&limit=10",
+			wantRes: Result{Code: 5, Msg: "系统错误"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("claims", &ijwt.UserClaims{Id: 123})
+			})
+			h := NewNotificationHandler(tc.mock(ctrl), &logger.NopLogger{})
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodGet, "/notification/list"+tc.query, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
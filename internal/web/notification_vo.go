@@ -0,0 +1,57 @@
+package web
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+)
+
+type NotificationVO struct {
+	Id            int64  `json:"id"`
+	Type          uint8  `json:"type"`
+	Biz           string `json:"biz"`
+	BizId         int64  `json:"biz_id"`
+	LastActor     int64  `json:"last_actor"`
+	LastActorName string `json:"last_actor_name"`
+	ActorCnt      int64  `json:"actor_cnt"`
+	// Text 展示用的文案，比如 "张三 等 12 人赞了你的帖子"
+	Text  string `json:"text"`
+	Read  bool   `json:"read"`
+	Utime string `json:"utime"`
+}
+
+func toNotificationVO(n domain.Notification) NotificationVO {
+	return NotificationVO{
+		Id:            n.Id,
+		Type:          n.Type.ToUint8(),
+		Biz:           n.Biz,
+		BizId:         n.BizId,
+		LastActor:     n.LastActor,
+		LastActorName: n.LastActorName,
+		ActorCnt:      n.ActorCnt,
+		Text:          notificationText(n),
+		Read:          n.Read,
+		Utime:         n.Utime.Format(time.DateTime),
+	}
+}
+
+func notificationText(n domain.Notification) string {
+	var action string
+	switch n.Type {
+	case domain.NotificationTypeLike:
+		action = "赞了你的帖子"
+	case domain.NotificationTypeCollect:
+		action = "收藏了你的帖子"
+	case domain.NotificationTypeComment:
+		action = "评论了你的帖子"
+	case domain.NotificationTypeReply:
+		action = "回复了你的评论"
+	default:
+		return ""
+	}
+	if n.ActorCnt > 1 {
+		return fmt.Sprintf("%s 等 %d 人%s", n.LastActorName, n.ActorCnt, action)
+	}
+	return n.LastActorName + " " + action
+}
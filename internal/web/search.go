@@ -0,0 +1,144 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+	"github.com/mrhelloboy/wehook/internal/service"
+	ijwt "github.com/mrhelloboy/wehook/internal/web/jwt"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+var _ Handler = (*SearchHandler)(nil)
+
+// SearchHandler 文章搜索，作者搜索自己的文章，读者搜索已发表的文章
+type SearchHandler struct {
+	svc service.SearchService
+	l   logger.Logger
+}
+
+func NewSearchHandler(svc service.SearchService, l logger.Logger) *SearchHandler {
+	return &SearchHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *SearchHandler) RegisterRouters(server *gin.Engine) {
+	g := server.Group("/article")
+	g.POST("/search", h.SearchAuthor)
+	g.POST("/pub/search", h.SearchPub)
+}
+
+// SearchAuthor 作者搜索自己的文章，可以按状态过滤
+func (h *SearchHandler) SearchAuthor(ctx *gin.Context) {
+	var req SearchReq
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	q, ok := req.toQuery()
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	q.Status = domain.ArticleStatus(req.Status)
+
+	c := ctx.MustGet("claims")
+	claims, ok := c.(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("未发现用户的 session 信息")
+		return
+	}
+
+	arts, err := h.svc.SearchAuthorArticles(ctx, claims.Id, q)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("搜索作者文章失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Data: h.toVOs(arts)})
+}
+
+// SearchPub 读者搜索已发表的文章，可以限定作者
+func (h *SearchHandler) SearchPub(ctx *gin.Context) {
+	var req SearchReq
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	q, ok := req.toQuery()
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "参数错误"})
+		return
+	}
+	q.AuthorId = req.AuthorId
+
+	arts, err := h.svc.SearchPubArticles(ctx, q)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		h.l.Error("搜索已发表文章失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Data: h.toVOs(arts)})
+}
+
+func (h *SearchHandler) toVOs(arts []domain.Article) []ArticleVO {
+	return slice.Map[domain.Article, ArticleVO](arts, func(idx int, src domain.Article) ArticleVO {
+		return ArticleVO{
+			Id:       src.Id,
+			Title:    src.Title,
+			Abstract: src.Abstract,
+			Status:   src.Status.ToUint8(),
+			Ctime:    src.Ctime.Format(time.DateTime),
+			Utime:    src.Utime.Format(time.DateTime),
+		}
+	})
+}
+
+type SearchReq struct {
+	Keyword string `json:"keyword"`
+	// Status 只对作者搜索生效，0 表示全部状态
+	Status uint8 `json:"status"`
+	// AuthorId 只对读者搜索生效，0 表示全部作者
+	AuthorId int64 `json:"author_id"`
+	// Start 和 End 是更新时间的毫秒数，0 表示不限制
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+	// SortBy utime 或者 popularity，默认 utime
+	SortBy string `json:"sort_by"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+}
+
+func (r SearchReq) toQuery() (domain.ArticleSearchQuery, bool) {
+	if r.Offset < 0 || r.Limit <= 0 || r.Limit > 100 {
+		return domain.ArticleSearchQuery{}, false
+	}
+	if r.Start < 0 || r.End < 0 || (r.End > 0 && r.Start >= r.End) {
+		return domain.ArticleSearchQuery{}, false
+	}
+	q := domain.ArticleSearchQuery{
+		Keyword: r.Keyword,
+		Offset:  r.Offset,
+		Limit:   r.Limit,
+	}
+	switch r.SortBy {
+	case "", "utime":
+		q.SortBy = domain.ArticleSortByUtime
+	case "popularity":
+		q.SortBy = domain.ArticleSortByPopularity
+	default:
+		return domain.ArticleSearchQuery{}, false
+	}
+	if r.Start > 0 {
+		q.Start = time.UnixMilli(r.Start)
+	}
+	if r.End > 0 {
+		q.End = time.UnixMilli(r.End)
+	}
+	return q, true
+}
@@ -1,7 +1,24 @@
 package web
 
-import "github.com/gin-gonic/gin"
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	ijwt "github.com/mrhelloboy/wehook/internal/web/jwt"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
 
 type Handler interface {
 	RegisterRouters(server *gin.Engine)
 }
+
+// claimsOf 取出登录校验中间件放进去的用户信息，取不到的时候直接返回系统错误
+func claimsOf(ctx *gin.Context, l logger.Logger) (*ijwt.UserClaims, bool) {
+	c := ctx.MustGet("claims")
+	claims, ok := c.(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		l.Error("未发现用户的 session 信息")
+	}
+	return claims, ok
+}
@@ -3,6 +3,8 @@ package web
 import (
 	"errors"
 	"net/http"
+	"time"
+	"unicode/utf8"
 
 	"go.opentelemetry.io/otel/trace"
 
@@ -21,6 +23,27 @@ import (
 
 const biz = "login"
 
+// bizSignup 邮箱注册，验证码发到注册的邮箱上
+const bizSignup = "signup"
+
+const (
+	// bizChangePhone 换绑手机号，验证码发到新的手机号上
+	bizChangePhone = "change_phone"
+	// bizChangeEmail 换绑邮箱，验证码发到新的邮箱上
+	bizChangeEmail = "change_email"
+	// bizEditSensitive 修改手机号、邮箱、密码，验证码发到已经绑定的手机号上，没有绑定手机号的发到绑定的邮箱上
+	bizEditSensitive = "edit_sensitive"
+	// bizResetPwd 忘记密码，没有登录，验证码发到用户填的手机号或者邮箱上
+	bizResetPwd = "reset_pwd"
+)
+
+// 个人资料的长度限制，按照字符数算
+const (
+	nicknameMaxLen = 32
+	aboutMeMaxLen  = 1024
+	avatarMaxLen   = 1024
+)
+
 // 确保 UserHandler 实现了 Handler 接口
 var _ Handler = (*UserHandler)(nil)
 
@@ -61,12 +84,23 @@ func (u *UserHandler) RegisterRouters(server *gin.Engine) {
 	ug.POST("/logout", u.Logout)
 	ug.POST("/logoutJWT", u.LogoutJWT)
 	ug.POST("/signup", u.SignUp)
+	ug.POST("/signup/code/send", u.SendSignupEmailCode)
 	ug.POST("/edit", u.Edit)
+	ug.POST("/edit/phone/code/send", u.SendEditPhoneCode)
+	ug.POST("/edit/phone", u.EditPhone)
+	ug.POST("/edit/code/send", u.SendEditCode)
+	ug.POST("/edit/email/code/send", u.SendEditEmailCode)
+	ug.POST("/edit/email", u.EditEmail)
+	ug.POST("/edit/password", u.EditPassword)
+	ug.POST("/reset_pwd/code/send", u.SendResetPwdCode)
+	ug.POST("/reset_pwd", u.ResetPassword)
 	ug.GET("/profile", u.Profile)
 	ug.GET("/profileJWT", u.ProfileJWT)
 
 	ug.POST("/login_sms/code/send", u.SendLoginSmsCode)
 	ug.POST("/login_sms", u.LoginSMS)
+	ug.POST("/login_email/code/send", u.SendLoginEmailCode)
+	ug.POST("/login_email", u.LoginEmail)
 	ug.POST("/refresh_token", u.RefreshToken)
 }
 
@@ -187,6 +221,66 @@ func (u *UserHandler) SendLoginSmsCode(ctx *gin.Context) {
 	}
 }
 
+// SendSignupEmailCode 邮箱注册之前，先给注册的邮箱发验证码
+func (u *UserHandler) SendSignupEmailCode(ctx *gin.Context) {
+	type Req struct {
+		Email string `json:"email"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if !u.checkEmail(ctx, req.Email) {
+		return
+	}
+	u.sendEmailCode(ctx, bizSignup, req.Email)
+}
+
+// SendLoginEmailCode 通过邮箱验证码登录，和短信验证码登录的 biz 一样，按照邮箱区分
+func (u *UserHandler) SendLoginEmailCode(ctx *gin.Context) {
+	type Req struct {
+		Email string `json:"email"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if !u.checkEmail(ctx, req.Email) {
+		return
+	}
+	u.sendEmailCode(ctx, biz, req.Email)
+}
+
+// LoginEmail 通过邮箱验证码登录，没有注册过的直接注册
+func (u *UserHandler) LoginEmail(ctx *gin.Context) {
+	type Req struct {
+		Email string `json:"email"`
+		Code  string `json:"code"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if !u.checkEmail(ctx, req.Email) {
+		return
+	}
+	if !u.verifyCode(ctx, biz, req.Email, req.Code) {
+		return
+	}
+	user, err := u.svc.FindOrCreateByEmail(ctx, req.Email)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		zap.L().Error("通过邮箱查找或者创建用户失败", zap.Error(err))
+		return
+	}
+	if err = u.SetLoginToken(ctx, user.Id); err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		zap.L().Error("设置登录态失败", zap.Int64("uid", user.Id), zap.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Msg: "通过邮箱登录成功"})
+}
+
 // Login 登录用户
 func (u *UserHandler) Login(ctx *gin.Context) {
 	type LoginReq struct {
@@ -284,6 +378,8 @@ func (u *UserHandler) SignUp(ctx *gin.Context) {
 		Email           string `json:"email"`
 		Password        string `json:"password"`
 		ConfirmPassword string `json:"confirmPassword"`
+		// Code 发到注册邮箱上的验证码
+		Code string `json:"code"`
 	}
 
 	var req SignUpReq
@@ -321,6 +417,18 @@ func (u *UserHandler) SignUp(ctx *gin.Context) {
 		return
 	}
 
+	// 邮箱验证码校验，确认邮箱是注册的人自己的
+	ok, err = u.codeSvc.Verify(ctx, bizSignup, req.Email, req.Code)
+	if err != nil {
+		ctx.String(http.StatusOK, "系统错误")
+		zap.L().Error("校验验证码出错", zap.Error(err))
+		return
+	}
+	if !ok {
+		ctx.String(http.StatusOK, "验证码错误")
+		return
+	}
+
 	// 调用 Service 层的注册方法
 	err = u.svc.Signup(ctx.Request.Context(), domain.User{
 		Email:    req.Email,
@@ -342,13 +450,420 @@ func (u *UserHandler) SignUp(ctx *gin.Context) {
 	ctx.String(http.StatusOK, "注册成功")
 }
 
-// Edit 修改用户信息（手机、邮箱、密码的修改需要验证才能修改）
+// Edit 修改用户信息（手机、邮箱、密码的修改需要验证才能修改，见 EditPhone、EditEmail、EditPassword）
+// 昵称、生日、简介和头像整个覆盖，没有传的字段会被清空
 func (u *UserHandler) Edit(ctx *gin.Context) {
+	type Req struct {
+		Nickname string `json:"nickname"`
+		// Birthday 格式是 2006-01-02，空字符串表示不填
+		Birthday string `json:"birthday"`
+		AboutMe  string `json:"about_me"`
+		Avatar   string `json:"avatar"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Nickname == "" || utf8.RuneCountInString(req.Nickname) > nicknameMaxLen {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "昵称不能为空，并且不能超过 32 个字符"})
+		return
+	}
+	var birthday time.Time
+	if req.Birthday != "" {
+		var err error
+		birthday, err = time.ParseInLocation(time.DateOnly, req.Birthday, time.Local)
+		if err != nil || birthday.After(time.Now()) {
+			ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "生日格式不对"})
+			return
+		}
+	}
+	if utf8.RuneCountInString(req.AboutMe) > aboutMeMaxLen {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "简介不能超过 1024 个字符"})
+		return
+	}
+	if len(req.Avatar) > avatarMaxLen {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "头像地址太长"})
+		return
+	}
+	uc := ctx.MustGet("claims").(*myjwt.UserClaims)
+	err := u.svc.UpdateNonSensitiveInfo(ctx, domain.User{
+		Id:       uc.Id,
+		Nickname: req.Nickname,
+		Birthday: birthday,
+		AboutMe:  req.AboutMe,
+		Avatar:   req.Avatar,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		zap.L().Error("修改用户信息失败", zap.Int64("uid", uc.Id), zap.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+}
+
+// SendEditPhoneCode 换绑手机号，先给新的手机号发验证码
+func (u *UserHandler) SendEditPhoneCode(ctx *gin.Context) {
+	type Req struct {
+		Phone string `json:"phone"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if !u.checkPhone(ctx, req.Phone) {
+		return
+	}
+	u.sendCode(ctx, bizChangePhone, req.Phone)
+}
+
+// EditPhone 换绑手机号，Code 是发到新的手机号上的验证码。
+// 已经绑定了手机号或者邮箱的，还要 OldCode 这个发到原来的手机号或者邮箱上的验证码（见 SendEditCode），
+// 不然登录态泄露之后，别人换绑手机号再走忘记密码就能拿走账号
+func (u *UserHandler) EditPhone(ctx *gin.Context) {
+	type Req struct {
+		Phone   string `json:"phone"`
+		Code    string `json:"code"`
+		OldCode string `json:"oldCode"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if !u.checkPhone(ctx, req.Phone) {
+		return
+	}
+	uc := ctx.MustGet("claims").(*myjwt.UserClaims)
+	user, err := u.svc.Profile(ctx, uc.Id)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		zap.L().Error("查询用户失败", zap.Int64("uid", uc.Id), zap.Error(err))
+		return
+	}
+	if target, _ := editCodeTarget(user); target != "" && !u.verifyCode(ctx, bizEditSensitive, target, req.OldCode) {
+		return
+	}
+	if !u.verifyCode(ctx, bizChangePhone, req.Phone, req.Code) {
+		return
+	}
+	err = u.svc.UpdatePhone(ctx, uc.Id, req.Phone)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+	case errors.Is(err, service.ErrUserDuplicate):
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "手机号码已经绑定了其他账号"})
+	default:
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		zap.L().Error("修改手机号码失败", zap.Int64("uid", uc.Id), zap.Error(err))
+	}
+}
+
+// SendEditCode 换绑手机号、修改邮箱、修改密码之前，给已经绑定的手机号发验证码，
+// 邮箱注册的用户没有绑定手机号，就发到绑定的邮箱上
+func (u *UserHandler) SendEditCode(ctx *gin.Context) {
+	target, isEmail, ok := u.boundContact(ctx)
+	if !ok {
+		return
+	}
+	if isEmail {
+		u.sendEmailCode(ctx, bizEditSensitive, target)
+		return
+	}
+	u.sendCode(ctx, bizEditSensitive, target)
+}
+
+// SendEditEmailCode 换绑邮箱，先给新的邮箱发验证码
+func (u *UserHandler) SendEditEmailCode(ctx *gin.Context) {
+	type Req struct {
+		Email string `json:"email"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if !u.checkEmail(ctx, req.Email) {
+		return
+	}
+	u.sendEmailCode(ctx, bizChangeEmail, req.Email)
+}
+
+// EditEmail 修改邮箱，Code 是发到新的邮箱上的验证码，OldCode 是 SendEditCode 发的验证码。
+// 邮箱可以用来登录，不验证新邮箱的话，别人的邮箱就能被绑到自己的账号上
+func (u *UserHandler) EditEmail(ctx *gin.Context) {
+	type Req struct {
+		Email   string `json:"email"`
+		Code    string `json:"code"`
+		OldCode string `json:"oldCode"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if !u.checkEmail(ctx, req.Email) {
+		return
+	}
+	target, _, ok := u.boundContact(ctx)
+	if !ok {
+		return
+	}
+	if !u.verifyCode(ctx, bizEditSensitive, target, req.OldCode) {
+		return
+	}
+	if !u.verifyCode(ctx, bizChangeEmail, req.Email, req.Code) {
+		return
+	}
+	uc := ctx.MustGet("claims").(*myjwt.UserClaims)
+	err := u.svc.UpdateEmail(ctx, uc.Id, req.Email)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+	case errors.Is(err, service.ErrUserDuplicate):
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "邮箱已经绑定了其他账号"})
+	default:
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		zap.L().Error("修改邮箱失败", zap.Int64("uid", uc.Id), zap.Error(err))
+	}
+}
+
+// EditPassword 修改密码，验证码是 SendEditCode 发到已经绑定的手机号或者邮箱上的
+func (u *UserHandler) EditPassword(ctx *gin.Context) {
+	type Req struct {
+		Password        string `json:"password"`
+		ConfirmPassword string `json:"confirmPassword"`
+		Code            string `json:"code"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if !u.checkPassword(ctx, req.Password, req.ConfirmPassword) {
+		return
+	}
+	target, _, ok := u.boundContact(ctx)
+	if !ok {
+		return
+	}
+	if !u.verifyCode(ctx, bizEditSensitive, target, req.Code) {
+		return
+	}
+	uc := ctx.MustGet("claims").(*myjwt.UserClaims)
+	if err := u.svc.UpdatePassword(ctx, uc.Id, req.Password); err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		zap.L().Error("修改密码失败", zap.Int64("uid", uc.Id), zap.Error(err))
+		return
+	}
+	// 其他设备都要重新登录，当前这个设备换一个新的登录态
+	u.clearAllSessions(ctx, uc.Id)
+	if err := u.SetLoginToken(ctx, uc.Id); err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		zap.L().Error("设置登录态失败", zap.Int64("uid", uc.Id), zap.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+}
+
+// SendResetPwdCode 忘记密码，给用户填的手机号或者邮箱发验证码，不需要登录。
+// 填了邮箱的就发邮件，邮箱注册的用户没有绑定手机号也能找回密码
+func (u *UserHandler) SendResetPwdCode(ctx *gin.Context) {
+	type Req struct {
+		Phone string `json:"phone"`
+		Email string `json:"email"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	if req.Email != "" {
+		if !u.checkEmail(ctx, req.Email) {
+			return
+		}
+		u.sendEmailCode(ctx, bizResetPwd, req.Email)
+		return
+	}
+	if !u.checkPhone(ctx, req.Phone) {
+		return
+	}
+	u.sendCode(ctx, bizResetPwd, req.Phone)
+}
+
+// ResetPassword 忘记密码，通过手机或者邮箱验证码重新设置密码，所有设备都要重新登录
+func (u *UserHandler) ResetPassword(ctx *gin.Context) {
+	type Req struct {
+		Phone           string `json:"phone"`
+		Email           string `json:"email"`
+		Code            string `json:"code"`
+		Password        string `json:"password"`
+		ConfirmPassword string `json:"confirmPassword"`
+	}
+	var req Req
+	if err := ctx.Bind(&req); err != nil {
+		return
+	}
+	byEmail := req.Email != ""
+	target := req.Phone
+	if byEmail {
+		target = req.Email
+		if !u.checkEmail(ctx, req.Email) {
+			return
+		}
+	} else if !u.checkPhone(ctx, req.Phone) {
+		return
+	}
+	if !u.checkPassword(ctx, req.Password, req.ConfirmPassword) {
+		return
+	}
+	if !u.verifyCode(ctx, bizResetPwd, target, req.Code) {
+		return
+	}
+	var (
+		uid int64
+		err error
+	)
+	if byEmail {
+		uid, err = u.svc.ResetPasswordByEmail(ctx, req.Email, req.Password)
+	} else {
+		uid, err = u.svc.ResetPassword(ctx, req.Phone, req.Password)
+	}
+	switch {
+	case err == nil:
+		u.clearAllSessions(ctx, uid)
+		ctx.JSON(http.StatusOK, Result{Msg: "OK"})
+	case errors.Is(err, service.ErrUserNotFound):
+		// 验证码已经证明了手机号或者邮箱是他的，告诉他没有注册不会泄露别人的信息
+		if byEmail {
+			ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "邮箱还没有注册"})
+			return
+		}
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "手机号码还没有注册"})
+	default:
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		zap.L().Error("重置密码失败", zap.Error(err))
+	}
+}
+
+// checkPassword 两次密码不一致或者不符合密码规则的时候已经写好了响应
+func (u *UserHandler) checkPassword(ctx *gin.Context, password, confirmPassword string) bool {
+	if password != confirmPassword {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "两次密码不一致"})
+		return false
+	}
+	ok, err := u.passwordExp.MatchString(password)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		return false
+	}
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "密码必须包含数字、特殊字符，并且长度不能小于 8 位"})
+		return false
+	}
+	return true
+}
+
+// clearAllSessions 密码已经改了，登录态清理失败只记录日志，最多 7 天之后也会过期
+func (u *UserHandler) clearAllSessions(ctx *gin.Context, uid int64) {
+	if err := u.ClearAllSessions(ctx, uid); err != nil {
+		zap.L().Error("清理登录态失败", zap.Int64("uid", uid), zap.Error(err))
+	}
+}
+
+// checkPhone 手机号码不合法的时候已经写好了响应
+func (u *UserHandler) checkPhone(ctx *gin.Context, phone string) bool {
+	ok, err := u.phoneExp.MatchString(phone)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		return false
+	}
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "手机号码不合法"})
+		return false
+	}
+	return true
+}
+
+// boundContact 修改敏感信息的验证码发到哪里，isEmail 表示是邮箱。
+// 手机号和邮箱都没有绑定的时候已经写好了响应
+func (u *UserHandler) boundContact(ctx *gin.Context) (target string, isEmail bool, ok bool) {
+	uc := ctx.MustGet("claims").(*myjwt.UserClaims)
+	user, err := u.svc.Profile(ctx, uc.Id)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		zap.L().Error("查询用户失败", zap.Int64("uid", uc.Id), zap.Error(err))
+		return "", false, false
+	}
+	target, isEmail = editCodeTarget(user)
+	if target == "" {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "请先绑定手机号码或者邮箱"})
+		return "", false, false
+	}
+	return target, isEmail, true
+}
+
+// editCodeTarget 优先用绑定的手机号，没有的话用绑定的邮箱，都没有返回空字符串
+func editCodeTarget(user domain.User) (target string, isEmail bool) {
+	if user.Phone != "" {
+		return user.Phone, false
+	}
+	return user.Email, user.Email != ""
+}
+
+// checkEmail 邮箱格式不对的时候已经写好了响应
+func (u *UserHandler) checkEmail(ctx *gin.Context, email string) bool {
+	ok, err := u.emailExp.MatchString(email)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		return false
+	}
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "邮箱格式错误"})
+		return false
+	}
+	return true
+}
+
+func (u *UserHandler) sendCode(ctx *gin.Context, biz string, phone string) {
+	u.handleSendCodeErr(ctx, biz, u.codeSvc.Send(ctx, biz, phone))
+}
+
+func (u *UserHandler) sendEmailCode(ctx *gin.Context, biz string, email string) {
+	u.handleSendCodeErr(ctx, biz, u.codeSvc.SendEmail(ctx, biz, email))
+}
+
+func (u *UserHandler) handleSendCodeErr(ctx *gin.Context, biz string, err error) {
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, Result{Msg: "发送成功"})
+	case errors.Is(err, service.ErrCodeSendTooMany):
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "发送太频繁，请稍后再试"})
+	default:
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		zap.L().Error("发送验证码失败", zap.String("biz", biz), zap.Error(err))
+	}
+}
+
+// verifyCode 验证码不对的时候已经写好了响应，target 是收验证码的手机号或者邮箱
+func (u *UserHandler) verifyCode(ctx *gin.Context, biz string, target string, code string) bool {
+	ok, err := u.codeSvc.Verify(ctx, biz, target, code)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		zap.L().Error("校验验证码出错", zap.String("biz", biz), zap.Error(err))
+		return false
+	}
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{Code: 4, Msg: "验证码错误"})
+		return false
+	}
+	return true
 }
 
 // Profile 用户信息
 func (u *UserHandler) Profile(ctx *gin.Context) {
-	ctx.String(http.StatusOK, "这是你的Profile")
+	uc := ctx.MustGet("claims").(*myjwt.UserClaims)
+	user, err := u.svc.Profile(ctx, uc.Id)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{Code: 5, Msg: "系统错误"})
+		zap.L().Error("查询用户失败", zap.Int64("uid", uc.Id), zap.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{Data: toProfileVO(user)})
 }
 
 // ProfileJWT 用户信息
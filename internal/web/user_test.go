@@ -7,11 +7,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mrhelloboy/wehook/internal/domain"
 	"github.com/mrhelloboy/wehook/internal/service"
 	svcmocks "github.com/mrhelloboy/wehook/internal/service/mocks"
+	myjwt "github.com/mrhelloboy/wehook/internal/web/jwt"
+	jwtmocks "github.com/mrhelloboy/wehook/internal/web/jwt/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -20,35 +23,38 @@ import (
 func TestUserHandler_SignUp(t *testing.T) {
 	testCases := []struct {
 		name         string
-		mock         func(ctrl *gomock.Controller) service.UserService
+		mock         func(ctrl *gomock.Controller) (service.UserService, service.CodeService)
 		reqBody      string
 		wantCode     int
 		wantRespBody string
 	}{
 		{
 			name: "注册成功",
-			mock: func(ctrl *gomock.Controller) service.UserService {
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				codesvc.EXPECT().Verify(gomock.Any(), "signup", "123@qq.com", "123456").Return(true, nil)
 				usersvc := svcmocks.NewMockUserService(ctrl)
 				usersvc.EXPECT().Signup(gomock.Any(), domain.User{
 					Email:    "123@qq.com",
 					Password: "hello@world123",
 				}).Return(nil)
-				return usersvc
+				return usersvc, codesvc
 			},
 			reqBody: `
 			{
 				"email":"123@qq.com",
 				"password":"hello@world123",
-				"confirmPassword":"hello@world123"
+				"confirmPassword":"hello@world123",
+				"code":"123456"
 			}`,
 			wantCode:     http.StatusOK,
 			wantRespBody: "注册成功",
 		},
 		{
 			name: "参数错误，bind失败",
-			mock: func(ctrl *gomock.Controller) service.UserService {
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
 				usersvc := svcmocks.NewMockUserService(ctrl)
-				return usersvc
+				return usersvc, svcmocks.NewMockCodeService(ctrl)
 			},
 			reqBody: `
 			{
@@ -59,24 +65,25 @@ func TestUserHandler_SignUp(t *testing.T) {
 		},
 		{
 			name: "邮箱格式错误",
-			mock: func(ctrl *gomock.Controller) service.UserService {
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
 				usersvc := svcmocks.NewMockUserService(ctrl)
-				return usersvc
+				return usersvc, svcmocks.NewMockCodeService(ctrl)
 			},
 			reqBody: `
 			{
 				"email":"123@qq",
 				"password":"hello@world123",
-				"confirmPassword":"hello@world123"
+				"confirmPassword":"hello@world123",
+				"code":"123456"
 			}`,
 			wantCode:     http.StatusOK,
 			wantRespBody: "邮箱格式错误",
 		},
 		{
 			name: "两次密码不一致",
-			mock: func(ctrl *gomock.Controller) service.UserService {
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
 				usersvc := svcmocks.NewMockUserService(ctrl)
-				return usersvc
+				return usersvc, svcmocks.NewMockCodeService(ctrl)
 			},
 			reqBody: `
 			{
@@ -89,9 +96,9 @@ func TestUserHandler_SignUp(t *testing.T) {
 		},
 		{
 			name: "密码格式错误",
-			mock: func(ctrl *gomock.Controller) service.UserService {
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
 				usersvc := svcmocks.NewMockUserService(ctrl)
-				return usersvc
+				return usersvc, svcmocks.NewMockCodeService(ctrl)
 			},
 			reqBody: `
 			{
@@ -102,40 +109,63 @@ func TestUserHandler_SignUp(t *testing.T) {
 			wantCode:     http.StatusOK,
 			wantRespBody: "密码必须包含数字、特殊字符，并且长度不能小于 8 位",
 		},
+		{
+			name: "验证码错误",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				codesvc.EXPECT().Verify(gomock.Any(), "signup", "123@qq.com", "123456").Return(false, nil)
+				return svcmocks.NewMockUserService(ctrl), codesvc
+			},
+			reqBody: `
+			{
+				"email":"123@qq.com",
+				"password":"hello@world123",
+				"confirmPassword":"hello@world123",
+				"code":"123456"
+			}`,
+			wantCode:     http.StatusOK,
+			wantRespBody: "验证码错误",
+		},
 		{
 			name: "注册邮箱已存在",
-			mock: func(ctrl *gomock.Controller) service.UserService {
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				codesvc.EXPECT().Verify(gomock.Any(), "signup", "123@qq.com", "123456").Return(true, nil)
 				usersvc := svcmocks.NewMockUserService(ctrl)
 				usersvc.EXPECT().Signup(gomock.Any(), domain.User{
 					Email:    "123@qq.com",
 					Password: "hello@world123",
 				}).Return(service.ErrUserDuplicate)
-				return usersvc
+				return usersvc, codesvc
 			},
 			reqBody: `
 			{
 				"email":"123@qq.com",
 				"password":"hello@world123",
-				"confirmPassword":"hello@world123"
+				"confirmPassword":"hello@world123",
+				"code":"123456"
 			}`,
 			wantCode:     http.StatusOK,
 			wantRespBody: "邮箱已存在, 请换一个",
 		},
 		{
 			name: "调用Signup失败",
-			mock: func(ctrl *gomock.Controller) service.UserService {
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				codesvc.EXPECT().Verify(gomock.Any(), "signup", "123@qq.com", "123456").Return(true, nil)
 				usersvc := svcmocks.NewMockUserService(ctrl)
 				usersvc.EXPECT().Signup(gomock.Any(), domain.User{
 					Email:    "123@qq.com",
 					Password: "hello@world123",
 				}).Return(errors.New("未知错误"))
-				return usersvc
+				return usersvc, codesvc
 			},
 			reqBody: `
 			{
 				"email":"123@qq.com",
 				"password":"hello@world123",
-				"confirmPassword":"hello@world123"
+				"confirmPassword":"hello@world123",
+				"code":"123456"
 			}`,
 			wantCode:     http.StatusOK,
 			wantRespBody: "系统异常",
@@ -148,7 +178,8 @@ func TestUserHandler_SignUp(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			server := gin.Default()
-			h := NewUserHandler(tc.mock(ctrl), nil, nil, nil)
+			usersvc, codesvc := tc.mock(ctrl)
+			h := NewUserHandler(usersvc, codesvc, nil, nil)
 			h.RegisterRouters(server)
 
 			// 构建请求
@@ -171,19 +202,21 @@ func TestUserHandler_SignUp(t *testing.T) {
 func TestUserHandler_LoginSMS(t *testing.T) {
 	testCases := []struct {
 		name         string
-		mock         func(ctrl *gomock.Controller) (service.UserService, service.CodeService)
+		mock         func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler)
 		reqBody      string
 		wantCode     int
 		wantRespBody func() string
 	}{
 		{
 			name: "通过手机号码登录成功",
-			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
 				codesvc := svcmocks.NewMockCodeService(ctrl)
 				codesvc.EXPECT().Verify(gomock.Any(), "login", "18612345678", "123456").Return(true, nil)
 				usersvc := svcmocks.NewMockUserService(ctrl)
 				usersvc.EXPECT().FindOrCreate(gomock.Any(), "18612345678").Return(domain.User{Id: 1}, nil)
-				return usersvc, codesvc
+				jwtHdl := jwtmocks.NewMockHandler(ctrl)
+				jwtHdl.EXPECT().SetLoginToken(gomock.Any(), int64(1)).Return(nil)
+				return usersvc, codesvc, jwtHdl
 			},
 			reqBody:  `{"phone":"18612345678", "code":"123456"}`,
 			wantCode: http.StatusOK,
@@ -194,10 +227,10 @@ func TestUserHandler_LoginSMS(t *testing.T) {
 		},
 		{
 			name: "请求参数异常，Bind失败",
-			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
 				codesvc := svcmocks.NewMockCodeService(ctrl)
 				usersvc := svcmocks.NewMockUserService(ctrl)
-				return usersvc, codesvc
+				return usersvc, codesvc, jwtmocks.NewMockHandler(ctrl)
 			},
 			reqBody:  `{"phone":"18612345678", "code":"123456"`,
 			wantCode: http.StatusBadRequest,
@@ -207,10 +240,10 @@ func TestUserHandler_LoginSMS(t *testing.T) {
 		},
 		{
 			name: "手机号码不合法",
-			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
 				codesvc := svcmocks.NewMockCodeService(ctrl)
 				usersvc := svcmocks.NewMockUserService(ctrl)
-				return usersvc, codesvc
+				return usersvc, codesvc, jwtmocks.NewMockHandler(ctrl)
 			},
 			reqBody:  `{"phone":"1861234567", "code":"123456"}`,
 			wantCode: http.StatusOK,
@@ -221,11 +254,11 @@ func TestUserHandler_LoginSMS(t *testing.T) {
 		},
 		{
 			name: "短信验证码验证异常",
-			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
 				codesvc := svcmocks.NewMockCodeService(ctrl)
 				codesvc.EXPECT().Verify(gomock.Any(), "login", "18612345678", "123456").Return(true, errors.New("系统错误"))
 				usersvc := svcmocks.NewMockUserService(ctrl)
-				return usersvc, codesvc
+				return usersvc, codesvc, jwtmocks.NewMockHandler(ctrl)
 			},
 			reqBody:  `{"phone":"18612345678", "code":"123456"}`,
 			wantCode: http.StatusOK,
@@ -236,11 +269,11 @@ func TestUserHandler_LoginSMS(t *testing.T) {
 		},
 		{
 			name: "短信验证码错误",
-			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
 				codesvc := svcmocks.NewMockCodeService(ctrl)
 				codesvc.EXPECT().Verify(gomock.Any(), "login", "18612345678", "123456").Return(false, nil)
 				usersvc := svcmocks.NewMockUserService(ctrl)
-				return usersvc, codesvc
+				return usersvc, codesvc, jwtmocks.NewMockHandler(ctrl)
 			},
 			reqBody:  `{"phone":"18612345678", "code":"123456"}`,
 			wantCode: http.StatusOK,
@@ -251,12 +284,12 @@ func TestUserHandler_LoginSMS(t *testing.T) {
 		},
 		{
 			name: "查找或创建用户失败",
-			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
 				codesvc := svcmocks.NewMockCodeService(ctrl)
 				codesvc.EXPECT().Verify(gomock.Any(), "login", "18612345678", "123456").Return(true, nil)
 				usersvc := svcmocks.NewMockUserService(ctrl)
 				usersvc.EXPECT().FindOrCreate(gomock.Any(), "18612345678").Return(domain.User{Id: 1}, errors.New("error"))
-				return usersvc, codesvc
+				return usersvc, codesvc, jwtmocks.NewMockHandler(ctrl)
 			},
 			reqBody:  `{"phone":"18612345678", "code":"123456"}`,
 			wantCode: http.StatusOK,
@@ -275,8 +308,8 @@ func TestUserHandler_LoginSMS(t *testing.T) {
 
 			// gin server and user handler
 			server := gin.Default()
-			usersvc, codesvc := tc.mock(ctrl)
-			h := NewUserHandler(usersvc, codesvc, nil, nil)
+			usersvc, codesvc, jwtHdl := tc.mock(ctrl)
+			h := NewUserHandler(usersvc, codesvc, nil, jwtHdl)
 			h.RegisterRouters(server)
 			// request
 			req, err := http.NewRequest(http.MethodPost, "/user/login_sms", bytes.NewBuffer([]byte(tc.reqBody)))
@@ -291,3 +324,682 @@ func TestUserHandler_LoginSMS(t *testing.T) {
 		})
 	}
 }
+
+func TestUserHandler_Edit(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) service.UserService
+		reqBody string
+		wantRes Result
+	}{
+		{
+			name: "修改成功",
+			mock: func(ctrl *gomock.Controller) service.UserService {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				usersvc.EXPECT().UpdateNonSensitiveInfo(gomock.Any(), domain.User{
+					Id:       123,
+					Nickname: "张三",
+					Birthday: time.Date(1990, 1, 1, 0, 0, 0, 0, time.Local),
+					AboutMe:  "hello",
+					Avatar:   "avatar/123.png",
+				}).Return(nil)
+				return usersvc
+			},
+			reqBody: `{"nickname":"张三","birthday":"1990-01-01","about_me":"hello","avatar":"avatar/123.png"}`,
+			wantRes: Result{Msg: "OK"},
+		},
+		{
+			name: "昵称为空",
+			mock: func(ctrl *gomock.Controller) service.UserService {
+				return svcmocks.NewMockUserService(ctrl)
+			},
+			reqBody: `{"nickname":""}`,
+			wantRes: Result{Code: 4, Msg: "昵称不能为空，并且不能超过 32 个字符"},
+		},
+		{
+			name: "生日格式不对",
+			mock: func(ctrl *gomock.Controller) service.UserService {
+				return svcmocks.NewMockUserService(ctrl)
+			},
+			reqBody: `{"nickname":"张三","birthday":"1990/01/01"}`,
+			wantRes: Result{Code: 4, Msg: "生日格式不对"},
+		},
+		{
+			name: "修改失败",
+			mock: func(ctrl *gomock.Controller) service.UserService {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				usersvc.EXPECT().UpdateNonSensitiveInfo(gomock.Any(), domain.User{Id: 123, Nickname: "张三"}).
+					Return(errors.New("db error"))
+				return usersvc
+			},
+			reqBody: `{"nickname":"张三"}`,
+			wantRes: Result{Code: 5, Msg: "系统错误"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("claims", &myjwt.UserClaims{Id: 123})
+			})
+			h := NewUserHandler(tc.mock(ctrl), nil, nil, nil)
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/user/edit", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestUserHandler_EditPhone(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (service.UserService, service.CodeService)
+		reqBody string
+		wantRes Result
+	}{
+		{
+			name: "手机号和邮箱都没有绑定过，只验证新手机号",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).Return(domain.User{Id: 123}, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "change_phone", "18612345678", "123456").Return(true, nil)
+				usersvc.EXPECT().UpdatePhone(gomock.Any(), int64(123), "18612345678").Return(nil)
+				return usersvc, codesvc
+			},
+			reqBody: `{"phone":"18612345678","code":"123456"}`,
+			wantRes: Result{Msg: "OK"},
+		},
+		{
+			name: "没有绑定手机号，要验证绑定的邮箱和新手机号",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).Return(domain.User{Id: 123, Email: "123@qq.com"}, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "edit_sensitive", "123@qq.com", "654321").Return(false, nil)
+				return usersvc, codesvc
+			},
+			reqBody: `{"phone":"18612345678","code":"123456","oldCode":"654321"}`,
+			wantRes: Result{Code: 4, Msg: "验证码错误"},
+		},
+		{
+			name: "已经绑定了手机号，新旧手机号都要验证",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).Return(domain.User{Id: 123, Phone: "13812345678"}, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "edit_sensitive", "13812345678", "654321").Return(true, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "change_phone", "18612345678", "123456").Return(true, nil)
+				usersvc.EXPECT().UpdatePhone(gomock.Any(), int64(123), "18612345678").Return(nil)
+				return usersvc, codesvc
+			},
+			reqBody: `{"phone":"18612345678","code":"123456","oldCode":"654321"}`,
+			wantRes: Result{Msg: "OK"},
+		},
+		{
+			name: "原手机号的验证码错误",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).Return(domain.User{Id: 123, Phone: "13812345678"}, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "edit_sensitive", "13812345678", "").Return(false, nil)
+				return usersvc, codesvc
+			},
+			reqBody: `{"phone":"18612345678","code":"123456"}`,
+			wantRes: Result{Code: 4, Msg: "验证码错误"},
+		},
+		{
+			name: "手机号已经被别人绑定了",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).Return(domain.User{Id: 123}, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "change_phone", "18612345678", "123456").Return(true, nil)
+				usersvc.EXPECT().UpdatePhone(gomock.Any(), int64(123), "18612345678").Return(service.ErrUserDuplicate)
+				return usersvc, codesvc
+			},
+			reqBody: `{"phone":"18612345678","code":"123456"}`,
+			wantRes: Result{Code: 4, Msg: "手机号码已经绑定了其他账号"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("claims", &myjwt.UserClaims{Id: 123})
+			})
+			usersvc, codesvc := tc.mock(ctrl)
+			h := NewUserHandler(usersvc, codesvc, nil, nil)
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/user/edit/phone", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestUserHandler_EditEmail(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (service.UserService, service.CodeService)
+		reqBody string
+		wantRes Result
+	}{
+		{
+			name: "绑定的手机号和新邮箱的验证码都对，修改成功",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).Return(domain.User{Id: 123, Phone: "18612345678"}, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "edit_sensitive", "18612345678", "654321").Return(true, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "change_email", "123@qq.com", "123456").Return(true, nil)
+				usersvc.EXPECT().UpdateEmail(gomock.Any(), int64(123), "123@qq.com").Return(nil)
+				return usersvc, codesvc
+			},
+			reqBody: `{"email":"123@qq.com","code":"123456","oldCode":"654321"}`,
+			wantRes: Result{Msg: "OK"},
+		},
+		{
+			name: "没有绑定手机号，验证码发到原来的邮箱上",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).Return(domain.User{Id: 123, Email: "old@qq.com"}, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "edit_sensitive", "old@qq.com", "654321").Return(true, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "change_email", "123@qq.com", "123456").Return(true, nil)
+				usersvc.EXPECT().UpdateEmail(gomock.Any(), int64(123), "123@qq.com").Return(nil)
+				return usersvc, codesvc
+			},
+			reqBody: `{"email":"123@qq.com","code":"123456","oldCode":"654321"}`,
+			wantRes: Result{Msg: "OK"},
+		},
+		{
+			name: "手机号和邮箱都没有绑定",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).Return(domain.User{Id: 123}, nil)
+				return usersvc, svcmocks.NewMockCodeService(ctrl)
+			},
+			reqBody: `{"email":"123@qq.com","code":"123456","oldCode":"654321"}`,
+			wantRes: Result{Code: 4, Msg: "请先绑定手机号码或者邮箱"},
+		},
+		{
+			name: "手机验证码错误",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).Return(domain.User{Id: 123, Phone: "18612345678"}, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "edit_sensitive", "18612345678", "654321").Return(false, nil)
+				return usersvc, codesvc
+			},
+			reqBody: `{"email":"123@qq.com","code":"123456","oldCode":"654321"}`,
+			wantRes: Result{Code: 4, Msg: "验证码错误"},
+		},
+		{
+			name: "新邮箱的验证码错误，不能绑定别人的邮箱",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).Return(domain.User{Id: 123, Phone: "18612345678"}, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "edit_sensitive", "18612345678", "654321").Return(true, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "change_email", "123@qq.com", "123456").Return(false, nil)
+				return usersvc, codesvc
+			},
+			reqBody: `{"email":"123@qq.com","code":"123456","oldCode":"654321"}`,
+			wantRes: Result{Code: 4, Msg: "验证码错误"},
+		},
+		{
+			name: "邮箱已经被别人绑定了",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).Return(domain.User{Id: 123, Phone: "18612345678"}, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "edit_sensitive", "18612345678", "654321").Return(true, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "change_email", "123@qq.com", "123456").Return(true, nil)
+				usersvc.EXPECT().UpdateEmail(gomock.Any(), int64(123), "123@qq.com").Return(service.ErrUserDuplicate)
+				return usersvc, codesvc
+			},
+			reqBody: `{"email":"123@qq.com","code":"123456","oldCode":"654321"}`,
+			wantRes: Result{Code: 4, Msg: "邮箱已经绑定了其他账号"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("claims", &myjwt.UserClaims{Id: 123})
+			})
+			usersvc, codesvc := tc.mock(ctrl)
+			h := NewUserHandler(usersvc, codesvc, nil, nil)
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/user/edit/email", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestUserHandler_SendEditCode(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (service.UserService, service.CodeService)
+		wantRes Result
+	}{
+		{
+			name: "优先发到绑定的手机号上",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).
+					Return(domain.User{Id: 123, Phone: "18612345678", Email: "123@qq.com"}, nil)
+				codesvc.EXPECT().Send(gomock.Any(), "edit_sensitive", "18612345678").Return(nil)
+				return usersvc, codesvc
+			},
+			wantRes: Result{Msg: "发送成功"},
+		},
+		{
+			name: "没有绑定手机号，发到绑定的邮箱上",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).Return(domain.User{Id: 123, Email: "123@qq.com"}, nil)
+				codesvc.EXPECT().SendEmail(gomock.Any(), "edit_sensitive", "123@qq.com").Return(nil)
+				return usersvc, codesvc
+			},
+			wantRes: Result{Msg: "发送成功"},
+		},
+		{
+			name: "手机号和邮箱都没有绑定",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).Return(domain.User{Id: 123}, nil)
+				return usersvc, svcmocks.NewMockCodeService(ctrl)
+			},
+			wantRes: Result{Code: 4, Msg: "请先绑定手机号码或者邮箱"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("claims", &myjwt.UserClaims{Id: 123})
+			})
+			usersvc, codesvc := tc.mock(ctrl)
+			h := NewUserHandler(usersvc, codesvc, nil, nil)
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/user/edit/code/send", nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestUserHandler_EditPassword(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler)
+		reqBody string
+		wantRes Result
+	}{
+		{
+			name: "邮箱注册的用户用邮箱验证码修改密码",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				jwtHdl := jwtmocks.NewMockHandler(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).Return(domain.User{Id: 123, Email: "123@qq.com"}, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "edit_sensitive", "123@qq.com", "123456").Return(true, nil)
+				usersvc.EXPECT().UpdatePassword(gomock.Any(), int64(123), "hello@world123").Return(nil)
+				jwtHdl.EXPECT().ClearAllSessions(gomock.Any(), int64(123)).Return(nil)
+				jwtHdl.EXPECT().SetLoginToken(gomock.Any(), int64(123)).Return(nil)
+				return usersvc, codesvc, jwtHdl
+			},
+			reqBody: `{"password":"hello@world123","confirmPassword":"hello@world123","code":"123456"}`,
+			wantRes: Result{Msg: "OK"},
+		},
+		{
+			name: "验证码错误",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				usersvc.EXPECT().Profile(gomock.Any(), int64(123)).Return(domain.User{Id: 123, Phone: "18612345678"}, nil)
+				codesvc.EXPECT().Verify(gomock.Any(), "edit_sensitive", "18612345678", "123456").Return(false, nil)
+				return usersvc, codesvc, jwtmocks.NewMockHandler(ctrl)
+			},
+			reqBody: `{"password":"hello@world123","confirmPassword":"hello@world123","code":"123456"}`,
+			wantRes: Result{Code: 4, Msg: "验证码错误"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("claims", &myjwt.UserClaims{Id: 123})
+			})
+			usersvc, codesvc, jwtHdl := tc.mock(ctrl)
+			h := NewUserHandler(usersvc, codesvc, nil, jwtHdl)
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/user/edit/password", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestUserHandler_ResetPassword(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler)
+		reqBody string
+		wantRes Result
+	}{
+		{
+			name: "重置成功，所有登录态失效",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				jwtHdl := jwtmocks.NewMockHandler(ctrl)
+				codesvc.EXPECT().Verify(gomock.Any(), "reset_pwd", "18612345678", "123456").Return(true, nil)
+				usersvc.EXPECT().ResetPassword(gomock.Any(), "18612345678", "hello@world123").Return(int64(1), nil)
+				jwtHdl.EXPECT().ClearAllSessions(gomock.Any(), int64(1)).Return(nil)
+				return usersvc, codesvc, jwtHdl
+			},
+			reqBody: `{"phone":"18612345678","code":"123456","password":"hello@world123","confirmPassword":"hello@world123"}`,
+			wantRes: Result{Msg: "OK"},
+		},
+		{
+			name: "密码不符合规则",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
+				return svcmocks.NewMockUserService(ctrl), svcmocks.NewMockCodeService(ctrl), jwtmocks.NewMockHandler(ctrl)
+			},
+			reqBody: `{"phone":"18612345678","code":"123456","password":"hello","confirmPassword":"hello"}`,
+			wantRes: Result{Code: 4, Msg: "密码必须包含数字、特殊字符，并且长度不能小于 8 位"},
+		},
+		{
+			name: "验证码错误",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				codesvc.EXPECT().Verify(gomock.Any(), "reset_pwd", "18612345678", "123456").Return(false, nil)
+				return svcmocks.NewMockUserService(ctrl), codesvc, jwtmocks.NewMockHandler(ctrl)
+			},
+			reqBody: `{"phone":"18612345678","code":"123456","password":"hello@world123","confirmPassword":"hello@world123"}`,
+			wantRes: Result{Code: 4, Msg: "验证码错误"},
+		},
+		{
+			name: "手机号码没有注册",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				codesvc.EXPECT().Verify(gomock.Any(), "reset_pwd", "18612345678", "123456").Return(true, nil)
+				usersvc.EXPECT().ResetPassword(gomock.Any(), "18612345678", "hello@world123").
+					Return(int64(0), service.ErrUserNotFound)
+				return usersvc, codesvc, jwtmocks.NewMockHandler(ctrl)
+			},
+			reqBody: `{"phone":"18612345678","code":"123456","password":"hello@world123","confirmPassword":"hello@world123"}`,
+			wantRes: Result{Code: 4, Msg: "手机号码还没有注册"},
+		},
+		{
+			name: "通过邮箱重置成功，所有登录态失效",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				jwtHdl := jwtmocks.NewMockHandler(ctrl)
+				codesvc.EXPECT().Verify(gomock.Any(), "reset_pwd", "123@qq.com", "123456").Return(true, nil)
+				usersvc.EXPECT().ResetPasswordByEmail(gomock.Any(), "123@qq.com", "hello@world123").Return(int64(1), nil)
+				jwtHdl.EXPECT().ClearAllSessions(gomock.Any(), int64(1)).Return(nil)
+				return usersvc, codesvc, jwtHdl
+			},
+			reqBody: `{"email":"123@qq.com","code":"123456","password":"hello@world123","confirmPassword":"hello@world123"}`,
+			wantRes: Result{Msg: "OK"},
+		},
+		{
+			name: "邮箱格式错误",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
+				return svcmocks.NewMockUserService(ctrl), svcmocks.NewMockCodeService(ctrl), jwtmocks.NewMockHandler(ctrl)
+			},
+			reqBody: `{"email":"123","code":"123456","password":"hello@world123","confirmPassword":"hello@world123"}`,
+			wantRes: Result{Code: 4, Msg: "邮箱格式错误"},
+		},
+		{
+			name: "邮箱没有注册",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				codesvc.EXPECT().Verify(gomock.Any(), "reset_pwd", "123@qq.com", "123456").Return(true, nil)
+				usersvc.EXPECT().ResetPasswordByEmail(gomock.Any(), "123@qq.com", "hello@world123").
+					Return(int64(0), service.ErrUserNotFound)
+				return usersvc, codesvc, jwtmocks.NewMockHandler(ctrl)
+			},
+			reqBody: `{"email":"123@qq.com","code":"123456","password":"hello@world123","confirmPassword":"hello@world123"}`,
+			wantRes: Result{Code: 4, Msg: "邮箱还没有注册"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			usersvc, codesvc, jwtHdl := tc.mock(ctrl)
+			h := NewUserHandler(usersvc, codesvc, nil, jwtHdl)
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/user/reset_pwd", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestUserHandler_SendResetPwdCode(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) service.CodeService
+		reqBody string
+		wantRes Result
+	}{
+		{
+			name: "发到手机号上",
+			mock: func(ctrl *gomock.Controller) service.CodeService {
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				codesvc.EXPECT().Send(gomock.Any(), "reset_pwd", "18612345678").Return(nil)
+				return codesvc
+			},
+			reqBody: `{"phone":"18612345678"}`,
+			wantRes: Result{Msg: "发送成功"},
+		},
+		{
+			name: "发到邮箱上",
+			mock: func(ctrl *gomock.Controller) service.CodeService {
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				codesvc.EXPECT().SendEmail(gomock.Any(), "reset_pwd", "123@qq.com").Return(nil)
+				return codesvc
+			},
+			reqBody: `{"email":"123@qq.com"}`,
+			wantRes: Result{Msg: "发送成功"},
+		},
+		{
+			name: "邮箱格式错误",
+			mock: func(ctrl *gomock.Controller) service.CodeService {
+				return svcmocks.NewMockCodeService(ctrl)
+			},
+			reqBody: `{"email":"123"}`,
+			wantRes: Result{Code: 4, Msg: "邮箱格式错误"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			h := NewUserHandler(svcmocks.NewMockUserService(ctrl), tc.mock(ctrl), nil, nil)
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/user/reset_pwd/code/send", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestUserHandler_LoginEmail(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler)
+		reqBody string
+		wantRes Result
+	}{
+		{
+			name: "通过邮箱登录成功",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				jwtHdl := jwtmocks.NewMockHandler(ctrl)
+				codesvc.EXPECT().Verify(gomock.Any(), "login", "123@qq.com", "123456").Return(true, nil)
+				usersvc.EXPECT().FindOrCreateByEmail(gomock.Any(), "123@qq.com").Return(domain.User{Id: 1}, nil)
+				jwtHdl.EXPECT().SetLoginToken(gomock.Any(), int64(1)).Return(nil)
+				return usersvc, codesvc, jwtHdl
+			},
+			reqBody: `{"email":"123@qq.com","code":"123456"}`,
+			wantRes: Result{Msg: "通过邮箱登录成功"},
+		},
+		{
+			name: "邮箱格式错误",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
+				return svcmocks.NewMockUserService(ctrl), svcmocks.NewMockCodeService(ctrl), jwtmocks.NewMockHandler(ctrl)
+			},
+			reqBody: `{"email":"123@qq","code":"123456"}`,
+			wantRes: Result{Code: 4, Msg: "邮箱格式错误"},
+		},
+		{
+			name: "验证码错误",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				codesvc.EXPECT().Verify(gomock.Any(), "login", "123@qq.com", "123456").Return(false, nil)
+				return svcmocks.NewMockUserService(ctrl), codesvc, jwtmocks.NewMockHandler(ctrl)
+			},
+			reqBody: `{"email":"123@qq.com","code":"123456"}`,
+			wantRes: Result{Code: 4, Msg: "验证码错误"},
+		},
+		{
+			name: "查找或创建用户失败",
+			mock: func(ctrl *gomock.Controller) (service.UserService, service.CodeService, myjwt.Handler) {
+				usersvc := svcmocks.NewMockUserService(ctrl)
+				codesvc := svcmocks.NewMockCodeService(ctrl)
+				codesvc.EXPECT().Verify(gomock.Any(), "login", "123@qq.com", "123456").Return(true, nil)
+				usersvc.EXPECT().FindOrCreateByEmail(gomock.Any(), "123@qq.com").Return(domain.User{}, errors.New("db error"))
+				return usersvc, codesvc, jwtmocks.NewMockHandler(ctrl)
+			},
+			reqBody: `{"email":"123@qq.com","code":"123456"}`,
+			wantRes: Result{Code: 5, Msg: "系统错误"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			usersvc, codesvc, jwtHdl := tc.mock(ctrl)
+			h := NewUserHandler(usersvc, codesvc, nil, jwtHdl)
+			h.RegisterRouters(server)
+
+			req, err := http.NewRequest(http.MethodPost, "/user/login_email", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var res Result
+			err = json.NewDecoder(resp.Body).Decode(&res)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mrhelloboy/wehook/internal/domain"
+)
+
+// ProfileVO 自己的个人资料
+type ProfileVO struct {
+	Id       int64  `json:"id"`
+	Email    string `json:"email"`
+	Phone    string `json:"phone"`
+	Nickname string `json:"nickname"`
+	// Birthday 格式是 2006-01-02，没有填的是空字符串
+	Birthday string `json:"birthday"`
+	AboutMe  string `json:"about_me"`
+	Avatar   string `json:"avatar"`
+	Ctime    string `json:"ctime"`
+}
+
+func toProfileVO(u domain.User) ProfileVO {
+	var birthday string
+	if !u.Birthday.IsZero() {
+		birthday = u.Birthday.Format(time.DateOnly)
+	}
+	return ProfileVO{
+		Id:       u.Id,
+		Email:    u.Email,
+		Phone:    u.Phone,
+		Nickname: u.Nickname,
+		Birthday: birthday,
+		AboutMe:  u.AboutMe,
+		Avatar:   u.Avatar,
+		Ctime:    u.Ctime.Format(time.DateTime),
+	}
+}
@@ -0,0 +1,50 @@
+package ioc
+
+import (
+	"fmt"
+
+	events "github.com/mrhelloboy/wehook/internal/events/article"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+	daoArt "github.com/mrhelloboy/wehook/internal/repository/dao/article"
+	"github.com/mrhelloboy/wehook/internal/service"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// InitArticleDAO 配置了 contentStore 的时候，文章内容放到对象存储里面，数据库只保存 key
+func InitArticleDAO(db *gorm.DB) daoArt.AuthorDAO {
+	type Config struct {
+		// Type 为空表示内容还是放在数据库里面
+		Type string `yaml:"type"`
+		// Root 本地文件系统的根目录
+		Root string `yaml:"root"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("contentStore", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	dao := daoArt.NewGormArticleDAO(db)
+	switch cfg.Type {
+	case "":
+		return dao
+	case "local":
+		store, err := daoArt.NewLocalContentStore(cfg.Root)
+		if err != nil {
+			panic(err)
+		}
+		return daoArt.NewObjectStoreAuthorDAO(dao, store)
+	default:
+		panic(fmt.Errorf("未知的对象存储类型 %s", cfg.Type))
+	}
+}
+
+// InitArticleSvc 配置了 article.batchReadEvent 的时候批量发送阅读事件，
+// 返回的函数在关闭应用的时候调用，把还没发出去的阅读事件发送完
+func InitArticleSvc(repo article.AuthorRepository, l logger.Logger, producer events.Producer) (service.ArticleService, func()) {
+	if viper.GetBool("article.batchReadEvent") {
+		return service.NewArticleSvcV1(repo, l, producer)
+	}
+	return service.NewArticleSvc(repo, l, producer), func() {}
+}
@@ -0,0 +1,10 @@
+package ioc
+
+import (
+	"github.com/mrhelloboy/wehook/internal/service/email"
+	"github.com/mrhelloboy/wehook/internal/service/email/memory"
+)
+
+func InitEmailService() email.Service {
+	return memory.NewService()
+}
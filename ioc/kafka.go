@@ -3,6 +3,10 @@ package ioc
 import (
 	"github.com/IBM/sarama"
 	"github.com/mrhelloboy/wehook/internal/events"
+	"github.com/mrhelloboy/wehook/internal/events/article"
+	"github.com/mrhelloboy/wehook/internal/events/feed"
+	"github.com/mrhelloboy/wehook/internal/events/notification"
+	"github.com/mrhelloboy/wehook/internal/events/ranking"
 	"github.com/spf13/viper"
 )
 
@@ -32,6 +36,14 @@ func NewSyncProducer(client sarama.Client) sarama.SyncProducer {
 	return res
 }
 
-func NewConsumers() []events.Consumer {
-	return []events.Consumer{}
+func NewConsumers(rankingConsumer *ranking.RealtimeRankingConsumer,
+	historyConsumer *article.HistoryReadEventConsumer,
+	feedConsumer *feed.PublishEventConsumer,
+	notificationConsumer *notification.InteractionEventConsumer) []events.Consumer {
+	return []events.Consumer{
+		rankingConsumer,
+		historyConsumer,
+		feedConsumer,
+		notificationConsumer,
+	}
 }
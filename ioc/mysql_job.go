@@ -2,6 +2,7 @@ package ioc
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/mrhelloboy/wehook/internal/domain"
@@ -10,20 +11,59 @@ import (
 	"github.com/mrhelloboy/wehook/pkg/logger"
 )
 
-func InitScheduler(l logger.Logger, local *job.LocalFuncExecutor, svc service.JobService) *job.Scheduler {
+const (
+	// publishScheduledJob 每分钟检查一次到期的定时发表
+	publishScheduledJob = "article:publish_scheduled"
+	// purgeTrashJob 每天凌晨清理一次回收站里过期的帖子
+	purgeTrashJob = "article:purge_trash"
+)
+
+// InitScheduler 注册内置的任务，注册失败的时候返回 error，不直接 panic
+func InitScheduler(l logger.Logger, local *job.LocalFuncExecutor, svc service.JobService) (*job.Scheduler, error) {
 	res := job.NewScheduler(svc, l)
 	res.RegisterExecutor(local)
-	return res
+	jobs := []domain.Job{
+		{Name: publishScheduledJob, Executor: local.Name(), Cron: "* * * * *"},
+		{Name: purgeTrashJob, Executor: local.Name(), Cron: "0 3 * * *"},
+	}
+	for _, j := range jobs {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err := svc.AddJob(ctx, j)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("注册任务 %s 失败: %w", j.Name, err)
+		}
+	}
+	return res, nil
 }
 
-func InitLocalFuncExecutor(svc service.RankingService) *job.LocalFuncExecutor {
+func InitLocalFuncExecutor(svc service.RankingService, strategies service.RankingStrategies,
+	scheduleSvc service.ArticleScheduleService, trashSvc service.ArticleTrashService) *job.LocalFuncExecutor {
 	res := job.NewLocalFuncExecutor()
 	// 要在数据库里面插入一条记录。
 	// ranking job 的记录，通过管理任务接口来插入。
-	res.RegisterFunc("ranking", func(ctx context.Context, j domain.Job) error {
+	// 每个榜单对应一个任务，名字是 ranking:榜单名，
+	// 原来的 ranking 任务保留，计算的是综合热榜
+	rankingFunc := func(name string) func(ctx context.Context, j domain.Job) error {
+		return func(ctx context.Context, j domain.Job) error {
+			ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+			defer cancel()
+			return svc.TopN(ctx, name)
+		}
+	}
+	res.RegisterFunc("ranking", rankingFunc(service.RankingHot))
+	for name := range strategies {
+		res.RegisterFunc("ranking:"+name, rankingFunc(name))
+	}
+	res.RegisterFunc(publishScheduledJob, func(ctx context.Context, j domain.Job) error {
 		ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 		defer cancel()
-		return svc.TopN(ctx)
+		return scheduleSvc.PublishDue(ctx)
+	})
+	res.RegisterFunc(purgeTrashJob, func(ctx context.Context, j domain.Job) error {
+		ctx, cancel := context.WithTimeout(ctx, time.Minute*10)
+		defer cancel()
+		return trashSvc.PurgeExpired(ctx)
 	})
 	return res
 }
@@ -11,17 +11,29 @@ import (
 	"github.com/mrhelloboy/wehook/pkg/logger"
 )
 
-func InitRankingJob(svc service.RankingService, rlockClient *rlock.Client, l logger.Logger) *job.RankingJob {
-	return job.NewRankingJob(svc, time.Second*30, rlockClient, l)
+func InitRankingStrategies() service.RankingStrategies {
+	return service.DefaultRankingStrategies()
 }
 
-func InitJobs(l logger.Logger, rankingJob *job.RankingJob) *cron.Cron {
+// InitRankingJobs 每个榜单一个任务
+func InitRankingJobs(svc service.RankingService, strategies service.RankingStrategies,
+	rlockClient *rlock.Client, l logger.Logger) []*job.RankingJob {
+	res := make([]*job.RankingJob, 0, len(strategies))
+	for name := range strategies {
+		res = append(res, job.NewRankingJob(svc, name, time.Second*30, rlockClient, l))
+	}
+	return res
+}
+
+func InitJobs(l logger.Logger, rankingJobs []*job.RankingJob) *cron.Cron {
 	res := cron.New(cron.WithSeconds())
 	cbd := job.NewCronJobBuilder(l)
-	// 这里每三分钟一次
-	_, err := res.AddJob("0 */3 * * * ?", cbd.Build(rankingJob))
-	if err != nil {
-		panic(err)
+	for _, rankingJob := range rankingJobs {
+		// 这里每三分钟一次
+		_, err := res.AddJob("0 */3 * * * ?", cbd.Build(rankingJob))
+		if err != nil {
+			panic(err)
+		}
 	}
 	return res
 }
@@ -0,0 +1,48 @@
+package ioc
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+
+	"github.com/mrhelloboy/wehook/internal/repository/article"
+	"github.com/mrhelloboy/wehook/internal/repository/dao"
+	daoArt "github.com/mrhelloboy/wehook/internal/repository/dao/article"
+	"github.com/mrhelloboy/wehook/internal/repository/search"
+	"github.com/mrhelloboy/wehook/pkg/logger"
+)
+
+// InitArticleIndex 按照 search.type 选择文章索引：
+//   - db（默认）：索引放在数据库里面，所有实例共用，第一次部署的时候从数据库全量加载一遍
+//   - memory：进程内的索引，每次启动都从数据库全量加载一遍。
+//     实例之间不会同步，只能单实例部署，见 search.MemoryArticleIndex
+func InitArticleIndex(db *gorm.DB, artDAO daoArt.AuthorDAO, l logger.Logger) search.ArticleIndex {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if viper.GetString("search.type") == "memory" {
+		index := search.NewMemoryArticleIndex()
+		buildArticleIndex(ctx, artDAO, index, l)
+		return index
+	}
+	index := search.NewDBArticleIndex(dao.NewGORMArticleSearchDAO(db))
+	empty, err := index.Empty(ctx)
+	if err != nil {
+		l.Error("查询文章索引失败", logger.Error(err))
+		return index
+	}
+	if empty {
+		// 多个实例同时加载也没关系，写索引是幂等的
+		buildArticleIndex(ctx, artDAO, index, l)
+	}
+	return index
+}
+
+func buildArticleIndex(ctx context.Context, artDAO daoArt.AuthorDAO, index search.ArticleIndex, l logger.Logger) {
+	err := article.BuildArticleIndex(ctx, artDAO, index, 500)
+	if err != nil {
+		// 索引不完整只影响搜索，不影响启动
+		l.Error("加载文章索引失败", logger.Error(err))
+	}
+}
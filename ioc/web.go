@@ -24,12 +24,23 @@ import (
 	"github.com/spf13/viper"
 )
 
-func InitGin(mws []gin.HandlerFunc, userhdr *web.UserHandler, oauth2WechatHdl *web.OAuth2WechatHandler, articleHdl *web.ArticleHandler) *gin.Engine {
+func InitGin(mws []gin.HandlerFunc, userhdr *web.UserHandler, oauth2WechatHdl *web.OAuth2WechatHandler,
+	articleHdl *web.ArticleHandler, historyHdl *web.HistoryHandler, searchHdl *web.SearchHandler,
+	scheduleHdl *web.ArticleScheduleHandler, trashHdl *web.ArticleTrashHandler,
+	commentHdl *web.CommentHandler, followHdl *web.FollowHandler,
+	notificationHdl *web.NotificationHandler) *gin.Engine {
 	server := gin.Default()
 	server.Use(mws...)
 	userhdr.RegisterRouters(server)
 	oauth2WechatHdl.RegisterRouters(server)
 	articleHdl.RegisterRouters(server)
+	historyHdl.RegisterRouters(server)
+	searchHdl.RegisterRouters(server)
+	scheduleHdl.RegisterRouters(server)
+	trashHdl.RegisterRouters(server)
+	commentHdl.RegisterRouters(server)
+	followHdl.RegisterRouters(server)
+	notificationHdl.RegisterRouters(server)
 	(&web.ObservabilityHandler{}).RegisterRouters(server)
 	return server
 }
@@ -111,12 +122,24 @@ func corsMiddleware() gin.HandlerFunc {
 func jwtMiddleware(jwtHdl myjwt.Handler) gin.HandlerFunc {
 	return middleware.NewLoginJWTMiddlewareBuilder(jwtHdl).
 		IgnorePath("/user/signup").
+		IgnorePath("/user/signup/code/send").
 		IgnorePath("/user/loginJWT").
 		IgnorePath("/user/login_sms").
 		IgnorePath("/user/login_sms/code/send").
+		IgnorePath("/user/login_email").
+		IgnorePath("/user/login_email/code/send").
 		IgnorePath("/user/refresh_token").
+		IgnorePath("/user/reset_pwd/code/send").
+		IgnorePath("/user/reset_pwd").
 		IgnorePath("/oauth2/wechat/authurl").
 		IgnorePath("/oauth2/wechat/callback").
 		IgnorePath("/test/metric").
+		IgnorePath("/article/ranking").
+		IgnorePath("/article/pub/timeline").
+		IgnorePath("/article/pub/author").
+		IgnorePath("/comment/list").
+		IgnorePath("/comment/replies").
+		IgnorePath("/follow/followers").
+		IgnorePath("/follow/followees").
 		Build()
 }
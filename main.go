@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/mrhelloboy/wehook/ioc"
@@ -23,7 +26,10 @@ func main() {
 	initViper()
 	closeFunc := ioc.InitOTEL()
 	initPrometheus()
-	app := InitWebServer()
+	app, cleanup, err := InitWebServer()
+	if err != nil {
+		panic(err)
+	}
 
 	// kafka 在此处进行消费
 	for _, c := range app.consumers {
@@ -35,24 +41,50 @@ func main() {
 
 	// 启动定时任务
 	app.cron.Start()
+	// 启动基于 MySQL 的任务调度
+	schCtx, schCancel := context.WithCancel(context.Background())
+	go func() {
+		_ = app.scheduler.Schedule(schCtx)
+	}()
 
-	if err := app.web.Run(":8080"); err != nil {
-		panic(err)
+	server := &http.Server{
+		Addr:    ":8080",
+		Handler: app.web,
 	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			panic(err)
+		}
+	}()
 
-	// 关闭 otel
+	// 收到退出信号之后优雅退出
+	sigCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	<-sigCtx.Done()
+
+	// 先停止接收新请求，等正在处理的请求处理完
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
-	closeFunc(ctx)
+	if err := server.Shutdown(ctx); err != nil {
+		zap.L().Error("关闭 web 服务失败", zap.Error(err))
+	}
+	// 把还没发出去的阅读事件之类的发送完
+	cleanup()
 
 	// 关停定时任务
-	ctx = app.cron.Stop()
+	schCancel()
+	cronCtx := app.cron.Stop()
 	// 考虑超时强制退出，防止有些任务执行特别长的时间
 	tm := time.NewTimer(time.Minute * 10)
 	select {
 	case <-tm.C:
-	case <-ctx.Done():
+	case <-cronCtx.Done():
 	}
+
+	// 关闭 otel
+	otelCtx, otelCancel := context.WithTimeout(context.Background(), time.Minute)
+	defer otelCancel()
+	closeFunc(otelCtx)
 }
 
 func initLogger() {
@@ -0,0 +1,58 @@
+package markdownx
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+)
+
+var (
+	// md 支持 GFM（表格、删除线、任务列表、自动链接），
+	// 允许内嵌 HTML，交给 policy 做清理
+	md = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+	)
+	policy = newPolicy()
+	// strict 去掉所有的标签，只留下文字
+	strict = bluemonday.StrictPolicy()
+)
+
+// newPolicy 在 UGC 的基础上允许代码块的语言标记和任务列表的勾选框，
+// 外部链接都加上 nofollow 并且在新窗口打开
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w-]+$`)).OnElements("code")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}
+
+// Render 把 Markdown 渲染成可以直接给读者展示的 HTML，脚本、事件属性之类的都会被去掉
+func Render(src string) (string, error) {
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(src), &buf); err != nil {
+		return "", err
+	}
+	return policy.Sanitize(buf.String()), nil
+}
+
+// PlainText 去掉 Markdown 和 HTML 标记，只保留文字，连续的空白合并成一个空格。
+// 先按照 Render 的规则渲染，所以脚本之类的内容不会出现在结果里面
+func PlainText(src string) string {
+	res, err := Render(src)
+	if err != nil {
+		return src
+	}
+	res = html.UnescapeString(strict.Sanitize(res))
+	return strings.Join(strings.FieldsFunc(res, unicode.IsSpace), " ")
+}
@@ -0,0 +1,93 @@
+package markdownx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "标题和段落",
+			src:  "# 标题\n\n一段**加粗**的文字",
+			want: "<h1>标题</h1>\n<p>一段<strong>加粗</strong>的文字</p>\n",
+		},
+		{
+			name: "代码块保留语言",
+			src:  "```go\nfmt.Println(1)\n```",
+			want: "<pre><code class=\"language-go\">fmt.Println(1)\n</code></pre>\n",
+		},
+		{
+			name: "去掉脚本",
+			src:  "前面<script>alert(1)</script>后面",
+			want: "<p>前面后面</p>\n",
+		},
+		{
+			name: "去掉事件属性",
+			src:  `<img src="https://example.com/a.png" onerror="alert(1)">`,
+			want: `<img src="https://example.com/a.png">`,
+		},
+		{
+			name: "javascript 链接",
+			src:  "[点我](javascript:alert(1))",
+			want: "<p>点我</p>\n",
+		},
+		{
+			name: "外部链接",
+			src:  "[链接](https://example.com)",
+			want: `<p><a href="https://example.com" rel="nofollow noopener" target="_blank">链接</a></p>` + "\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Render(tc.src)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "纯文本",
+			src:  "没有任何标记",
+			want: "没有任何标记",
+		},
+		{
+			name: "标题、强调和列表",
+			src:  "# 标题\n\n一段**加粗**和*斜体*\n\n- 第一项\n- 第二项",
+			want: "标题 一段加粗和斜体 第一项 第二项",
+		},
+		{
+			name: "链接和图片",
+			src:  "看[这里](https://example.com) ![图片](https://example.com/a.png) <https://example.com/x>",
+			want: "看这里 https://example.com/x",
+		},
+		{
+			name: "代码",
+			src:  "用 `go run` 运行\n\n```go\nfmt.Println(1)\n```",
+			want: "用 go run 运行 fmt.Println(1)",
+		},
+		{
+			name: "HTML 只留下文字",
+			src:  "<div class=\"x\">块里面的&amp;文字</div>\n\n行内<b>加粗</b><script>alert(1)</script>",
+			want: "块里面的&文字 行内加粗",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, PlainText(tc.src))
+		})
+	}
+}
@@ -9,6 +9,9 @@ import (
 	dao2 "github.com/mrhelloboy/wehook/interactive/repository/dao"
 	service2 "github.com/mrhelloboy/wehook/interactive/service"
 	eventsArt "github.com/mrhelloboy/wehook/internal/events/article"
+	eventsFeed "github.com/mrhelloboy/wehook/internal/events/feed"
+	eventsNtf "github.com/mrhelloboy/wehook/internal/events/notification"
+	"github.com/mrhelloboy/wehook/internal/events/ranking"
 	"github.com/mrhelloboy/wehook/internal/repository"
 	"github.com/mrhelloboy/wehook/internal/repository/article"
 	"github.com/mrhelloboy/wehook/internal/repository/cache"
@@ -29,12 +32,14 @@ var interactiveSvcProvider = wire.NewSet(
 
 var rankingSvcProvider = wire.NewSet(
 	service.NewBatchRankingSrv,
+	service.NewRealtimeRankingSrv,
 	repository.NewCachedRankingRepo,
 	cache.NewRankingRedisCache,
 	cache.NewRankingLocalCache,
+	cache.NewRankingRealtimeRedisCache,
 )
 
-func InitWebServer() *App {
+func InitWebServer() (*App, func(), error) {
 	wire.Build(
 		ioc.InitDB,
 		ioc.InitRedis,
@@ -50,33 +55,72 @@ func InitWebServer() *App {
 		rankingSvcProvider,
 		ioc.InitRLockClient,
 		ioc.InitJobs,
-		ioc.InitRankingJob,
+		ioc.InitRankingJobs,
+		ioc.InitRankingStrategies,
+		ioc.InitScheduler,
+		ioc.InitLocalFuncExecutor,
 
 		// consumer
+		ranking.NewRealtimeRankingConsumer,
+		eventsArt.NewHistoryReadEventConsumer,
+		eventsFeed.NewPublishEventConsumer,
+		eventsNtf.NewInteractionEventConsumer,
 		// eventsArt.NewInteractiveReadEventConsumer,
 		// events.NewInteractiveReadEventBatchConsumer,
 		// producer
 		eventsArt.NewKafkaProducer,
 
 		dao.NewUserDAO, cache.NewUserCache, cache.NewCodeCache,
-		daoArt.NewGormArticleDAO,
+		dao.NewGORMHistoryRecordDAO,
+		dao.NewGORMCommentDAO,
+		dao.NewGORMFollowRelationDAO,
+		dao.NewGORMFeedDAO,
+		dao.NewGORMNotificationDAO,
+		dao.NewGORMJobDAO,
+		ioc.InitArticleDAO,
+		daoArt.NewGORMPublishScheduleDAO,
 		// daoArt.NewGormReaderDAO,
 		// dao.NewGormInteractiveDAO,
 
 		repository.NewUserRepository, repository.NewCachedCodeRepository,
+		repository.NewHistoryRecordRepo,
+		repository.NewCommentRepo,
+		repository.NewFollowRepo,
+		repository.NewFeedRepo,
+		repository.NewNotificationRepo,
+		repository.NewPreemptCronJobRepo,
 		// repository.NewCachedInteractiveRepo,
 		article.NewCachedAuthorRepo,
+		article.NewPublishScheduleRepo,
 		// article.NewCachedReaderRepo,
 		// cache.NewRedisInteractiveCache,
 		cache.NewRedisArticleCache,
+		ioc.InitArticleIndex,
 		service.NewUserSvc, service.NewCodeSvc,
-		service.NewArticleSvc,
+		ioc.InitArticleSvc,
+		service.NewHistoryService,
+		service.NewCommentSvc,
+		service.NewFollowSvc,
+		service.NewFeedSvc,
+		service.NewNotificationSvc,
+		service.NewArticleSearchSvc,
+		service.NewArticleScheduleSvc,
+		service.NewArticleTrashSvc,
+		service.NewCronJobSvc,
 		// service.NewInteractiveService,
 		ioc.InitOAuth2WechatService,
 		ioc.InitSMSService,
+		ioc.InitEmailService,
 		web.NewUserHandler,
 		web.NewOAuth2WechatHandler,
 		web.NewArticleHandler,
+		web.NewHistoryHandler,
+		web.NewCommentHandler,
+		web.NewFollowHandler,
+		web.NewNotificationHandler,
+		web.NewSearchHandler,
+		web.NewArticleScheduleHandler,
+		web.NewArticleTrashHandler,
 		ioc.InitGin,
 		myjwt.NewRedisJWTHandler,
 		ioc.InitMiddleware,
@@ -85,5 +129,5 @@ func InitWebServer() *App {
 		// 组装 App 这个结构体的所有字段
 		wire.Struct(new(App), "*"),
 	)
-	return new(App)
+	return new(App), nil, nil
 }
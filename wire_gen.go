@@ -12,12 +12,15 @@ import (
 	cache2 "github.com/mrhelloboy/wehook/interactive/repository/cache"
 	dao2 "github.com/mrhelloboy/wehook/interactive/repository/dao"
 	service2 "github.com/mrhelloboy/wehook/interactive/service"
-	article3 "github.com/mrhelloboy/wehook/internal/events/article"
+	article2 "github.com/mrhelloboy/wehook/internal/events/article"
+	"github.com/mrhelloboy/wehook/internal/events/feed"
+	"github.com/mrhelloboy/wehook/internal/events/notification"
+	"github.com/mrhelloboy/wehook/internal/events/ranking"
 	"github.com/mrhelloboy/wehook/internal/repository"
-	article2 "github.com/mrhelloboy/wehook/internal/repository/article"
+	"github.com/mrhelloboy/wehook/internal/repository/article"
 	"github.com/mrhelloboy/wehook/internal/repository/cache"
 	"github.com/mrhelloboy/wehook/internal/repository/dao"
-	"github.com/mrhelloboy/wehook/internal/repository/dao/article"
+	article3 "github.com/mrhelloboy/wehook/internal/repository/dao/article"
 	"github.com/mrhelloboy/wehook/internal/service"
 	"github.com/mrhelloboy/wehook/internal/web"
 	"github.com/mrhelloboy/wehook/internal/web/jwt"
@@ -30,7 +33,7 @@ import (
 
 // Injectors from wire.go:
 
-func InitWebServer() *App {
+func InitWebServer() (*App, func(), error) {
 	cmdable := ioc.InitRedis()
 	limiter := ioc.InitRateLimiterOfMiddleware(cmdable)
 	handler := jwt.NewRedisJWTHandler(cmdable)
@@ -44,39 +47,87 @@ func InitWebServer() *App {
 	codeCache := cache.NewCodeCache(cmdable)
 	codeRepository := repository.NewCachedCodeRepository(codeCache)
 	smsService := ioc.InitSMSService()
-	codeService := service.NewCodeSvc(codeRepository, smsService)
+	emailService := ioc.InitEmailService()
+	codeService := service.NewCodeSvc(codeRepository, smsService, emailService)
 	userHandler := web.NewUserHandler(userService, codeService, cmdable, handler)
 	wechatService := ioc.InitOAuth2WechatService(logger)
 	oAuth2WechatHandler := web.NewOAuth2WechatHandler(wechatService, userService, handler)
-	authorDAO := article.NewGormArticleDAO(db)
+	authorDAO := ioc.InitArticleDAO(db)
 	articleCache := cache.NewRedisArticleCache(cmdable)
-	authorRepository := article2.NewCachedAuthorRepo(authorDAO, userRepository, articleCache, logger)
+	articleIndex := ioc.InitArticleIndex(db, authorDAO, logger)
+	authorRepository := article.NewCachedAuthorRepo(authorDAO, userRepository, articleCache, articleIndex, logger)
 	client := ioc.InitKafka()
 	syncProducer := ioc.NewSyncProducer(client)
-	producer := article3.NewKafkaProducer(syncProducer)
-	articleService := service.NewArticleSvc(authorRepository, logger, producer)
+	producer := article2.NewKafkaProducer(syncProducer)
+	articleService, cleanup := ioc.InitArticleSvc(authorRepository, logger, producer)
 	clientv3Client := ioc.InitEtcd()
 	interactiveServiceClient := ioc.InitIntrGRPCClientV1(clientv3Client)
-	articleHandler := web.NewArticleHandler(articleService, interactiveServiceClient, logger)
-	engine := ioc.InitGin(v, userHandler, oAuth2WechatHandler, articleHandler)
-	v2 := ioc.NewConsumers()
 	rankingRedisCache := cache.NewRankingRedisCache(cmdable)
 	rankingLocalCache := cache.NewRankingLocalCache()
-	rankingRepository := repository.NewCachedRankingRepo(rankingRedisCache, rankingLocalCache)
-	rankingService := service.NewBatchRankingSrv(articleService, interactiveServiceClient, rankingRepository)
+	rankingRealtimeCache := cache.NewRankingRealtimeRedisCache(cmdable)
+	rankingRepository := repository.NewCachedRankingRepo(rankingRedisCache, rankingLocalCache, rankingRealtimeCache)
+	rankingStrategies := ioc.InitRankingStrategies()
+	rankingService := service.NewBatchRankingSrv(articleService, interactiveServiceClient, rankingRepository, rankingStrategies)
+	realtimeRankingService := service.NewRealtimeRankingSrv(rankingRepository, authorRepository, logger)
+	articleHandler := web.NewArticleHandler(articleService, rankingService, realtimeRankingService, interactiveServiceClient, logger)
+	historyRecordDAO := dao.NewGORMHistoryRecordDAO(db)
+	historyRecordRepository := repository.NewHistoryRecordRepo(historyRecordDAO)
+	historyService := service.NewHistoryService(historyRecordRepository)
+	historyHandler := web.NewHistoryHandler(historyService, logger)
+	searchService := service.NewArticleSearchSvc(authorRepository, interactiveServiceClient, logger)
+	searchHandler := web.NewSearchHandler(searchService, logger)
+	publishScheduleDAO := article3.NewGORMPublishScheduleDAO(db)
+	publishScheduleRepository := article.NewPublishScheduleRepo(publishScheduleDAO)
+	articleScheduleService := service.NewArticleScheduleSvc(articleService, publishScheduleRepository, logger)
+	articleScheduleHandler := web.NewArticleScheduleHandler(articleScheduleService, logger)
+	articleTrashService := service.NewArticleTrashSvc(authorRepository, interactiveServiceClient, logger)
+	articleTrashHandler := web.NewArticleTrashHandler(articleTrashService, logger)
+	commentDAO := dao.NewGORMCommentDAO(db)
+	commentRepository := repository.NewCommentRepo(commentDAO)
+	commentService := service.NewCommentSvc(commentRepository, authorRepository, interactiveServiceClient, producer, logger)
+	commentHandler := web.NewCommentHandler(commentService, logger)
+	followRelationDAO := dao.NewGORMFollowRelationDAO(db)
+	followRepository := repository.NewFollowRepo(followRelationDAO)
+	feedDAO := dao.NewGORMFeedDAO(db)
+	feedRepository := repository.NewFeedRepo(feedDAO)
+	followService := service.NewFollowSvc(followRepository, userRepository, feedRepository, logger)
+	feedService := service.NewFeedSvc(feedRepository, followRepository, authorRepository)
+	followHandler := web.NewFollowHandler(followService, feedService, logger)
+	notificationDAO := dao.NewGORMNotificationDAO(db)
+	notificationRepository := repository.NewNotificationRepo(notificationDAO)
+	notificationService := service.NewNotificationSvc(notificationRepository, authorRepository, userRepository, logger)
+	notificationHandler := web.NewNotificationHandler(notificationService, logger)
+	engine := ioc.InitGin(v, userHandler, oAuth2WechatHandler, articleHandler, historyHandler, searchHandler, articleScheduleHandler, articleTrashHandler, commentHandler, followHandler, notificationHandler)
+	realtimeRankingConsumer := ranking.NewRealtimeRankingConsumer(client, realtimeRankingService, logger)
+	historyReadEventConsumer := article2.NewHistoryReadEventConsumer(client, historyRecordRepository, logger)
+	publishEventConsumer := feed.NewPublishEventConsumer(client, feedService, logger)
+	interactionEventConsumer := notification.NewInteractionEventConsumer(client, notificationService, logger)
+	v2 := ioc.NewConsumers(realtimeRankingConsumer, historyReadEventConsumer, publishEventConsumer, interactionEventConsumer)
 	rlockClient := ioc.InitRLockClient(cmdable)
-	rankingJob := ioc.InitRankingJob(rankingService, rlockClient, logger)
-	cron := ioc.InitJobs(logger, rankingJob)
+	v3 := ioc.InitRankingJobs(rankingService, rankingStrategies, rlockClient, logger)
+	cron := ioc.InitJobs(logger, v3)
+	localFuncExecutor := ioc.InitLocalFuncExecutor(rankingService, rankingStrategies, articleScheduleService, articleTrashService)
+	jobDAO := dao.NewGORMJobDAO(db)
+	jobRepository := repository.NewPreemptCronJobRepo(jobDAO)
+	jobService := service.NewCronJobSvc(jobRepository, logger)
+	scheduler, err := ioc.InitScheduler(logger, localFuncExecutor, jobService)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
 	app := &App{
 		web:       engine,
 		consumers: v2,
 		cron:      cron,
+		scheduler: scheduler,
 	}
-	return app
+	return app, func() {
+		cleanup()
+	}, nil
 }
 
 // wire.go:
 
 var interactiveSvcProvider = wire.NewSet(service2.NewInteractiveService, repository2.NewCachedInteractiveRepo, dao2.NewGormInteractiveDAO, cache2.NewRedisInteractiveCache)
 
-var rankingSvcProvider = wire.NewSet(service.NewBatchRankingSrv, repository.NewCachedRankingRepo, cache.NewRankingRedisCache, cache.NewRankingLocalCache)
+var rankingSvcProvider = wire.NewSet(service.NewBatchRankingSrv, service.NewRealtimeRankingSrv, repository.NewCachedRankingRepo, cache.NewRankingRedisCache, cache.NewRankingLocalCache, cache.NewRankingRealtimeRedisCache)